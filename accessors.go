@@ -0,0 +1,97 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"maps"
+	"slices"
+
+	json "github.com/goccy/go-json"
+)
+
+// Links returns a copy of the envelope's links, keyed by rel. Every rel maps
+// to a slice, whether it holds one link or several, and each Link has its Rel
+// set. Links from RegisterStatic or WrapPrecomputed are included.
+//
+// The result reflects the links as added; marshal-time processing such as
+// CURIE compaction or base URL resolution is not applied. Links is meant for
+// tests and diagnostics; modifying the result does not affect the envelope.
+//
+// # Example
+//
+//	env := inst.Wrap(ctx, user)
+//	if self := env.Links()["self"]; len(self) != 1 || self[0].Href != "/users/1" {
+//	    t.Fatalf("unexpected self links %v", self)
+//	}
+func (e *Envelope) Links() map[string][]Link {
+	out := make(map[string][]Link, len(e.links))
+	if e.precomputedJSON != nil {
+		var doc struct {
+			Links map[string]json.RawMessage `json:"_links"`
+		}
+		// precomputedJSON is produced by this package and always valid.
+		_ = json.Unmarshal(e.precomputedJSON, &doc)
+		for rel, raw := range doc.Links {
+			out[rel] = append(out[rel], decodeLinks(rel, raw)...)
+		}
+	}
+	for rel, v := range e.links {
+		for _, val := range relValues(v) {
+			switch l := val.(type) {
+			case Link:
+				l.Rel = rel
+				out[rel] = append(out[rel], l)
+			case json.RawMessage:
+				out[rel] = append(out[rel], decodeLinks(rel, l)...)
+			}
+		}
+	}
+	return out
+}
+
+// HasLink reports whether the envelope has at least one link under rel.
+func (e *Envelope) HasLink(rel string) bool {
+	if _, ok := e.links[rel]; ok {
+		return true
+	}
+	if e.precomputedJSON == nil {
+		return false
+	}
+	_, ok := e.Links()[rel]
+	return ok
+}
+
+// Embedded returns a copy of the envelope's embedded resources, keyed by rel.
+// Values are the nested *Envelope, or a slice of them for rels holding
+// several resources. The map and slices are copies; the nested envelopes are
+// shared.
+func (e *Envelope) Embedded() map[string]any {
+	out := maps.Clone(e.embedded)
+	if out == nil {
+		return map[string]any{}
+	}
+	for rel, v := range out {
+		if vals, ok := v.([]any); ok {
+			out[rel] = slices.Clone(vals)
+		}
+	}
+	return out
+}
+
+// decodeLinks decodes raw, a link object or an array of them.
+func decodeLinks(rel string, raw json.RawMessage) []Link {
+	var links []Link
+	if len(raw) > 0 && raw[0] == '[' {
+		_ = json.Unmarshal(raw, &links)
+	} else {
+		var l Link
+		if json.Unmarshal(raw, &l) == nil {
+			links = []Link{l}
+		}
+	}
+	for idx := range links {
+		links[idx].Rel = rel
+	}
+	return links
+}
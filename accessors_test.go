@@ -0,0 +1,90 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestEnvelope_LinksNormalizesShape(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{
+			{Rel: "self", Href: "/users/1"},
+			{Rel: "item", Href: "/items/1"},
+			{Rel: "item", Href: "/items/2"},
+		}
+	})
+	env := inst.Wrap(context.Background(), &collectionUser{ID: 1})
+
+	want := map[string][]Link{
+		"self": {{Rel: "self", Href: "/users/1"}},
+		"item": {{Rel: "item", Href: "/items/1"}, {Rel: "item", Href: "/items/2"}},
+	}
+	if got := env.Links(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	// Accessors work the same after marshaling, and return copies.
+	_ = marshalString(t, env)
+	links := env.Links()
+	links["self"][0].Href = "/changed"
+	delete(links, "item")
+	if got := env.Links(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected links unchanged, got %v", got)
+	}
+}
+
+func TestEnvelope_LinksIncludesStatic(t *testing.T) {
+	inst := New()
+	RegisterStatic(inst, &collectionUser{}, []Link{{Rel: "self", Href: "/users"}})
+	env := inst.Wrap(context.Background(), &collectionUser{ID: 1})
+
+	if !env.HasLink("self") || env.HasLink("next") {
+		t.Fatalf("unexpected HasLink results for %v", env.Links())
+	}
+	if got := env.Links()["self"]; len(got) != 1 || !got[0].Equal(Link{Rel: "self", Href: "/users"}) {
+		t.Fatalf("expected static self link, got %v", got)
+	}
+}
+
+func TestEnvelope_HasLink(t *testing.T) {
+	env := &Envelope{Data: &collectionUser{ID: 1}}
+	if env.HasLink("self") {
+		t.Fatal("expected no links on empty envelope")
+	}
+	env.AddLink(Link{Rel: "self", Href: "/users/1"})
+	if !env.HasLink("self") {
+		t.Fatal("expected self link")
+	}
+}
+
+func TestEnvelope_EmbeddedReturnsCopy(t *testing.T) {
+	ctx := context.Background()
+	env := embeddingInstance().WrapRaw(&collectionUser{ID: 1})
+	if got := env.Embedded(); got == nil || len(got) != 0 {
+		t.Fatalf("expected empty map, got %v", got)
+	}
+
+	env.AddEmbedded(ctx, "comments", &embeddedComment{Text: "a"})
+	env.AddEmbedded(ctx, "comments", &embeddedComment{Text: "b"})
+	env.AddEmbedded(ctx, "author", &embeddedComment{Text: "c"})
+
+	embedded := env.Embedded()
+	comments, ok := embedded["comments"].([]any)
+	if !ok || len(comments) != 2 {
+		t.Fatalf("expected two comments, got %v", embedded["comments"])
+	}
+	if author, ok := embedded["author"].(*Envelope); !ok || author.Data.(*embeddedComment).Text != "c" {
+		t.Fatalf("expected author envelope, got %v", embedded["author"])
+	}
+
+	comments[0] = nil
+	delete(embedded, "author")
+	if again := env.Embedded(); again["comments"].([]any)[0] == nil || again["author"] == nil {
+		t.Fatal("expected envelope state unchanged by modifying the copy")
+	}
+}
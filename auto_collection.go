@@ -0,0 +1,70 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"reflect"
+)
+
+// WithAutoCollection makes Wrap turn slice and array data, or a pointer to
+// one, into a collection instead of failing to splice HAL members into a
+// JSON array. The returned envelope holds a CollectionPage built as by
+// Collection, with the items under DefaultItemsRel, no self link and no
+// total; links added to the envelope are merged into the page's _links.
+//
+// Slices of bytes, types that marshal themselves and types with links
+// registered are wrapped as usual.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithAutoCollection())
+//	env := inst.Wrap(ctx, users) // []User
+//	env.AddLink(hal.SelfLink("/users"))
+func WithAutoCollection() InstanceOption {
+	return func(i *Instance) {
+		i.autoCollection = true
+	}
+}
+
+// collectionItems returns data as a slice of collection items if it is a
+// slice or array, or a non-nil pointer to one, that Wrap cannot splice into.
+func (i *Instance) collectionItems(data any) (any, bool) {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if k := v.Kind(); k != reflect.Slice && k != reflect.Array {
+		return nil, false
+	}
+	if v.Type().Elem().Kind() == reflect.Uint8 || marshalsItself(v.Type()) || i.customMarshalFunc(data) != nil {
+		return nil, false
+	}
+	if v.Kind() == reflect.Array {
+		s := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), v.Len(), v.Len())
+		reflect.Copy(s, v)
+		v = s
+	}
+	return v.Interface(), true
+}
+
+// wrapCollection wraps items, as returned by collectionItems, in an
+// envelope holding their CollectionPage. An item's generator error is
+// recorded on the envelope; other failures panic like Collection.
+func (i *Instance) wrapCollection(ctx context.Context, items any) *Envelope {
+	page := i.collectionResult(i.buildCollection(ctx, items, 0, Link{}, nil))
+	delete(page.Links, "self")
+	page.TotalKnown = false
+
+	e := &Envelope{
+		Data:     page,
+		instance: i,
+		links:    make(map[string]any, defaultLinksCapacity),
+		baseURL:  i.baseURLFor(ctx),
+		rewriter: i.rewriterFor(ctx),
+		linkErr:  page.err,
+	}
+	i.finishWrap(ctx, e)
+	return e
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func autoCollectionInstance(opts ...InstanceOption) *Instance {
+	inst := New(append([]InstanceOption{WithAutoCollection()}, opts...)...)
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	return inst
+}
+
+func TestWithAutoCollection(t *testing.T) {
+	twoUsers := `{"_links":{},"_embedded":{"items":[` +
+		`{"id":1,"_links":{"self":{"href":"/users/1"}}},` +
+		`{"id":2,"_links":{"self":{"href":"/users/2"}}}]},"count":2}`
+	tests := []struct {
+		name string
+		data any
+		want string
+	}{
+		{"values", []collectionUser{{ID: 1}, {ID: 2}}, twoUsers},
+		{"pointers", []*collectionUser{{ID: 1}, nil, {ID: 2}}, twoUsers},
+		{"array", [2]collectionUser{{ID: 1}, {ID: 2}}, twoUsers},
+		{"pointer to slice", &[]collectionUser{{ID: 1}, {ID: 2}}, twoUsers},
+		{"empty array", [0]collectionUser{}, `{"_links":{},"_embedded":{"items":[]},"count":0}`},
+		{"nil slice", []collectionUser(nil), `{"_links":{},"_embedded":{"items":[]},"count":0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inst := autoCollectionInstance(WithAlwaysEmitTotal())
+			if got := marshalString(t, inst.Wrap(context.Background(), tt.data)); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithAutoCollection_AddLink(t *testing.T) {
+	env := autoCollectionInstance().Clone().Wrap(context.Background(), []collectionUser{{ID: 1}})
+	if _, ok := env.Data.(*CollectionPage); !ok {
+		t.Fatalf("expected a collection page, got %T", env.Data)
+	}
+	env.AddLink(SelfLink("/users"))
+	got := marshalString(t, env)
+	if !strings.HasPrefix(got, `{"_embedded":{"items":[`) || !strings.HasSuffix(got, `"count":1,"_links":{"self":{"href":"/users"}}}`) {
+		t.Fatalf("expected a single _links member with the self link, got %s", got)
+	}
+}
+
+func TestWithAutoCollection_Exclusions(t *testing.T) {
+	inst := autoCollectionInstance()
+	RegisterInstance(inst, func(_ context.Context, _ *[]embeddedComment) []Link { return []Link{SelfLink("/comments")} })
+	ctx := context.Background()
+
+	if env := inst.Wrap(ctx, []byte(`{"a":1}`)); !isBytes(env.Data) {
+		t.Errorf("expected byte slices to be wrapped as usual, got %T", env.Data)
+	}
+	if env := inst.Wrap(ctx, &[]embeddedComment{{Text: "a"}}); len(env.Links()["self"]) != 1 {
+		t.Errorf("expected the registered generator to run, got %T", env.Data)
+	}
+}
+
+func isBytes(v any) bool {
+	_, ok := v.([]byte)
+	return ok
+}
+
+func TestStrictMode_SliceData(t *testing.T) {
+	inst := New(WithStrictMode())
+	_, err := inst.WrapE(context.Background(), []collectionUser{{ID: 1}})
+	if !errors.Is(err, ErrNonObjectData) || !strings.Contains(err.Error(), "use Collection") {
+		t.Fatalf("expected an error pointing to Collection, got %v", err)
+	}
+
+	env, err := autoCollectionInstance(WithStrictMode()).WrapE(context.Background(), []collectionUser{{ID: 1}})
+	if err != nil {
+		t.Fatalf("expected the slice to become a collection, got %v", err)
+	}
+	if _, ok := env.Data.(*CollectionPage); !ok {
+		t.Fatalf("expected a collection page, got %T", env.Data)
+	}
+}
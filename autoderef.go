@@ -0,0 +1,62 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import "reflect"
+
+// WithAutoDeref matches values and pointers to the links registered for their
+// counterpart. When nothing is registered for the wrapped type, a T value
+// uses the links registered for *T, and a *T pointer those registered for T.
+// This covers ranging over a []User with a generator for *User. The envelope
+// still serializes the value as passed; only the generator sees the
+// converted value. Strict mode accepts such matches.
+//
+// Passing a T to a *T generator copies the value once to take its address.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithAutoDeref())
+//	for _, u := range users { // []User
+//	    envs = append(envs, inst.Wrap(ctx, u))
+//	}
+func WithAutoDeref() InstanceOption {
+	return func(i *Instance) {
+		i.autoDeref = true
+	}
+}
+
+// linkType returns the type to look up links for. Without WithAutoDeref, it
+// is data's own type, except that map values use the links registered for a
+// pointer to their type: generators are registered for *T, and a map is
+// usually passed by value.
+func (i *Instance) linkType(data any) reflect.Type {
+	t := reflect.TypeOf(data)
+	if t == nil || (!i.autoDeref && t.Kind() != reflect.Map) || i.hasLinksFor(t) {
+		return t
+	}
+	if t.Kind() == reflect.Pointer {
+		if reflect.ValueOf(data).IsNil() || !i.hasLinksFor(t.Elem()) {
+			return t
+		}
+		return t.Elem()
+	}
+	if ptrT := reflect.PointerTo(t); i.hasLinksFor(ptrT) {
+		return ptrT
+	}
+	return t
+}
+
+// linkValue converts data to t, as returned by linkType, for its generator.
+func linkValue(data any, t reflect.Type) any {
+	dt := reflect.TypeOf(data)
+	switch {
+	case dt == t:
+		return data
+	case dt.Kind() == reflect.Pointer && dt.Elem() == t:
+		return reflect.ValueOf(data).Elem().Interface()
+	}
+	p := reflect.New(dt)
+	p.Elem().Set(reflect.ValueOf(data))
+	return p.Interface()
+}
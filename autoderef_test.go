@@ -0,0 +1,67 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"testing"
+)
+
+type derefUser struct {
+	ID int `json:"id"`
+}
+
+type derefTeam struct {
+	Name string `json:"name"`
+}
+
+func derefInstance(opts ...InstanceOption) *Instance {
+	inst := New(append(opts, WithAutoDeref())...)
+	RegisterInstance(inst, func(_ context.Context, u *derefUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	RegisterStatic(inst, derefTeam{}, []Link{{Rel: "self", Href: "/teams"}})
+	return inst
+}
+
+func TestAutoDeref_ValueUsesPointerGenerator(t *testing.T) {
+	inst := derefInstance()
+	users := []derefUser{{ID: 1}, {ID: 2}}
+
+	for _, u := range users {
+		want := `{"id":` + itoa(u.ID) + `,"_links":{"self":{"href":"/users/` + itoa(u.ID) + `"}}}`
+		if got := marshalString(t, inst.Wrap(context.Background(), u)); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	}
+}
+
+func TestAutoDeref_PointerUsesValueLinks(t *testing.T) {
+	got := marshalString(t, derefInstance().Wrap(context.Background(), &derefTeam{Name: "core"}))
+
+	if want := `{"name":"core","_links":{"self":{"href":"/teams"}}}`; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestAutoDeref_StrictModeAcceptsMatch(t *testing.T) {
+	env, err := derefInstance(WithStrictMode()).WrapE(context.Background(), derefUser{ID: 3})
+	if err != nil {
+		t.Fatalf("expected auto-deref match in strict mode, got %v", err)
+	}
+	if got := marshalString(t, env); got != `{"id":3,"_links":{"self":{"href":"/users/3"}}}` {
+		t.Fatalf("unexpected output %s", got)
+	}
+}
+
+func TestAutoDeref_DisabledByDefault(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *derefUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/1"}}
+	})
+
+	if got := marshalString(t, inst.Wrap(context.Background(), derefUser{ID: 1})); got != wantUserID1 {
+		t.Fatalf("expected no links without WithAutoDeref, got %s", got)
+	}
+}
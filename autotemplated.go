@@ -0,0 +1,63 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import "strings"
+
+// WithAutoTemplated sets Link.Templated on links whose Href contains an
+// RFC 6570 expression, such as "/users{?q}", so a forgotten flag does not
+// make clients fetch the template literally. Braces that do not form a valid
+// expression are left alone, as are percent-encoded braces (%7B, %7D), which
+// are how a literal brace is written in a URI.
+//
+// The converse, a Templated link without any expression, is reported to the
+// lint hook; strict instances reject it in generator output via CheckTemplated.
+// Links added with AddLink, generator output and RegisterStatic links are all
+// covered. Templated is never cleared.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithAutoTemplated())
+//	e := inst.Wrap(ctx, user)
+//	e.AddLink(hal.Link{Rel: "search", Href: "/users{?q}"}) // serialized with "templated":true
+func WithAutoTemplated() InstanceOption {
+	return func(i *Instance) {
+		i.autoTemplated = true
+	}
+}
+
+// autoTemplate returns l with Templated set if the instance detects
+// template expressions and l's Href contains one.
+func (i *Instance) autoTemplate(l Link) Link {
+	if i == nil || !i.autoTemplated {
+		return l
+	}
+	hasExpr := hasTemplateExpression(l.Href)
+	switch {
+	case hasExpr && !l.Templated:
+		l.Templated = true
+	case !hasExpr && l.Templated:
+		i.lintf("hal: link %q is templated but href %q has no template expression", l.Rel, l.Href)
+	}
+	return l
+}
+
+// hasTemplateExpression reports whether href contains at least one
+// well-formed URI template expression.
+func hasTemplateExpression(href string) bool {
+	for {
+		open := strings.IndexByte(href, '{')
+		if open < 0 {
+			return false
+		}
+		end := strings.IndexByte(href[open:], '}')
+		if end < 0 {
+			return false
+		}
+		if _, _, err := ParseTemplateExpression(href[open+1 : open+end]); err == nil {
+			return true
+		}
+		href = href[open+1:]
+	}
+}
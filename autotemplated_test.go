@@ -0,0 +1,77 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAutoTemplated_Detection(t *testing.T) {
+	tests := []struct {
+		href string
+		want bool
+	}{
+		{"/users{?q}", true},
+		{"{?q,page}", true},
+		{"/users/{id}", true},
+		{"/users{/id*}", true},
+		{"/users", false},
+		{"/files/%7Bname%7D", false},
+		{"/weird/{}", false},
+		{"/weird/{ not a var }", false},
+		{"/unclosed/{id", false},
+		{"/mixed/{ bad }/{id}", true},
+	}
+	for _, tt := range tests {
+		inst := New(WithAutoTemplated())
+		e := inst.Wrap(context.Background(), map[string]any{})
+		e.AddLink(Link{Rel: "x", Href: tt.href})
+		if got := e.Links()["x"][0].Templated; got != tt.want {
+			t.Errorf("%q: expected templated %v, got %v", tt.href, tt.want, got)
+		}
+	}
+}
+
+func TestAutoTemplated_OffByDefault(t *testing.T) {
+	e := New().Wrap(context.Background(), map[string]any{})
+	e.AddLink(Link{Rel: "search", Href: "/users{?q}"})
+	if e.Links()["search"][0].Templated {
+		t.Fatal("expected Templated to be left unset without WithAutoTemplated")
+	}
+}
+
+func TestAutoTemplated_GeneratorAndStatic(t *testing.T) {
+	inst := New(WithAutoTemplated())
+	RegisterInstance(inst, func(ctx context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/1"}, {Rel: "search", Href: "/users{?q}"}}
+	})
+	b := marshalString(t, inst.Wrap(context.Background(), &collectionUser{ID: 1}))
+	if !strings.Contains(b, `"search":{"href":"/users{?q}","templated":true}`) {
+		t.Fatalf("expected generator link to be templated, got %s", b)
+	}
+	if strings.Contains(b, `"self":{"href":"/users/1","templated"`) {
+		t.Fatalf("expected self link untouched, got %s", b)
+	}
+
+	RegisterStatic(inst, &TestData{}, []Link{{Rel: "find", Href: "/data{?id}"}})
+	b = marshalString(t, inst.Wrap(context.Background(), &TestData{}))
+	if !strings.Contains(b, `"templated":true`) {
+		t.Fatalf("expected static link to be templated, got %s", b)
+	}
+}
+
+func TestAutoTemplated_LintsTemplatedWithoutExpression(t *testing.T) {
+	var msgs []string
+	inst := New(WithAutoTemplated(), WithLintHook(func(msg string) { msgs = append(msgs, msg) }))
+	e := inst.Wrap(context.Background(), map[string]any{})
+	e.AddLink(Link{Rel: "self", Href: "/users", Templated: true})
+	if len(msgs) != 1 || !strings.Contains(msgs[0], "no template expression") {
+		t.Fatalf("expected one lint warning, got %q", msgs)
+	}
+	if !e.Links()["self"][0].Templated {
+		t.Fatal("expected Templated to be preserved")
+	}
+}
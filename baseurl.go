@@ -0,0 +1,166 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"maps"
+	"net/url"
+	"strings"
+)
+
+// WithBaseURLResolver makes link hrefs absolute. fn is called once per Wrap
+// with the Wrap context and returns the base URL (such as the tenant's
+// "https://acme.api.example.com") that relative hrefs are resolved against
+// when the envelope is marshaled. Embedded resources and collection items are
+// wrapped with the same context, so they resolve against the same base.
+//
+// Absolute hrefs are left untouched, as are envelopes using precomputed links.
+// An empty or non-absolute result leaves hrefs relative.
+// RequestBaseURL is a ready-made resolver for framework integrations.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithBaseURLResolver(func(ctx context.Context) string {
+//	    return "https://" + tenantFrom(ctx) + ".api.example.com"
+//	}))
+func WithBaseURLResolver(fn func(ctx context.Context) string) InstanceOption {
+	return func(i *Instance) {
+		i.baseURLResolver = fn
+	}
+}
+
+// WithAbsoluteLinks makes link hrefs absolute using the base URL stored in
+// the Wrap context with WithBaseURL. Relative hrefs are resolved with
+// url.URL.ResolveReference when the envelope is marshaled. Absolute hrefs
+// and precomputed links pass through untouched. For templated hrefs, only
+// the literal part before the first expression is resolved, so expressions
+// are never percent-encoded. A context without a base URL leaves hrefs
+// relative.
+// A resolver set with WithBaseURLResolver takes precedence when it returns
+// a base URL.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithAbsoluteLinks())
+//
+//	// In middleware, once the externally visible scheme and host are known:
+//	ctx = hal.WithBaseURL(ctx, &url.URL{Scheme: "https", Host: r.Host})
+func WithAbsoluteLinks() InstanceOption {
+	return func(i *Instance) {
+		i.absoluteLinks = true
+	}
+}
+
+type baseURLKey struct{}
+
+// WithBaseURL returns a copy of ctx carrying base, the absolute URL that
+// instances configured with WithAbsoluteLinks resolve relative hrefs against.
+func WithBaseURL(ctx context.Context, base *url.URL) context.Context {
+	return context.WithValue(ctx, baseURLKey{}, base)
+}
+
+// BaseURLFromContext returns the base URL stored in ctx by WithBaseURL, if any.
+func BaseURLFromContext(ctx context.Context) (*url.URL, bool) {
+	base, ok := ctx.Value(baseURLKey{}).(*url.URL)
+	return base, ok && base != nil
+}
+
+// RequestBaseURL returns the scheme and host of the RequestInfo stored in
+// ctx, or "" if there is none. Use it with WithBaseURLResolver to derive
+// absolute links from the incoming request's (forwarded) host.
+func RequestBaseURL(ctx context.Context) string {
+	req, ok := RequestInfoFromContext(ctx)
+	if !ok || req.URL == nil || req.URL.Host == "" {
+		return ""
+	}
+	scheme := req.URL.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	return scheme + "://" + req.URL.Host
+}
+
+// baseURLFor returns the base URL for ctx, or nil if links stay relative.
+func (i *Instance) baseURLFor(ctx context.Context) *url.URL {
+	if i.baseURLResolver != nil {
+		if raw := i.baseURLResolver(ctx); raw != "" {
+			base, err := url.Parse(raw)
+			if err != nil || !base.IsAbs() {
+				i.lintf("hal: base URL %q is not an absolute URL; links stay relative", raw)
+				return nil
+			}
+			return base
+		}
+	}
+	if !i.absoluteLinks || ctx == nil {
+		return nil
+	}
+	base, ok := BaseURLFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if !base.IsAbs() {
+		i.lintf("hal: base URL %q is not an absolute URL; links stay relative", base)
+		return nil
+	}
+	return base
+}
+
+// absoluteLinks returns links with every relative href resolved against base.
+// Changed entries are copies; the input map is not modified.
+func absoluteLinks(links map[string]any, base *url.URL) map[string]any {
+	var out map[string]any
+	set := func(rel string, v any) {
+		if out == nil {
+			out = maps.Clone(links)
+		}
+		out[rel] = v
+	}
+	for rel, v := range links {
+		switch vv := v.(type) {
+		case Link:
+			set(rel, absoluteLink(vv, base))
+		case []any:
+			s := make([]any, len(vv))
+			for idx, item := range vv {
+				if l, ok := item.(Link); ok {
+					item = absoluteLink(l, base)
+				}
+				s[idx] = item
+			}
+			set(rel, s)
+		case []Link:
+			s := make([]Link, len(vv))
+			for idx, l := range vv {
+				s[idx] = absoluteLink(l, base)
+			}
+			set(rel, s)
+		}
+	}
+	if out == nil {
+		return links
+	}
+	return out
+}
+
+func absoluteLink(l Link, base *url.URL) Link {
+	l.Href = resolveHref(base, l.Href)
+	return l
+}
+
+// resolveHref resolves href against base per RFC 3986. Absolute hrefs are
+// returned unchanged. For URI templates only the literal prefix before the
+// first expression is resolved, so expressions are never percent-encoded.
+func resolveHref(base *url.URL, href string) string {
+	prefix, template := href, ""
+	if idx := strings.IndexByte(href, '{'); idx >= 0 {
+		prefix, template = href[:idx], href[idx:]
+	}
+	ref, err := url.Parse(prefix)
+	if err != nil || ref.IsAbs() || ref.Host != "" {
+		return href
+	}
+	return base.ResolveReference(ref).String() + template
+}
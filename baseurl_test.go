@@ -0,0 +1,219 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+type tenantKey struct{}
+
+func tenantInstance() *Instance {
+	inst := New(WithBaseURLResolver(func(ctx context.Context) string {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		if tenant == "" {
+			return ""
+		}
+		return "https://" + tenant + ".api.example.com"
+	}))
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{
+			{Rel: "self", Href: "/users/" + itoa(u.ID)},
+			{Rel: "docs", Href: "https://docs.example.com/users"},
+		}
+	})
+	return inst
+}
+
+func TestWithBaseURLResolver_PerTenant(t *testing.T) {
+	inst := tenantInstance()
+
+	for _, tenant := range []string{"acme", "globex"} {
+		ctx := context.WithValue(context.Background(), tenantKey{}, tenant)
+		b, err := json.Marshal(inst.Wrap(ctx, &collectionUser{ID: 1}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"id":1,"_links":{"docs":{"href":"https://docs.example.com/users"},` +
+			`"self":{"href":"https://` + tenant + `.api.example.com/users/1"}}}`
+		if string(b) != want {
+			t.Fatalf("expected %s, got %s", want, b)
+		}
+	}
+}
+
+func TestWithBaseURLResolver_NoBaseKeepsRelative(t *testing.T) {
+	b, err := json.Marshal(tenantInstance().Wrap(context.Background(), &collectionUser{ID: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":1,"_links":{"docs":{"href":"https://docs.example.com/users"},"self":{"href":"/users/1"}}}`
+	if string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+}
+
+func TestWithBaseURLResolver_EmbeddedAndCollection(t *testing.T) {
+	inst := tenantInstance()
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	env := inst.Wrap(ctx, &collectionUser{ID: 1})
+	env.AddEmbedded(ctx, "friend", &collectionUser{ID: 2})
+	env.AddLink(Link{Rel: "avatar", Href: "avatars/1.png"})
+
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Links    map[string]Link `json:"_links"`
+		Embedded struct {
+			Friend struct {
+				Links map[string]Link `json:"_links"`
+			} `json:"friend"`
+		} `json:"_embedded"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if got := doc.Links["avatar"].Href; got != "https://acme.api.example.com/avatars/1.png" {
+		t.Fatalf("expected links added after Wrap to resolve, got %s", got)
+	}
+	if got := doc.Embedded.Friend.Links["self"].Href; got != "https://acme.api.example.com/users/2" {
+		t.Fatalf("expected embedded link to resolve, got %s", got)
+	}
+
+	page := inst.Collection(ctx, []*collectionUser{{ID: 3}}, 1, Link{Rel: "self", Href: "/users?page=1"})
+	if got := page.Links["self"].(Link).Href; got != "https://acme.api.example.com/users?page=1" {
+		t.Fatalf("expected collection self link to resolve, got %s", got)
+	}
+	b, err = json.Marshal(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !contains(b, `"https://acme.api.example.com/users/3"`) {
+		t.Fatalf("expected collection item link to resolve, got %s", b)
+	}
+}
+
+func TestResolveHref(t *testing.T) {
+	base, _ := url.Parse("https://api.example.com/v1/")
+
+	tests := map[string]string{
+		"/users/1":            "https://api.example.com/users/1",
+		"users/1":             "https://api.example.com/v1/users/1",
+		"?page=2":             "https://api.example.com/v1/?page=2",
+		"/users{?q,page}":     "https://api.example.com/users{?q,page}",
+		"/users/{id}/posts":   "https://api.example.com/users/{id}/posts",
+		"{+path}":             "https://api.example.com/v1/{+path}",
+		"http://other.test/a": "http://other.test/a",
+		"//cdn.example.com/a": "//cdn.example.com/a",
+		"urn:isbn:0451450523": "urn:isbn:0451450523",
+		"https://x.test/{id}": "https://x.test/{id}",
+		"/files/a%2Fb":        "https://api.example.com/files/a%2Fb",
+	}
+
+	for href, want := range tests {
+		if got := resolveHref(base, href); got != want {
+			t.Errorf("resolveHref(%q) = %q, want %q", href, got, want)
+		}
+	}
+}
+
+func TestRequestBaseURL(t *testing.T) {
+	if got := RequestBaseURL(context.Background()); got != "" {
+		t.Fatalf("expected empty base without request info, got %q", got)
+	}
+
+	u, _ := url.Parse("https://acme.api.example.com/users?page=1")
+	ctx := WithRequestInfo(context.Background(), RequestInfo{Method: "GET", URL: u})
+	if got := RequestBaseURL(ctx); got != "https://acme.api.example.com" {
+		t.Fatalf("expected scheme and host, got %q", got)
+	}
+}
+
+func TestWithBaseURLResolver_InvalidBaseLinted(t *testing.T) {
+	var warnings []string
+	inst := New(
+		WithBaseURLResolver(func(context.Context) string { return "acme.api.example.com" }),
+		WithLintHook(func(msg string) { warnings = append(warnings, msg) }),
+	)
+
+	env := inst.Wrap(context.Background(), &collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "self", Href: "/users/1"})
+
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !contains(b, `"href":"/users/1"`) || len(warnings) != 1 {
+		t.Fatalf("expected relative link and one warning, got %s, %v", b, warnings)
+	}
+}
+
+func TestWithAbsoluteLinks(t *testing.T) {
+	inst := New(WithAbsoluteLinks())
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{
+			{Rel: "self", Href: "/users/" + itoa(u.ID)},
+			{Rel: "search", Href: "/users/{id}/orders{?q}", Templated: true},
+			{Rel: "docs", Href: "https://docs.example.com/users"},
+		}
+	})
+	ctx := WithBaseURL(context.Background(), &url.URL{Scheme: "https", Host: "api.example.com"})
+
+	b, err := json.Marshal(inst.Wrap(ctx, &collectionUser{ID: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":1,"_links":{"docs":{"href":"https://docs.example.com/users"},` +
+		`"search":{"href":"https://api.example.com/users/{id}/orders{?q}","templated":true},` +
+		`"self":{"href":"https://api.example.com/users/1"}}}`
+	if string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+
+	b, err = json.Marshal(inst.Wrap(context.Background(), &collectionUser{ID: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !contains(b, `"self":{"href":"/users/1"}`) {
+		t.Fatalf("expected relative hrefs without a base URL, got %s", b)
+	}
+}
+
+func TestWithAbsoluteLinks_RequiresOption(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	ctx := WithBaseURL(context.Background(), &url.URL{Scheme: "https", Host: "api.example.com"})
+	if got := marshalString(t, inst.Wrap(ctx, &collectionUser{ID: 1})); got != `{"id":1,"_links":{"self":{"href":"/users/1"}}}` {
+		t.Fatalf("expected the base URL to be ignored without WithAbsoluteLinks, got %s", got)
+	}
+}
+
+func TestWithAbsoluteLinks_ResolverTakesPrecedence(t *testing.T) {
+	inst := New(WithAbsoluteLinks(), WithBaseURLResolver(func(ctx context.Context) string {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		if tenant == "" {
+			return ""
+		}
+		return "https://" + tenant + ".api.example.com"
+	}))
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	ctx := WithBaseURL(context.Background(), &url.URL{Scheme: "https", Host: "api.example.com"})
+
+	if got := marshalString(t, inst.Wrap(context.WithValue(ctx, tenantKey{}, "acme"), &collectionUser{ID: 1})); !contains([]byte(got), "https://acme.api.example.com/users/1") {
+		t.Fatalf("expected the resolver's base, got %s", got)
+	}
+	if got := marshalString(t, inst.Wrap(ctx, &collectionUser{ID: 1})); !contains([]byte(got), "https://api.example.com/users/1") {
+		t.Fatalf("expected the context base when the resolver has none, got %s", got)
+	}
+}
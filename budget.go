@@ -0,0 +1,114 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// BudgetExceededError is recorded on the outermost envelope of a request when
+// its wrap budget (see WithWrapBudget) ran out. Retrieve it with errors.As on
+// the result of Envelope.Err.
+type BudgetExceededError struct {
+	Limit   int // The budget passed to WithWrapBudget
+	Skipped int // Embeds dropped after the budget ran out
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("hal: wrap budget of %d exceeded, %d embeds skipped", e.Limit, e.Skipped)
+}
+
+type wrapBudgetKey struct{}
+
+// wrapBudget is the shared per-request counter behind WithWrapBudget.
+type wrapBudget struct {
+	limit     int
+	remaining atomic.Int64
+	skipped   atomic.Int64
+	root      atomic.Pointer[Envelope]
+}
+
+// WithWrapBudget returns a copy of ctx limiting the number of envelopes that
+// may be built with it to n. Every Wrap consumes one unit; once none is left,
+// AddEmbedded and SetEmbedded drop the resource instead of wrapping it, while
+// links are still emitted. The failure is recorded on the outermost envelope
+// as a *BudgetExceededError (see Envelope.Err); under WithStrictMode marshaling
+// that envelope fails with it instead.
+//
+// Without a budget, wrapping is unlimited.
+//
+// # Example
+//
+//	ctx = hal.WithWrapBudget(ctx, 200)
+//	env := inst.Wrap(ctx, order) // AfterWrap hooks embed at most 199 resources
+func WithWrapBudget(ctx context.Context, n int) context.Context {
+	b := &wrapBudget{limit: n}
+	b.remaining.Store(int64(n))
+	return context.WithValue(ctx, wrapBudgetKey{}, b)
+}
+
+// budgetFrom returns the wrap budget carried by ctx, or nil.
+func budgetFrom(ctx context.Context) *wrapBudget {
+	if ctx == nil {
+		return nil
+	}
+	b, _ := ctx.Value(wrapBudgetKey{}).(*wrapBudget)
+	return b
+}
+
+// take consumes one unit for e. The first envelope built with the budget
+// becomes the root that reports exhaustion.
+func (b *wrapBudget) take(e *Envelope) {
+	if b.root.CompareAndSwap(nil, e) {
+		e.budget = b
+	}
+	b.remaining.Add(-1)
+}
+
+// allow reports whether another envelope may be built, counting a skip if
+// not.
+func (b *wrapBudget) allow() bool {
+	if b.remaining.Load() > 0 {
+		return true
+	}
+	b.skipped.Add(1)
+	return false
+}
+
+// err returns the exhaustion error, or nil if nothing was skipped.
+func (b *wrapBudget) err() error {
+	if b == nil {
+		return nil
+	}
+	skipped := b.skipped.Load()
+	if skipped == 0 {
+		return nil
+	}
+	return &BudgetExceededError{Limit: b.limit, Skipped: int(skipped)}
+}
+
+// allowEmbed reports whether ctx has budget left for embedding a resource.
+func allowEmbed(ctx context.Context) bool {
+	b := budgetFrom(ctx)
+	return b == nil || b.allow()
+}
+
+// marshalErr returns the error that must abort marshaling e, if any.
+func (e *Envelope) marshalErr() error {
+	if e.strictErr != nil {
+		return e.strictErr
+	}
+	if e.linkErr != nil {
+		return e.linkErr
+	}
+	if e.transformErr != nil {
+		return e.transformErr
+	}
+	if e.instance != nil && e.instance.strictMode {
+		return e.budget.err()
+	}
+	return nil
+}
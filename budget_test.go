@@ -0,0 +1,95 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type chainNode struct {
+	Depth int `json:"depth"`
+}
+
+// chainInstance embeds a child under "next" for every node, building a chain
+// of maxDepth+1 envelopes.
+func chainInstance(maxDepth int, opts ...InstanceOption) *Instance {
+	opts = append(opts, WithAfterWrap(func(ctx context.Context, e *Envelope) {
+		if n, ok := e.Data.(*chainNode); ok && n.Depth < maxDepth {
+			e.AddEmbedded(ctx, "next", &chainNode{Depth: n.Depth + 1})
+		}
+	}))
+	inst := New(opts...)
+	RegisterInstance(inst, func(_ context.Context, n *chainNode) []Link {
+		return []Link{{Rel: "self", Href: "/nodes/" + itoa(n.Depth)}}
+	})
+	return inst
+}
+
+func TestWrapBudget_TruncatesEmbedChain(t *testing.T) {
+	ctx := WithWrapBudget(context.Background(), 3)
+	env := chainInstance(100).Wrap(ctx, &chainNode{})
+
+	var budgetErr *BudgetExceededError
+	if !errors.As(env.Err(), &budgetErr) {
+		t.Fatalf("expected BudgetExceededError, got %v", env.Err())
+	}
+	if budgetErr.Limit != 3 || budgetErr.Skipped != 1 {
+		t.Fatalf("unexpected error fields: %+v", budgetErr)
+	}
+
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(b)
+	if got := strings.Count(out, `"depth"`); got != 3 {
+		t.Fatalf("expected 3 nodes, got %d: %s", got, out)
+	}
+	// The last wrapped node keeps its links.
+	if !strings.Contains(out, `"self":{"href":"/nodes/2"}`) || strings.Contains(out, "/nodes/3") {
+		t.Fatalf("expected chain cut after node 2, got %s", out)
+	}
+}
+
+func TestWrapBudget_NotExceeded(t *testing.T) {
+	ctx := WithWrapBudget(context.Background(), 10)
+	env := chainInstance(4).Wrap(ctx, &chainNode{})
+
+	if err := env.Err(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(b), `"depth"`); got != 5 {
+		t.Fatalf("expected 5 nodes, got %d", got)
+	}
+}
+
+func TestWrapBudget_UnlimitedByDefault(t *testing.T) {
+	env := chainInstance(50).Wrap(context.Background(), &chainNode{})
+
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(b), `"depth"`); got != 51 {
+		t.Fatalf("expected 51 nodes, got %d", got)
+	}
+}
+
+func TestWrapBudget_StrictModeFailsMarshal(t *testing.T) {
+	ctx := WithWrapBudget(context.Background(), 2)
+	env := chainInstance(10, WithStrictMode()).Wrap(ctx, &chainNode{})
+
+	var budgetErr *BudgetExceededError
+	if _, err := json.Marshal(env); !errors.As(err, &budgetErr) {
+		t.Fatalf("expected BudgetExceededError from marshal, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import "sync"
+
+const (
+	// initialMarshalBuffer is the capacity of new pooled buffers, enough
+	// for typical resources without growing.
+	initialMarshalBuffer = 2 << 10
+	// maxPooledBuffer caps the buffers kept for reuse, so one large
+	// document does not pin its memory in the pool.
+	maxPooledBuffer = 64 << 10
+)
+
+// marshalBuffers holds scratch buffers for the marshal path. Documents are
+// built in them and copied or written out before the buffer is returned.
+var marshalBuffers = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, initialMarshalBuffer)
+		return &b
+	},
+}
+
+// getBuffer returns an empty scratch buffer from the pool.
+func getBuffer() *[]byte {
+	return marshalBuffers.Get().(*[]byte)
+}
+
+// putBuffer returns b to the pool. Nothing may reference its contents
+// afterwards.
+func putBuffer(b *[]byte) {
+	if cap(*b) > maxPooledBuffer {
+		return
+	}
+	*b = (*b)[:0]
+	marshalBuffers.Put(b)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+type requestCacheKey struct{}
+
+// requestCache holds generator output for the lifetime of one request.
+type requestCache struct {
+	mu    sync.Mutex
+	links map[linkCacheKey][]Link
+}
+
+// linkCacheKey identifies a resource within a request cache. Instances are
+// part of the key because they may register different generators.
+type linkCacheKey struct {
+	instance *Instance
+	t        reflect.Type
+	key      any
+}
+
+// NewCacheContext returns a copy of ctx carrying an empty link cache. Wrap
+// reuses the links cached for a resource instead of running its generator
+// again: a resource matches when it is the same pointer, or when the key
+// function registered with RegisterCacheKey returns the same key. HTTP
+// handlers install it per request with halhttp.WithRequestCache or the
+// halhttp.RequestCache middleware.
+func NewCacheContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestCacheKey{}, &requestCache{
+		links: make(map[linkCacheKey][]Link),
+	})
+}
+
+// RegisterCacheKey sets the request cache key for values of type *T, so that
+// distinct pointers to the same entity share cached links. fn must return a
+// comparable value, such as an ID.
+//
+// # Example
+//
+//	hal.RegisterCacheKey(inst, func(t *Team) any { return t.ID })
+func RegisterCacheKey[T any](i *Instance, fn func(*T) any) {
+	targetType := reflect.TypeOf((*T)(nil))
+	adapter := func(v any) any { return fn(v.(*T)) }
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.cacheKeys[targetType] = adapter
+}
+
+// generateLinks runs gen for v, consulting the request cache carried by ctx.
+func (i *Instance) generateLinks(ctx context.Context, gen Generator, t reflect.Type, v any) ([]Link, error) {
+	var cache *requestCache
+	if ctx != nil {
+		cache, _ = ctx.Value(requestCacheKey{}).(*requestCache)
+	}
+	if cache == nil {
+		return i.callGenerator(ctx, gen, t, v)
+	}
+	key, ok := i.cacheKeyFor(t, v)
+	if !ok {
+		return i.callGenerator(ctx, gen, t, v)
+	}
+
+	cache.mu.Lock()
+	links, hit := cache.links[key]
+	cache.mu.Unlock()
+	if hit {
+		return links, nil
+	}
+
+	links, err := i.callGenerator(ctx, gen, t, v)
+	if err != nil {
+		return nil, err
+	}
+	cache.mu.Lock()
+	cache.links[key] = links
+	cache.mu.Unlock()
+	return links, nil
+}
+
+// cacheKeyFor returns the request cache key for v: the registered key
+// function's result, else the pointer itself. Values that are neither
+// pointers nor keyed are not cached.
+func (i *Instance) cacheKeyFor(t reflect.Type, v any) (linkCacheKey, bool) {
+	i.mu.RLock()
+	fn, ok := i.cacheKeys[t]
+	i.mu.RUnlock()
+	if ok {
+		return linkCacheKey{instance: i, t: t, key: fn(v)}, true
+	}
+	if t.Kind() != reflect.Pointer || reflect.ValueOf(v).IsNil() {
+		return linkCacheKey{}, false
+	}
+	return linkCacheKey{instance: i, t: t, key: v}, true
+}
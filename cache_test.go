@@ -0,0 +1,99 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+type cacheTeam struct {
+	ID int `json:"id"`
+}
+
+func countingTeamInstance(calls *int) *Instance {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, t *cacheTeam) []Link {
+		*calls++
+		return []Link{{Rel: "self", Href: "/teams/" + itoa(t.ID)}}
+	})
+	return inst
+}
+
+func TestRequestCache_SamePointerGeneratedOnce(t *testing.T) {
+	calls := 0
+	inst := countingTeamInstance(&calls)
+	ctx := NewCacheContext(context.Background())
+
+	team := &cacheTeam{ID: 7}
+	env := inst.Wrap(ctx, &collectionUser{ID: 1})
+	for range 10 {
+		env.AddEmbedded(ctx, "team", team)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected 1 generator call, got %d", calls)
+	}
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !contains(b, `{"id":7,"_links":{"self":{"href":"/teams/7"}}}`) {
+		t.Fatalf("expected cached links on embedded team, got %s", b)
+	}
+}
+
+func TestRequestCache_DisabledWithoutContext(t *testing.T) {
+	calls := 0
+	inst := countingTeamInstance(&calls)
+
+	team := &cacheTeam{ID: 7}
+	for range 3 {
+		inst.Wrap(context.Background(), team)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 generator calls, got %d", calls)
+	}
+}
+
+func TestRequestCache_KeyFunc(t *testing.T) {
+	calls := 0
+	inst := countingTeamInstance(&calls)
+	RegisterCacheKey(inst, func(t *cacheTeam) any { return t.ID })
+	ctx := NewCacheContext(context.Background())
+
+	inst.Wrap(ctx, &cacheTeam{ID: 1})
+	inst.Wrap(ctx, &cacheTeam{ID: 1})
+	inst.Wrap(ctx, &cacheTeam{ID: 2})
+	if calls != 2 {
+		t.Fatalf("expected 2 generator calls, got %d", calls)
+	}
+}
+
+func TestRequestCache_DistinctPointersNotShared(t *testing.T) {
+	calls := 0
+	inst := countingTeamInstance(&calls)
+	ctx := NewCacheContext(context.Background())
+
+	inst.Wrap(ctx, &cacheTeam{ID: 1})
+	inst.Wrap(ctx, &cacheTeam{ID: 1})
+	if calls != 2 {
+		t.Fatalf("expected 2 generator calls without a key func, got %d", calls)
+	}
+}
+
+func TestRequestCache_ScopedPerInstance(t *testing.T) {
+	callsA, callsB := 0, 0
+	a := countingTeamInstance(&callsA)
+	b := countingTeamInstance(&callsB)
+	ctx := NewCacheContext(context.Background())
+
+	team := &cacheTeam{ID: 1}
+	a.Wrap(ctx, team)
+	b.Wrap(ctx, team)
+	if callsA != 1 || callsB != 1 {
+		t.Fatalf("expected each instance to generate once, got %d and %d", callsA, callsB)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+)
+
+// contextErr returns ctx.Err(), tolerating a nil context.
+func contextErr(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Err()
+}
+
+// WrapE wraps data like Wrap and reports failures as an error instead of
+// leaving them on the Envelope. Strict mode failures are returned rather than
+// panicking, wrapping ErrNoGenerator, ErrPointerMismatch or ErrInvalidLink.
+// If ctx is already cancelled, the generator is not invoked and an error
+// wrapping ctx.Err() is returned; otherwise the error is the one returned by
+// Envelope.Err.
+//
+// # Example
+//
+//	env, err := inst.WrapE(ctx, user)
+//	if err != nil {
+//	    return err // ErrNoGenerator, context.Canceled, ErrGeneratorPanic, ...
+//	}
+func (i *Instance) WrapE(ctx context.Context, data any) (*Envelope, error) {
+	if err := contextErr(ctx); err != nil {
+		return nil, fmt.Errorf("hal: wrapping %T: %w", data, err)
+	}
+	e := i.wrap(ctx, data)
+	if e.strictErr != nil {
+		return nil, e.strictErr
+	}
+	if err := e.Err(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// CollectionE builds a CollectionPage like Collection, but returns an error
+// instead of panicking on invalid items or strict mode failures of items,
+// and stops as soon as ctx is cancelled. Items that are not a slice are reported with their type and
+// kind, for example:
+//
+//	hal: collection items must be a slice, got api.User (struct)
+//
+// As with Collection, a nil slice yields an empty page.
+//
+// The cancellation error wraps ctx.Err() and records how many items were
+// wrapped before processing stopped:
+//
+//	hal: collection cancelled after 120 of 500 items: context canceled
+//
+// Generators receive ctx too and may return early on their own.
+func (i *Instance) CollectionE(ctx context.Context, items any, total int, selfLink Link, opts ...CollectionOption) (*CollectionPage, error) {
+	page, err := i.buildCollection(ctx, items, total, selfLink, append(opts[:len(opts):len(opts)], returnStrictErrors))
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
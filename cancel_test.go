@@ -0,0 +1,129 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// cancellingInstance returns an instance whose generator cancels the context
+// once it has run for the item with cancelAt as ID, and counts invocations.
+func cancellingInstance(cancel context.CancelFunc, cancelAt int, calls *int) *Instance {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		*calls++
+		if u.ID == cancelAt {
+			cancel()
+		}
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	return inst
+}
+
+func collectionUsers(n int) []*collectionUser {
+	users := make([]*collectionUser, n)
+	for idx := range users {
+		users[idx] = &collectionUser{ID: idx + 1}
+	}
+	return users
+}
+
+func TestCollectionE_StopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	inst := cancellingInstance(cancel, 3, &calls)
+
+	page, err := inst.CollectionE(ctx, collectionUsers(500), 500, Link{Rel: "self", Href: "/users"})
+	if page != nil {
+		t.Fatal("expected no page on cancellation")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "after 3 of 500 items") {
+		t.Fatalf("expected progress in error, got %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 generator calls, got %d", calls)
+	}
+}
+
+func TestCollectionE_InvalidItems(t *testing.T) {
+	inst := New()
+	ctx := context.Background()
+
+	if _, err := inst.CollectionE(ctx, nil, 0, Link{}); !errors.Is(err, ErrNilData) {
+		t.Fatalf("expected ErrNilData, got %v", err)
+	}
+	if _, err := inst.CollectionE(ctx, 42, 0, Link{}); !errors.Is(err, ErrNotASlice) {
+		t.Fatalf("expected ErrNotASlice, got %v", err)
+	}
+	_, err := inst.CollectionE(ctx, collectionUser{ID: 1}, 0, Link{})
+	if !errors.Is(err, ErrNotASlice) || !strings.Contains(err.Error(), "got hal.collectionUser (struct)") {
+		t.Fatalf("expected ErrNotASlice naming the type and kind, got %v", err)
+	}
+}
+
+func TestCollectionE_EmptyAndNilSlices(t *testing.T) {
+	inst := New()
+	for name, items := range map[string]any{
+		"nil":   []*collectionUser(nil),
+		"empty": []collectionUser{},
+	} {
+		page, err := inst.CollectionE(context.Background(), items, 0, SelfLink("/users"))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := marshalString(t, page); got != `{"_links":{"self":{"href":"/users"}},"_embedded":{"items":[]},"count":0}` {
+			t.Errorf("%s: expected an empty page, got %s", name, got)
+		}
+	}
+}
+
+func TestCollection_CancelledReturnsPartialPage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	inst := cancellingInstance(cancel, 2, &calls)
+
+	page := inst.Collection(ctx, collectionUsers(500), 500, Link{Rel: "self", Href: "/users"})
+	if calls != 2 {
+		t.Fatalf("expected 2 generator calls, got %d", calls)
+	}
+	if page.Count != 2 || len(page.Embedded["items"].([]*Envelope)) != 2 {
+		t.Fatalf("expected a page of 2 items, got count %d", page.Count)
+	}
+}
+
+func TestWrapE(t *testing.T) {
+	calls := 0
+	inst := cancellingInstance(func() {}, 0, &calls)
+
+	env, err := inst.WrapE(context.Background(), &collectionUser{ID: 1})
+	if err != nil || env == nil {
+		t.Fatalf("expected envelope, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := inst.WrapE(ctx, &collectionUser{ID: 1}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected generator to run once, got %d", calls)
+	}
+}
+
+func TestWrapE_ReportsGeneratorPanic(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(context.Context, *collectionUser) []Link { panic("boom") })
+
+	if _, err := inst.WrapE(context.Background(), &collectionUser{ID: 1}); !errors.Is(err, ErrGeneratorPanic) {
+		t.Fatalf("expected ErrGeneratorPanic, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+
+	json "github.com/goccy/go-json"
+)
+
+// WithCanonicalData re-encodes map-based Data into canonical JSON before
+// splicing: object keys are sorted at every level, numbers are formatted
+// consistently (1.0, 1e0 and 1 all become 1) and HTML characters are not
+// escaped. Semantically equal maps therefore produce identical bytes, which
+// deterministic output and ETag computation rely on. Struct data is unaffected.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithCanonicalData())
+func WithCanonicalData() InstanceOption {
+	return func(i *Instance) {
+		i.canonicalData = true
+	}
+}
+
+// isMapData reports whether data is a map, or a pointer to one.
+func isMapData(data any) bool {
+	t := baseType(reflect.TypeOf(data))
+	return t != nil && t.Kind() == reflect.Map
+}
+
+// canonicalJSON re-encodes the JSON document b in canonical form.
+func canonicalJSON(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	v, err := canonicalNumbers(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
+// canonicalNumbers walks a decoded document, replacing every json.Number with
+// its canonical representation. Maps are serialized with sorted keys.
+func canonicalNumbers(v any) (any, error) {
+	switch vv := v.(type) {
+	case map[string]any:
+		for k, item := range vv {
+			c, err := canonicalNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			vv[k] = c
+		}
+	case []any:
+		for i, item := range vv {
+			c, err := canonicalNumbers(item)
+			if err != nil {
+				return nil, err
+			}
+			vv[i] = c
+		}
+	case json.Number:
+		return canonicalNumber(vv)
+	}
+	return v, nil
+}
+
+// canonicalNumber keeps integers verbatim, preserving precision beyond
+// float64, and formats all other numbers as the shortest float64 form.
+func canonicalNumber(n json.Number) (json.Number, error) {
+	if _, err := strconv.ParseInt(n.String(), 10, 64); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(n.String(), 64)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(f)
+	if err != nil {
+		return "", err
+	}
+	return json.Number(b), nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+)
+
+func TestCanonicalData_EquivalentMapsIdentical(t *testing.T) {
+	inst := New(WithCanonicalData())
+	ctx := context.Background()
+
+	a := map[string]any{
+		"name":  "<Alice & Bob>",
+		"score": 1.0,
+		"stats": map[string]any{"z": json.Number("2.50"), "a": []any{json.Number("1e0"), 3}},
+		"big":   json.Number("9007199254740993"),
+	}
+	b := map[string]any{
+		"stats": map[string]any{"a": []any{1, json.Number("3")}, "z": 2.5},
+		"big":   json.Number("9007199254740993"),
+		"score": json.Number("1.0"),
+		"name":  "<Alice & Bob>",
+	}
+
+	// MarshalJSON is called directly: encoding/json would re-escape HTML.
+	outA := marshalDirect(t, inst.Wrap(ctx, a))
+	outB := marshalDirect(t, inst.Wrap(ctx, b))
+
+	want := `{"big":9007199254740993,"name":"<Alice & Bob>","score":1,"stats":{"a":[1,3],"z":2.5}}`
+	if outA != want {
+		t.Fatalf("expected %s, got %s", want, outA)
+	}
+	if sha256.Sum256([]byte(outA)) != sha256.Sum256([]byte(outB)) {
+		t.Fatalf("expected identical digests:\n%s\n%s", outA, outB)
+	}
+}
+
+func TestCanonicalData_WithLinks(t *testing.T) {
+	env := New(WithCanonicalData()).Wrap(context.Background(), map[string]any{"b": 1, "a": 2})
+	env.AddLink(Link{Rel: "self", Href: "/x"})
+
+	want := `{"a":2,"b":1,"_links":{"self":{"href":"/x"}}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestCanonicalData_StructUnaffected(t *testing.T) {
+	type Doc struct {
+		Z    float64 `json:"z"`
+		A    string  `json:"a"`
+		HTML string  `json:"html"`
+	}
+
+	doc := &Doc{Z: 1.5, A: "x", HTML: "<b>"}
+	got := marshalDirect(t, New(WithCanonicalData()).Wrap(context.Background(), doc))
+	want := marshalDirect(t, New().Wrap(context.Background(), doc))
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func marshalDirect(t *testing.T, env *Envelope) string {
+	t.Helper()
+	b, err := env.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	json "github.com/goccy/go-json"
+)
+
+// Client fetches HAL documents and follows their links. It is safe for
+// concurrent use.
+//
+// # Example
+//
+//	c := hal.NewClient(nil)
+//	root, err := c.Get(ctx, "https://api.example.com/")
+//	if err != nil {
+//	    return err
+//	}
+//	user, err := c.Follow(ctx, root, "acme:user", map[string]any{"id": 42})
+type Client struct {
+	hc *http.Client
+}
+
+// NewClient creates a Client sending requests with hc.
+// A nil hc means http.DefaultClient.
+func NewClient(hc *http.Client) *Client {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return &Client{hc: hc}
+}
+
+// StatusError is returned by Client for responses with a status outside the
+// 2xx range. Redirects are followed by the underlying http.Client according
+// to its CheckRedirect policy; one that stops them surfaces as a StatusError
+// with Location set.
+type StatusError struct {
+	URL        string // The requested URL
+	StatusCode int
+	Location   string // The Location header of a redirect, if any
+}
+
+func (e *StatusError) Error() string {
+	if e.Location != "" {
+		return fmt.Sprintf("hal: GET %s: %d %s to %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode), e.Location)
+	}
+	return fmt.Sprintf("hal: GET %s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Get fetches the HAL document at rawURL, sending Accept: application/hal+json.
+// Relative hrefs in the result resolve against its self link, itself resolved
+// against the final URL of the request, or against that URL if it has no
+// self link. An empty response body yields an empty Resource.
+func (c *Client) Get(ctx context.Context, rawURL string) (*Resource, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("hal: %w", err)
+	}
+	req.Header.Set("Accept", MediaType)
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Drain a short body so the connection can be reused.
+		_, _ = io.CopyN(io.Discard, resp.Body, 4<<10)
+		return nil, &StatusError{URL: rawURL, StatusCode: resp.StatusCode, Location: resp.Header.Get("Location")}
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("hal: reading %s: %w", rawURL, err)
+	}
+	res := new(Resource)
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, res); err != nil {
+			return nil, fmt.Errorf("hal: decoding %s: %w", rawURL, err)
+		}
+	}
+	res.setBase(resp.Request.URL)
+	return res, nil
+}
+
+// Follow fetches the target of res's link for rel, with Get. A templated
+// href is expanded with vars, and a relative one is resolved against res
+// (see Get). It returns an error wrapping ErrLinkNotFound if res has no
+// link for rel, and one wrapping ErrAmbiguousLink if it has several; use
+// FollowName to pick one of those by name.
+//
+// # Example
+//
+//	next, err := c.Follow(ctx, page, "next", nil)
+func (c *Client) Follow(ctx context.Context, res *Resource, rel string, vars map[string]any) (*Resource, error) {
+	links := res.Links[rel]
+	switch len(links) {
+	case 0:
+		return nil, fmt.Errorf("%w %q", ErrLinkNotFound, rel)
+	case 1:
+		return c.follow(ctx, res, links[0], vars)
+	default:
+		return nil, fmt.Errorf("%w %q: %d links", ErrAmbiguousLink, rel, len(links))
+	}
+}
+
+// FollowName is like Follow, but follows the link for rel whose Name equals
+// name (see Resource.LinkByName).
+//
+// # Example
+//
+//	pdf, err := c.FollowName(ctx, invoice, "alternate", "pdf", nil)
+func (c *Client) FollowName(ctx context.Context, res *Resource, rel, name string, vars map[string]any) (*Resource, error) {
+	l, ok := res.LinkByName(rel, name)
+	if !ok {
+		return nil, fmt.Errorf("%w %q named %q", ErrLinkNotFound, rel, name)
+	}
+	return c.follow(ctx, res, l, vars)
+}
+
+// follow expands l, resolves it against res and fetches it.
+func (c *Client) follow(ctx context.Context, res *Resource, l Link, vars map[string]any) (*Resource, error) {
+	l, err := l.Expand(vars)
+	if err != nil {
+		return nil, err
+	}
+	target := l.Href
+	if res.base != nil {
+		ref, err := url.Parse(l.Href)
+		if err != nil {
+			return nil, fmt.Errorf("hal: %q link: %w", l.Rel, err)
+		}
+		target = res.base.ResolveReference(ref).String()
+	}
+	return c.Get(ctx, target)
+}
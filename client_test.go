@@ -0,0 +1,159 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// clientServer serves a small HAL API under /api/ built with Wrap and
+// Collection. Relative hrefs are used on purpose.
+func clientServer(t *testing.T) *httptest.Server {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/api/users/" + itoa(u.ID)}, {Rel: "up", Href: "../users"}}
+	})
+	write := func(w http.ResponseWriter, r *http.Request, v any) {
+		if r.Header.Get("Accept") != MediaType {
+			http.Error(w, "unexpected Accept "+r.Header.Get("Accept"), http.StatusNotAcceptable)
+			return
+		}
+		w.Header().Set("Content-Type", MediaType)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/{$}", func(w http.ResponseWriter, r *http.Request) {
+		env := inst.WrapRaw(map[string]any{"version": 1})
+		env.AddLink(Link{Rel: "self", Href: "/api/"})
+		env.AddLink(Link{Rel: "user", Href: "users/{id}", Templated: true})
+		env.AddLink(Link{Rel: "users", Href: "users"})
+		env.AddLink(Link{Rel: "alternate", Href: "/api/users/1", Name: "one"})
+		env.AddLink(Link{Rel: "alternate", Href: "/api/users/2", Name: "two"})
+		env.AddLink(Link{Rel: "moved", Href: "old"})
+		env.AddLink(Link{Rel: "broken", Href: "missing"})
+		write(w, r, env)
+	})
+	mux.HandleFunc("GET /api/users", func(w http.ResponseWriter, r *http.Request) {
+		write(w, r, inst.Collection(r.Context(), []collectionUser{{ID: 1}, {ID: 2}}, 2, SelfLink("/api/users")))
+	})
+	mux.HandleFunc("GET /api/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		write(w, r, inst.Wrap(r.Context(), &collectionUser{ID: id}))
+	})
+	mux.HandleFunc("GET /api/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/api/users/3", http.StatusMovedPermanently)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_GetAndFollow(t *testing.T) {
+	srv := clientServer(t)
+	c := NewClient(srv.Client())
+	ctx := context.Background()
+
+	root, err := c.Get(ctx, srv.URL+"/api/")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(root.Properties["version"]) != "1" {
+		t.Fatalf("expected the root document, got %v", root.Properties)
+	}
+
+	user, err := c.Follow(ctx, root, "user", map[string]any{"id": 7})
+	if err != nil {
+		t.Fatalf("Follow: %v", err)
+	}
+	if self := user.Links["self"]; len(self) != 1 || self[0].Href != "/api/users/7" {
+		t.Fatalf("expected user 7, got %+v", user.Links)
+	}
+
+	users, err := c.Follow(ctx, user, "up", nil)
+	if err != nil {
+		t.Fatalf("Follow up: %v", err)
+	}
+	items, err := EmbeddedAs[Resource](users, "items")
+	if err != nil || len(items) != 2 {
+		t.Fatalf("expected two users, got %v (%v)", items, err)
+	}
+	second, err := c.Follow(ctx, &items[1], "up", nil)
+	if err != nil {
+		t.Fatalf("expected an embedded item's relative link to resolve against its self link, got %v", err)
+	}
+	if len(second.Links["self"]) != 1 || second.Links["self"][0].Href != "/api/users" {
+		t.Fatalf("expected the users page, got %+v", second.Links)
+	}
+
+	two, err := c.FollowName(ctx, root, "alternate", "two", nil)
+	if err != nil {
+		t.Fatalf("FollowName: %v", err)
+	}
+	if string(two.Properties["id"]) != "2" {
+		t.Fatalf("expected user 2, got %v", two.Properties)
+	}
+}
+
+func TestClient_FollowErrors(t *testing.T) {
+	srv := clientServer(t)
+	c := NewClient(srv.Client())
+	ctx := context.Background()
+	root, err := c.Get(ctx, srv.URL+"/api/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Follow(ctx, root, "alternate", nil); !errors.Is(err, ErrAmbiguousLink) {
+		t.Errorf("expected ErrAmbiguousLink, got %v", err)
+	}
+	if _, err := c.Follow(ctx, root, "next", nil); !errors.Is(err, ErrLinkNotFound) {
+		t.Errorf("expected ErrLinkNotFound, got %v", err)
+	}
+	if _, err := c.FollowName(ctx, root, "alternate", "three", nil); !errors.Is(err, ErrLinkNotFound) {
+		t.Errorf("expected ErrLinkNotFound for an unknown name, got %v", err)
+	}
+
+	var statusErr *StatusError
+	if _, err := c.Follow(ctx, root, "broken", nil); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a 404 StatusError, got %v", err)
+	} else if statusErr.URL != srv.URL+"/api/missing" {
+		t.Errorf("expected the resolved URL in the error, got %s", statusErr.URL)
+	}
+}
+
+func TestClient_Redirects(t *testing.T) {
+	srv := clientServer(t)
+	ctx := context.Background()
+	root, err := NewClient(srv.Client()).Get(ctx, srv.URL+"/api/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := NewClient(srv.Client()).Follow(ctx, root, "moved", nil)
+	if err != nil {
+		t.Fatalf("expected the redirect to be followed, got %v", err)
+	}
+	if up, err := NewClient(srv.Client()).Follow(ctx, moved, "up", nil); err != nil || len(up.Links["self"]) != 1 {
+		t.Fatalf("expected relative links to resolve against the final URL, got %v", err)
+	}
+
+	hc := srv.Client()
+	hc.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	var statusErr *StatusError
+	_, err = NewClient(hc).Follow(ctx, root, "moved", nil)
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusMovedPermanently || statusErr.Location != "/api/users/3" {
+		t.Fatalf("expected a 301 StatusError with its Location, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import "maps"
+
+// Clone returns a copy of the envelope whose links and embedded resources can be
+// mutated without affecting the original. Embedded envelopes are cloned
+// recursively.
+//
+// Data is shared by reference: the clone wraps the same value, so mutating the
+// data itself is visible through both envelopes.
+//
+// # Example
+//
+//	audit := env.Clone()
+//	audit.AddLink(hal.Link{Rel: "internal:trace", Href: "/traces/42"})
+func (e *Envelope) Clone() *Envelope {
+	if e == nil {
+		return nil
+	}
+	c := *e
+	c.links = cloneLinks(e.links)
+	c.embedded = cloneEmbedded(e.embedded)
+	c.templates = maps.Clone(e.templates)
+	return &c
+}
+
+// Clone returns a copy of the page whose links, embedded items and Meta can
+// be mutated without affecting the original. Item envelopes are cloned
+// recursively; their Data is shared by reference.
+func (p *CollectionPage) Clone() *CollectionPage {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	c.Links = cloneLinks(p.Links)
+	c.Embedded = cloneEmbedded(p.Embedded)
+	c.Meta = maps.Clone(p.Meta)
+	return &c
+}
+
+func cloneLinks(links map[string]any) map[string]any {
+	if links == nil {
+		return nil
+	}
+	out := make(map[string]any, len(links))
+	for rel, v := range links {
+		switch vv := v.(type) {
+		case []any:
+			out[rel] = append([]any(nil), vv...)
+		case []Link:
+			out[rel] = append([]Link(nil), vv...)
+		default:
+			out[rel] = v
+		}
+	}
+	return out
+}
+
+func cloneEmbedded(embedded map[string]any) map[string]any {
+	if embedded == nil {
+		return nil
+	}
+	out := make(map[string]any, len(embedded))
+	for rel, v := range embedded {
+		out[rel] = cloneEmbeddedValue(v)
+	}
+	return out
+}
+
+func cloneEmbeddedValue(v any) any {
+	switch vv := v.(type) {
+	case *Envelope:
+		return vv.Clone()
+	case *CollectionPage:
+		return vv.Clone()
+	case []*Envelope:
+		out := make([]*Envelope, len(vv))
+		for i, item := range vv {
+			out[i] = item.Clone()
+		}
+		return out
+	case []any:
+		out := make([]any, len(vv))
+		for i, item := range vv {
+			out[i] = cloneEmbeddedValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
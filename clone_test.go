@@ -0,0 +1,72 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func marshalString(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestEnvelopeClone_MutationIsolated(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, _ *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/1"}, {Rel: "item", Href: "/a"}, {Rel: "item", Href: "/b"}}
+	})
+
+	env := inst.Wrap(context.Background(), &collectionUser{ID: 1})
+	nested := inst.Wrap(context.Background(), &collectionUser{ID: 2})
+	env.embedded = map[string]any{"friend": nested}
+	before := marshalString(t, env)
+
+	c := env.Clone()
+	c.AddLink(Link{Rel: "self", Href: "/audit"})
+	c.AddLink(Link{Rel: "item", Href: "/c"})
+	c.embedded["friend"].(*Envelope).AddLink(Link{Rel: "internal", Href: "/trace"})
+	c.embedded["extra"] = nested
+
+	if after := marshalString(t, env); after != before {
+		t.Fatalf("original changed after clone mutation:\nbefore %s\nafter  %s", before, after)
+	}
+	if marshalString(t, c) == before {
+		t.Fatal("expected clone output to diverge")
+	}
+	if c.Data != env.Data {
+		t.Fatal("expected Data to be shared by reference")
+	}
+}
+
+func TestEnvelopeClone_Nil(t *testing.T) {
+	var e *Envelope
+	if e.Clone() != nil {
+		t.Fatal("expected nil clone of nil envelope")
+	}
+}
+
+func TestCollectionPageClone_MutationIsolated(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, _ *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users"}}
+	})
+
+	page := inst.Collection(context.Background(), []*collectionUser{{ID: 1}, {ID: 2}}, 2, Link{Rel: "self", Href: "/users"})
+	before := marshalString(t, page)
+
+	c := page.Clone()
+	c.Links["next"] = Link{Href: "/users?page=2"}
+	c.Embedded["items"].([]*Envelope)[0].AddLink(Link{Rel: "edit", Href: "/users/1"})
+
+	if after := marshalString(t, page); after != before {
+		t.Fatalf("original changed after clone mutation:\nbefore %s\nafter  %s", before, after)
+	}
+}
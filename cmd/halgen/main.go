@@ -0,0 +1,65 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+// Command halgen generates typed HAL link builders from a JSON route table.
+//
+// Usage:
+//
+//	halgen -in routes.json -pkg links [-out links_gen.go]
+//
+// The route table is a JSON array of halgen.RouteSpec objects:
+//
+//	[
+//	  {"name": "UserSelf", "rel": "self", "href": "/users/{id}"},
+//	  {"name": "OrdersSearch", "rel": "search", "href": "/orders{?q,page}"}
+//	]
+//
+// It is meant to be run with go:generate:
+//
+//	//go:generate go run github.com/Emin-ACIKGOZ/go-hal/cmd/halgen -in routes.json -pkg links -out links_gen.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Emin-ACIKGOZ/go-hal/halgen"
+)
+
+func main() {
+	in := flag.String("in", "", "route table `file` (JSON array of route specs)")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package `name` of the generated file")
+	out := flag.String("out", "", "output `file` (default stdout)")
+	flag.Parse()
+
+	if err := run(*in, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "halgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, pkg, out string) error {
+	if in == "" {
+		return fmt.Errorf("-in is required")
+	}
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+	var routes []halgen.RouteSpec
+	if err := json.Unmarshal(data, &routes); err != nil {
+		return fmt.Errorf("parsing %s: %w", in, err)
+	}
+
+	src, err := halgen.Generate(pkg, routes)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
@@ -5,7 +5,14 @@ package hal
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
 	"reflect"
+	"slices"
+	"strings"
+
+	json "github.com/goccy/go-json"
 )
 
 // CollectionPage represents a standard HAL collection response.
@@ -15,41 +22,519 @@ type CollectionPage struct {
 	Embedded map[string]any `json:"_embedded"`
 	Count    int            `json:"count"`
 	Total    int            `json:"total,omitempty"`
+
+	// Count64 and Total64 hold a count or total beyond the range of int,
+	// as on 32-bit platforms. Set Count and Total otherwise. A non-zero
+	// Count64 or Total64 is written in place of its int field; when both
+	// are non-zero they must be equal, or marshaling fails with an error
+	// wrapping ErrConflictingCounts. StreamCollection counts in Count64;
+	// see also WithTotal64.
+	Count64 int64 `json:"-"`
+	Total64 int64 `json:"-"`
+
+	// TotalKnown emits total even when it is 0. A zero total is otherwise
+	// taken to be unknown and omitted. See WithAlwaysEmitTotal.
+	TotalKnown bool `json:"-"`
+
+	// Meta holds additional top-level members, such as "page" or "facets",
+	// written after count and total in sorted key order. Keys starting
+	// with "_" and the keys "count" and "total" are rejected with an error
+	// wrapping ErrReservedMetaKey.
+	Meta map[string]any `json:"-"`
+
+	instance *Instance       // Resolves CURIEs at marshal time
+	adjust   func(Link) Link // Applied by AddLink, as to the self link
+	err      error           // Item generator failure; see Err
 }
 
-// Collection creates a CollectionPage using the DefaultInstance.
-func Collection[T any](ctx context.Context, items []*T, total int, selfLink Link) *CollectionPage {
-	return DefaultInstance.Collection(ctx, items, total, selfLink)
+// AddLink adds a link to the page, such as a "next" or "prev" pagination
+// link. Like Envelope.AddLink, a second link with the same rel turns the
+// entry into an array. The link is adjusted like the page's self link: the
+// rewriters and base URL in effect when the page was built apply to it.
+//
+// # Example
+//
+//	page := inst.Collection(ctx, users, total, hal.SelfLink("/users?page=2"))
+//	page.AddLink(hal.Link{Rel: "next", Href: "/users?page=3"})
+//	page.AddLink(hal.Link{Rel: "prev", Href: "/users?page=1"})
+func (p *CollectionPage) AddLink(l Link) {
+	if p.adjust != nil {
+		l = p.adjust(l)
+	}
+	if p.Links == nil {
+		p.Links = make(map[string]any, defaultLinksCapacity)
+	}
+	appendRel(p.Links, l.Rel, l)
+}
+
+// defaultItemsRel is the embedded rel used for collection items unless
+// overridden with WithDefaultItemsRel or WithItemsRel.
+const defaultItemsRel = "items"
+
+// CollectionOption configures a single Collection call.
+type CollectionOption func(*collectionConfig)
+
+type collectionConfig struct {
+	itemsRel    string
+	links       []Link
+	meta        map[string]any
+	wrapOpts    []WrapOption
+	errorPolicy CollectionErrorPolicy
+	total64     int64
+	strictErrs  bool // Return strict mode failures of items; set by the E variants
+}
+
+// WithItemsRel sets the embedded rel holding the items for one Collection call,
+// taking precedence over the instance default.
+//
+// # Example
+//
+//	page := inst.Collection(ctx, users, total, self, hal.WithItemsRel("users"))
+func WithItemsRel(rel string) CollectionOption {
+	return func(c *collectionConfig) {
+		c.itemsRel = rel
+	}
+}
+
+// WithPageLinks adds links to the page, as if AddLink had been called for
+// each. A link with Rel "self" replaces the selfLink given to Collection.
+//
+// # Example
+//
+//	page := inst.Collection(ctx, users, total, hal.SelfLink("/users?page=2"),
+//	    hal.WithPageLinks(
+//	        hal.Link{Rel: "next", Href: "/users?page=3"},
+//	        hal.Link{Rel: "prev", Href: "/users?page=1"},
+//	    ),
+//	)
+func WithPageLinks(links ...Link) CollectionOption {
+	return func(c *collectionConfig) {
+		c.links = append(c.links, links...)
+	}
+}
+
+// WithMeta adds top-level members to the page, stored in
+// CollectionPage.Meta. Entries of repeated calls are merged.
+//
+// # Example
+//
+//	page := inst.Collection(ctx, users, total, self,
+//	    hal.WithMeta(map[string]any{"page": 2, "query_time_ms": 12}))
+func WithMeta(meta map[string]any) CollectionOption {
+	return func(c *collectionConfig) {
+		if c.meta == nil {
+			c.meta = make(map[string]any, len(meta))
+		}
+		maps.Copy(c.meta, meta)
+	}
+}
+
+// WithTotal64 sets the page's total as an int64, stored in
+// CollectionPage.Total64, for totals that may not fit in an int on 32-bit
+// platforms. It takes precedence over the total argument; Total is set to
+// the same value when it fits in an int, and to 0 otherwise.
+//
+// # Example
+//
+//	page := inst.Collection(ctx, events, 0, self, hal.WithTotal64(eventCount))
+func WithTotal64(total int64) CollectionOption {
+	return func(c *collectionConfig) {
+		c.total64 = total
+	}
+}
+
+// WithAlwaysEmitTotal sets CollectionPage.TotalKnown on the pages built by
+// Collection and PaginatedCollection, so a total of 0 is emitted rather
+// than omitted. Pages built by CursorCollection, whose total is unknown,
+// still omit it.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithAlwaysEmitTotal())
+func WithAlwaysEmitTotal() InstanceOption {
+	return func(i *Instance) {
+		i.emitTotal = true
+	}
+}
+
+// WithDefaultItemsRel sets the embedded rel used for collection items on this
+// instance, replacing "items". Per-call WithItemsRel still wins.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithDefaultItemsRel("results"))
+func WithDefaultItemsRel(rel string) InstanceOption {
+	return func(i *Instance) {
+		i.itemsRel = rel
+	}
+}
+
+// DefaultItemsRel returns the embedded rel this instance uses for collection
+// items. Adapters such as the OpenAPI package use it to document the same key.
+func (i *Instance) DefaultItemsRel() string {
+	if i.itemsRel == "" {
+		return defaultItemsRel
+	}
+	return i.itemsRel
+}
+
+// Collection creates a CollectionPage using the instance stored in ctx by
+// NewContext, or the DefaultInstance if there is none. Every item is wrapped
+// with that same instance.
+func Collection[T any](ctx context.Context, items []*T, total int, selfLink Link, opts ...CollectionOption) *CollectionPage {
+	return instanceFor(ctx).Collection(ctx, items, total, selfLink, opts...)
+}
+
+// CollectionOf is like Collection for a slice of values, such as the []User
+// returned by a repository, without building a slice of pointers first. See
+// Instance.Collection for how the elements are wrapped.
+//
+// # Example
+//
+//	users, total := repo.List(ctx) // []User
+//	page := hal.CollectionOf(ctx, users, total, hal.Link{Rel: "self", Href: "/users"})
+func CollectionOf[T any](ctx context.Context, items []T, total int, selfLink Link, opts ...CollectionOption) *CollectionPage {
+	return instanceFor(ctx).Collection(ctx, items, total, selfLink, opts...)
 }
 
 // Collection wraps a slice of items into a HAL CollectionPage.
 // It iterates over the items, wraps each one using the registered generators,
-// and constructs the embedded items list.
+// and constructs the embedded items list. Items that are already *Envelope
+// values are embedded as-is, so their generators do not run a second time.
+//
+// Items are embedded under the instance's DefaultItemsRel unless overridden
+// with WithItemsRel.
+//
+// items may be a slice of values ([]T), of pointers ([]*T) or of interface
+// values ([]any), and the three produce the same embedded output. A value
+// whose type has no links registered, while *T has, is wrapped through a
+// pointer: for []T the address of the slice element, so the slice must not
+// be modified until the page is marshaled, and for []any a copy. Nil
+// pointers and nil interface values are skipped and not counted, since an
+// embedded resource must be a JSON object.
 //
-// This method panics if items is not a slice.
-func (i *Instance) Collection(ctx context.Context, items any, total int, selfLink Link) *CollectionPage {
+// This method panics with an error wrapping ErrNilData if items is a nil
+// interface, or ErrNotASlice if items is not a slice; CollectionE returns
+// these errors instead. A nil slice yields an empty page with count 0.
+//
+// When a RegisterInstanceE generator fails, the item's *GeneratorError is
+// recorded on the returned page instead, unless
+// WithCollectionErrorPolicy(SkipOnError) is given: the page holds no items,
+// CollectionPage.Err reports the error and MarshalJSON fails with it, as for
+// an Envelope. Strict instances panic with it, unless WithStrictErrors is
+// given.
+//
+// ctx is checked between items: once it is cancelled no further generators
+// run and the page holds only the items wrapped so far. Use CollectionE to
+// get the cancellation reported as an error instead.
+func (i *Instance) Collection(ctx context.Context, items any, total int, selfLink Link, opts ...CollectionOption) *CollectionPage {
+	return i.collectionResult(i.buildCollection(ctx, items, total, selfLink, opts))
+}
+
+// collectionResult returns the page Collection returns for the result of
+// buildCollection. An item's generator error is recorded on an empty page,
+// except in strict mode, which panics with it as with invalid items.
+func (i *Instance) collectionResult(page *CollectionPage, err error) *CollectionPage {
+	if err == nil || page != nil {
+		return page
+	}
+	var genErr *GeneratorError
+	if !errors.As(err, &genErr) || i.strictMode && !i.strictErrors {
+		panic(err)
+	}
+	return &CollectionPage{Links: map[string]any{}, instance: i, err: err}
+}
+
+// Err returns the error recorded while the page was built: the
+// *GeneratorError of the item that failed it, if any. MarshalJSON fails
+// with the same error. Pages returned by CollectionE never hold one.
+func (p *CollectionPage) Err() error {
+	return p.err
+}
+
+// buildCollection implements Collection and CollectionE. When ctx is
+// cancelled part-way it returns the partial page along with the error; for
+// invalid items the page is nil.
+func (i *Instance) buildCollection(ctx context.Context, items any, total int, selfLink Link, opts []CollectionOption) (*CollectionPage, error) {
+	if items == nil {
+		return nil, fmt.Errorf("hal: collection items: %w: got a nil interface", ErrNilData)
+	}
+	cfg, err := i.collectionConfig(opts)
+	if err != nil {
+		return nil, err
+	}
 	val := reflect.ValueOf(items)
 	if val.Kind() != reflect.Slice {
-		panic("hal: Collection items must be a slice")
+		return nil, fmt.Errorf("%w, got %T (%s)", ErrNotASlice, items, val.Kind())
 	}
 
 	count := val.Len()
-	embeddedItems := make([]*Envelope, count)
+	embeddedItems := make([]*Envelope, 0, count)
+	elem := val.Type().Elem()
+	byPointer := i.wrapsByPointer(elem)
+	if byPointer {
+		elem = reflect.PointerTo(elem)
+	}
+	wrap := i.collectionItemWrapper(elem, cfg.wrapOpts)
+	var boxed map[reflect.Type]bool // wrapsByPointer per dynamic type of []any items
 
+	var ctxErr error
 	for idx := 0; idx < count; idx++ {
-		item := val.Index(idx).Interface()
-		embeddedItems[idx] = i.Wrap(ctx, item)
+		if err := contextErr(ctx); err != nil {
+			ctxErr = fmt.Errorf("hal: collection cancelled after %d of %d items: %w", idx, count, err)
+			break
+		}
+		v := val.Index(idx)
+		switch {
+		case byPointer:
+			v = v.Addr()
+		case v.Kind() == reflect.Interface:
+			if v = v.Elem(); !v.IsValid() {
+				continue
+			}
+			if t := v.Type(); t.Kind() != reflect.Pointer {
+				by, ok := boxed[t]
+				if !ok {
+					if boxed == nil {
+						boxed = make(map[reflect.Type]bool)
+					}
+					by = i.wrapsByPointer(t)
+					boxed[t] = by
+				}
+				if by {
+					p := reflect.New(t)
+					p.Elem().Set(v)
+					v = p
+				}
+			}
+		}
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			continue
+		}
+		env, err := cfg.wrapItem(ctx, idx, v.Interface(), wrap)
+		if err != nil {
+			return nil, err
+		}
+		if env != nil {
+			embeddedItems = append(embeddedItems, env)
+		}
 	}
+	return i.newCollectionPage(ctx, cfg, embeddedItems, total, selfLink), ctxErr
+}
 
-	links := make(map[string]any)
-	links["self"] = selfLink
+// returnStrictErrors makes the E variants of Collection return strict mode
+// failures of items instead of panicking.
+func returnStrictErrors(c *collectionConfig) {
+	c.strictErrs = true
+}
+
+// collectionConfig applies opts over the instance defaults.
+func (i *Instance) collectionConfig(opts []CollectionOption) (collectionConfig, error) {
+	cfg := collectionConfig{itemsRel: i.DefaultItemsRel()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg, checkMeta(cfg.meta)
+}
 
-	return &CollectionPage{
-		Links: links,
+// wrapItem wraps item, the collection item at idx, with wrap, leaving its
+// curies to the page. Envelopes are returned as-is. A generator failure is
+// returned unless the error policy skips the item, in which case the
+// envelope is nil. A strict mode failure is returned for the E variants and
+// handled as by Wrap otherwise.
+func (cfg *collectionConfig) wrapItem(ctx context.Context, idx int, item any, wrap func(context.Context, any) *Envelope) (*Envelope, error) {
+	if env, ok := item.(*Envelope); ok {
+		return env, nil
+	}
+	env := wrap(ctx, item)
+	if env.strictErr != nil {
+		if cfg.strictErrs {
+			return nil, fmt.Errorf("hal: collection item %d: %w", idx, env.strictErr)
+		}
+		env.instance.failStrict(env)
+	}
+	if env.linkErr != nil {
+		if cfg.errorPolicy == SkipOnError {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("hal: collection item %d: %w", idx, env.linkErr)
+	}
+	env.hoistedCuries = true
+	return env, nil
+}
+
+// newCollectionPage builds the page holding the wrapped items.
+func (i *Instance) newCollectionPage(ctx context.Context, cfg collectionConfig, embeddedItems []*Envelope, total int, selfLink Link) *CollectionPage {
+	rewriter, base := i.rewriterFor(ctx), i.baseURLFor(ctx)
+	adjust := func(l Link) Link {
+		l = i.rewriteLink(i.autoTemplate(l), rewriter)
+		if base != nil {
+			l = absoluteLink(l, base)
+		}
+		return l
+	}
+	page := &CollectionPage{
+		Links: map[string]any{"self": adjust(selfLink)},
 		Embedded: map[string]any{
-			"items": embeddedItems,
+			cfg.itemsRel: embeddedItems,
 		},
-		Count: count,
-		Total: total,
+		Count:      len(embeddedItems),
+		Total:      total,
+		TotalKnown: i.emitTotal,
+		Meta:       cfg.meta,
+		instance:   i,
+		adjust:     adjust,
+	}
+	if cfg.total64 != 0 {
+		page.Total, page.Total64 = int(cfg.total64), cfg.total64
+		if int64(page.Total) != cfg.total64 {
+			page.Total = 0
+		}
+	}
+	for _, l := range cfg.links {
+		if l.Rel == "self" {
+			page.Links["self"] = adjust(l)
+			continue
+		}
+		page.AddLink(l)
+	}
+	return page
+}
+
+// wrapsByPointer reports whether collection items of the value type t are
+// wrapped as *t: when links are registered for *t but not for t.
+func (i *Instance) wrapsByPointer(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer || t.Kind() == reflect.Interface {
+		return false
+	}
+	return !i.resolve(t).found() && i.resolve(reflect.PointerTo(t)).found()
+}
+
+// linksForMarshal returns the page's links with the curies used by them, and
+// by the item envelopes the page wrapped, added. Those items leave their
+// curies to the page, so each is emitted once, at the top level; with
+// WithHoistedCuries, so do the resources embedded in them.
+func (p *CollectionPage) linksForMarshal() map[string]any {
+	if p.instance == nil {
+		return p.Links
+	}
+	curies := p.instance.curieSnapshot()
+	if len(curies) == 0 {
+		return arrayLinks(p.Links, nil)
+	}
+	rels := make(map[string]any, len(p.Links))
+	for rel := range p.Links {
+		rels[rel] = nil
+	}
+	for _, v := range p.Embedded {
+		p.instance.collectHoistedRels(rels, v, curies)
+	}
+	links := p.Links
+	if used := resolveCuries(rels, curies); len(used) > 0 {
+		links = maps.Clone(links)
+		if links == nil {
+			links = make(map[string]any, 1)
+		}
+		links[RelCuries] = mergeCuries(links[RelCuries], used)
+	}
+	return arrayLinks(links, nil)
+}
+
+// checkMeta reports the reserved keys of meta, if any.
+func checkMeta(meta map[string]any) error {
+	var reserved []string
+	for key := range meta {
+		if strings.HasPrefix(key, "_") || key == "count" || key == "total" {
+			reserved = append(reserved, key)
+		}
+	}
+	if len(reserved) == 0 {
+		return nil
+	}
+	slices.Sort(reserved)
+	return fmt.Errorf("%w: %q", ErrReservedMetaKey, reserved)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// If an embedded item envelope fails to serialize, the returned error
+// identifies the item by its index. A page holding an error (see Err) fails
+// with it.
+func (p CollectionPage) MarshalJSON() ([]byte, error) {
+	type plain struct {
+		Links    map[string]any `json:"_links"`
+		Embedded map[string]any `json:"_embedded"`
+		Count    int64          `json:"count"`
+		Total    int64          `json:"total,omitempty"`
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if err := checkMeta(p.Meta); err != nil {
+		return nil, err
+	}
+	if err := p.checkCounts(); err != nil {
+		return nil, err
+	}
+	count, total := p.counts()
+	b, err := json.Marshal(plain{p.linksForMarshal(), p.Embedded, count, total})
+	if err == nil {
+		if p.TotalKnown && total == 0 {
+			b = append(b[:len(b)-1], `,"total":0}`...)
+		}
+		return p.appendMeta(b)
+	}
+	// Slow path: locate the failing item so the error is actionable.
+	for _, v := range p.Embedded {
+		items, ok := v.([]*Envelope)
+		if !ok {
+			continue
+		}
+		for idx, item := range items {
+			if _, itemErr := item.MarshalJSON(); itemErr != nil {
+				return nil, fmt.Errorf("hal: collection item %d: %w", idx, itemErr)
+			}
+		}
+	}
+	return nil, err
+}
+
+// checkCounts reports a Count64 or Total64 that disagrees with its int
+// field.
+func (p *CollectionPage) checkCounts() error {
+	if p.Count != 0 && p.Count64 != 0 && int64(p.Count) != p.Count64 {
+		return fmt.Errorf("%w: count %d, count64 %d", ErrConflictingCounts, p.Count, p.Count64)
+	}
+	if p.Total != 0 && p.Total64 != 0 && int64(p.Total) != p.Total64 {
+		return fmt.Errorf("%w: total %d, total64 %d", ErrConflictingCounts, p.Total, p.Total64)
+	}
+	return nil
+}
+
+// counts returns the count and total written for p, taking Count64 and
+// Total64 over Count and Total when set. It is the only place the members
+// are chosen; see checkCounts.
+func (p *CollectionPage) counts() (count, total int64) {
+	count, total = int64(p.Count), int64(p.Total)
+	if p.Count64 != 0 {
+		count = p.Count64
+	}
+	if p.Total64 != 0 {
+		total = p.Total64
+	}
+	return count, total
+}
+
+// appendMeta splices the Meta members into b, the page's JSON object.
+func (p CollectionPage) appendMeta(b []byte) ([]byte, error) {
+	if len(p.Meta) == 0 {
+		return b, nil
+	}
+	meta, err := json.Marshal(p.Meta)
+	if err != nil {
+		return nil, fmt.Errorf("hal: marshaling collection metadata: %w", err)
 	}
+	out := make([]byte, 0, len(b)+len(meta)-1)
+	out = append(out, b[:len(b)-1]...)
+	out = append(out, ',')
+	return append(out, meta[1:]...), nil
 }
@@ -0,0 +1,82 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+//go:build go1.23
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// CollectionSeq builds a CollectionPage from the items yielded by seq, such
+// as rows read from a database cursor, wrapping each as it arrives instead
+// of materializing a slice first. It uses the instance stored in ctx by
+// NewContext, or the DefaultInstance. Nil items are skipped, and count is
+// the number of items embedded.
+//
+// Like Collection, it records the item's *GeneratorError on the page when a
+// RegisterInstanceE generator fails, unless WithCollectionErrorPolicy(
+// SkipOnError) is given, and once ctx is cancelled it stops consuming seq
+// and returns the items wrapped so far. Use CollectionSeqE to get these
+// failures as errors.
+//
+// This function is only built with Go 1.23 or later.
+//
+// # Example
+//
+//	page := hal.CollectionSeq(ctx, repo.Users(ctx), total, hal.SelfLink("/users"))
+func CollectionSeq[T any](ctx context.Context, seq iter.Seq[*T], total int, selfLink Link, opts ...CollectionOption) *CollectionPage {
+	i := instanceFor(ctx)
+	return i.collectionResult(buildCollectionSeq(ctx, i, seq, total, selfLink, opts))
+}
+
+// CollectionSeqE is like CollectionSeq, but returns an error instead of
+// panicking, and reports cancellation: once ctx is cancelled it stops
+// consuming seq and returns nil with an error wrapping ctx.Err().
+//
+// This function is only built with Go 1.23 or later.
+func CollectionSeqE[T any](ctx context.Context, seq iter.Seq[*T], total int, selfLink Link, opts ...CollectionOption) (*CollectionPage, error) {
+	page, err := buildCollectionSeq(ctx, instanceFor(ctx), seq, total, selfLink, append(opts[:len(opts):len(opts)], returnStrictErrors))
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// buildCollectionSeq implements CollectionSeq and CollectionSeqE like
+// buildCollection does for slices.
+func buildCollectionSeq[T any](ctx context.Context, i *Instance, seq iter.Seq[*T], total int, selfLink Link, opts []CollectionOption) (*CollectionPage, error) {
+	cfg, err := i.collectionConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	wrap := i.collectionItemWrapper(reflect.TypeFor[*T](), cfg.wrapOpts)
+
+	var embeddedItems []*Envelope
+	var ctxErr error
+	idx := 0
+	for item := range seq {
+		if err := contextErr(ctx); err != nil {
+			ctxErr = fmt.Errorf("hal: collection cancelled after %d items: %w", idx, err)
+			break
+		}
+		if item != nil {
+			env, err := cfg.wrapItem(ctx, idx, item, wrap)
+			if err != nil {
+				return nil, err
+			}
+			if env != nil {
+				embeddedItems = append(embeddedItems, env)
+			}
+		}
+		idx++
+	}
+	if embeddedItems == nil {
+		embeddedItems = []*Envelope{}
+	}
+	return i.newCollectionPage(ctx, cfg, embeddedItems, total, selfLink), ctxErr
+}
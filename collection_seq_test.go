@@ -0,0 +1,66 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+//go:build go1.23
+
+package hal
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestCollectionSeq_MatchesCollection(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	ctx := NewContext(context.Background(), inst)
+	users := []*collectionUser{{ID: 1}, nil, {ID: 2}}
+
+	got := marshalString(t, CollectionSeq(ctx, slices.Values(users), 10, SelfLink("/users"), WithItemsRel("users")))
+	want := marshalString(t, inst.Collection(ctx, users, 10, SelfLink("/users"), WithItemsRel("users")))
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	empty := func(func(*collectionUser) bool) {}
+	if got := marshalString(t, CollectionSeq(ctx, iter.Seq[*collectionUser](empty), 0, SelfLink("/users"))); got != `{"_links":{"self":{"href":"/users"}},"_embedded":{"items":[]},"count":0}` {
+		t.Fatalf("expected an empty page, got %s", got)
+	}
+}
+
+// cancellingSeq yields users until the consumer stops, calling cancel
+// before yielding the fourth. It counts the users yielded in *yielded.
+func cancellingSeq(cancel context.CancelFunc, yielded *int) iter.Seq[*collectionUser] {
+	return func(yield func(*collectionUser) bool) {
+		for n := 0; n < 100; n++ {
+			*yielded++
+			if n == 3 {
+				cancel()
+			}
+			if !yield(&collectionUser{ID: n}) {
+				return
+			}
+		}
+	}
+}
+
+func TestCollectionSeq_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	yielded := 0
+	page := CollectionSeq(ctx, cancellingSeq(cancel, &yielded), 0, SelfLink("/users"))
+	if page.Count != 3 || yielded != 4 {
+		t.Fatalf("expected 3 items from 4 yielded, got %d from %d", page.Count, yielded)
+	}
+
+	ctx, cancelE := context.WithCancel(context.Background())
+	defer cancelE()
+	if _, err := CollectionSeqE(ctx, cancellingSeq(cancelE, &yielded), 0, SelfLink("/users")); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
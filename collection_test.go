@@ -6,6 +6,9 @@ package hal
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -78,3 +81,207 @@ func TestCollection_MarshalJSON_Recursive(t *testing.T) {
 		t.Fatal("embedded item missing 'self' link (recursive marshaling failed)")
 	}
 }
+
+func TestCollection_DefaultItemsRel(t *testing.T) {
+	type User struct {
+		ID int
+	}
+
+	inst := New(WithDefaultItemsRel("results"))
+	page := inst.Collection(context.Background(), []*User{{ID: 1}}, 1, Link{Rel: "self", Href: "/users"})
+
+	if _, ok := page.Embedded["results"]; !ok {
+		t.Fatalf("expected embedded results, got %v", page.Embedded)
+	}
+	if inst.DefaultItemsRel() != "results" {
+		t.Fatalf("expected accessor to report results, got %s", inst.DefaultItemsRel())
+	}
+	if New().DefaultItemsRel() != "items" {
+		t.Fatal("expected items as the default rel")
+	}
+}
+
+func TestCollection_PerCallItemsRelWins(t *testing.T) {
+	type User struct {
+		ID int
+	}
+
+	inst := New(WithDefaultItemsRel("results"))
+	page := inst.Collection(context.Background(), []*User{{ID: 1}}, 1, Link{Rel: "self", Href: "/users"}, WithItemsRel("users"))
+
+	if _, ok := page.Embedded["users"]; !ok {
+		t.Fatalf("expected embedded users, got %v", page.Embedded)
+	}
+	if _, ok := page.Embedded["results"]; ok {
+		t.Fatal("expected instance default to be overridden")
+	}
+}
+
+func TestCollection_MixedTypes(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	RegisterInstance(inst, func(_ context.Context, o *mergeOrder) []Link {
+		return []Link{{Rel: "self", Href: "/orders/" + itoa(o.ID)}}
+	})
+
+	items := []any{&collectionUser{ID: 1}, &mergeOrder{ID: 2}, &collectionUser{ID: 3}, map[string]int{"n": 4}}
+	got := marshalString(t, inst.Collection(context.Background(), items, 4, SelfLink("/mixed")))
+	want := `{"_links":{"self":{"href":"/mixed"}},"_embedded":{"items":[` +
+		`{"id":1,"_links":{"self":{"href":"/users/1"}}},` +
+		`{"id":2,"_links":{"self":{"href":"/orders/2"}}},` +
+		`{"id":3,"_links":{"self":{"href":"/users/3"}}},` +
+		`{"n":4}]},"count":4,"total":4}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestCollection_MatchesWrap(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	users := []*collectionUser{{ID: 1}, {ID: 2}}
+	page := inst.Collection(context.Background(), users, 2, SelfLink("/users"))
+	for idx, env := range page.Embedded["items"].([]*Envelope) {
+		if got, want := marshalString(t, env), marshalString(t, inst.Wrap(context.Background(), users[idx])); got != want {
+			t.Errorf("item %d: expected %s, got %s", idx, want, got)
+		}
+	}
+}
+
+func TestCollection_SliceShapes(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	ctx := context.Background()
+	want := `{"_links":{"self":{"href":"/users"}},"_embedded":{"items":[` +
+		`{"id":1,"_links":{"self":{"href":"/users/1"}}},` +
+		`{"id":2,"_links":{"self":{"href":"/users/2"}}}]},"count":2,"total":2}`
+
+	for name, items := range map[string]any{
+		"values":   []collectionUser{{ID: 1}, {ID: 2}},
+		"pointers": []*collectionUser{{ID: 1}, nil, {ID: 2}},
+		"any":      []any{collectionUser{ID: 1}, nil, &collectionUser{ID: 2}, (*collectionUser)(nil)},
+	} {
+		if got := marshalString(t, inst.Collection(ctx, items, 2, SelfLink("/users"))); got != want {
+			t.Errorf("%s: expected %s, got %s", name, want, got)
+		}
+	}
+	if got := marshalString(t, CollectionOf(NewContext(ctx, inst), []collectionUser{{ID: 1}, {ID: 2}}, 2, SelfLink("/users"))); got != want {
+		t.Errorf("CollectionOf: expected %s, got %s", want, got)
+	}
+}
+
+func TestCollection_ValueTypeRegistration(t *testing.T) {
+	inst := New()
+	RegisterStatic(inst, mergeOrder{}, []Link{{Rel: "self", Href: "/orders/value"}})
+	RegisterStatic(inst, &mergeOrder{}, []Link{{Rel: "self", Href: "/orders/pointer"}})
+
+	page := inst.Collection(context.Background(), []mergeOrder{{ID: 1}}, 1, SelfLink("/orders"))
+	if got := marshalString(t, page); !strings.Contains(got, "/orders/value") {
+		t.Fatalf("expected the value type's links, got %s", got)
+	}
+}
+
+func TestCollectionPage_AddLink(t *testing.T) {
+	inst := New(WithLinkRewriter(PrefixRewriter("/api")))
+	page := inst.Collection(context.Background(), []*collectionUser{{ID: 1}}, 3, SelfLink("/users?page=2"),
+		WithPageLinks(Link{Rel: "next", Href: "/users?page=3"}, Link{Rel: "self", Href: "/users?page=2&size=1"}))
+	page.AddLink(Link{Rel: "prev", Href: "/users?page=1"})
+	page.AddLink(Link{Rel: "alternate", Href: "/users.csv"})
+	page.AddLink(Link{Rel: "alternate", Href: "/users.xml"})
+
+	got := marshalString(t, page)
+	want := `{"_links":{"alternate":[{"href":"/api/users.csv"},{"href":"/api/users.xml"}],` +
+		`"next":{"href":"/api/users?page=3"},"prev":{"href":"/api/users?page=1"},` +
+		`"self":{"href":"/api/users?page=2\u0026size=1"}},`
+	if !strings.HasPrefix(got, want) {
+		t.Fatalf("expected prefix %s, got %s", want, got)
+	}
+
+	var empty CollectionPage
+	empty.AddLink(Link{Rel: "next", Href: "/next"})
+	if l, ok := empty.Links["next"].(Link); !ok || l.Href != "/next" {
+		t.Fatalf("expected AddLink to allocate the links map, got %v", empty.Links)
+	}
+}
+
+func TestCollection_Meta(t *testing.T) {
+	inst := New()
+	page := inst.Collection(context.Background(), []collectionUser{{ID: 1}}, 5, SelfLink("/users"),
+		WithMeta(map[string]any{"per_page": 1, "facets": map[string]int{"active": 4}}),
+		WithMeta(map[string]any{"page": 1}))
+
+	got := marshalString(t, page)
+	want := `{"_links":{"self":{"href":"/users"}},"_embedded":{"items":[{"id":1}]},"count":1,"total":5,` +
+		`"facets":{"active":4},"page":1,"per_page":1}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	for _, key := range []string{"_links", "_extra", "count", "total"} {
+		_, err := inst.CollectionE(context.Background(), []collectionUser{}, 0, SelfLink("/users"), WithMeta(map[string]any{key: 1}))
+		if !errors.Is(err, ErrReservedMetaKey) {
+			t.Errorf("%s: expected ErrReservedMetaKey, got %v", key, err)
+		}
+	}
+	page.Meta["count"] = 3
+	if _, err := json.Marshal(page); !errors.Is(err, ErrReservedMetaKey) {
+		t.Fatalf("expected MarshalJSON to reject reserved keys, got %v", err)
+	}
+}
+
+func TestCollection_TotalKnown(t *testing.T) {
+	ctx := context.Background()
+	if got := marshalString(t, New().Collection(ctx, []collectionUser{}, 0, SelfLink("/users"))); strings.Contains(got, "total") {
+		t.Fatalf("expected a zero total to be omitted by default, got %s", got)
+	}
+
+	inst := New(WithAlwaysEmitTotal())
+	page := inst.Collection(ctx, []collectionUser{}, 0, SelfLink("/users"), WithMeta(map[string]any{"page": 1}))
+	if got, want := marshalString(t, page), `{"_links":{"self":{"href":"/users"}},"_embedded":{"items":[]},"count":0,"total":0,"page":1}`; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	base, _ := url.Parse("/events")
+	if got := marshalString(t, inst.CursorCollection(ctx, []collectionUser{}, "", "", base)); strings.Contains(got, "total") {
+		t.Fatalf("expected cursor pages to omit the unknown total, got %s", got)
+	}
+}
+
+func TestCollection_Total64(t *testing.T) {
+	page := New().Collection(context.Background(), []collectionUser{{ID: 1}}, 1, SelfLink("/events"), WithTotal64(1<<40))
+	if page.Total64 != 1<<40 {
+		t.Fatalf("expected Total64 to be set, got %d", page.Total64)
+	}
+	page.Count64 = 1 << 33
+	if _, err := json.Marshal(page); !errors.Is(err, ErrConflictingCounts) {
+		t.Fatalf("expected ErrConflictingCounts for Count 1 and Count64 1<<33, got %v", err)
+	}
+	page.Count = 0
+	want := `"count":8589934592,"total":1099511627776}`
+	if got := marshalString(t, page); !strings.HasSuffix(got, want) {
+		t.Fatalf("expected the int64 members in %s", got)
+	}
+}
+
+func BenchmarkCollection_10k(b *testing.B) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users"}}
+	})
+	users := make([]*collectionUser, 10000)
+	for idx := range users {
+		users[idx] = &collectionUser{ID: idx}
+	}
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		inst.Collection(ctx, users, len(users), SelfLink("/users"))
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Compose combines generators into one that returns their links
+// concatenated in argument order. Nil generators are skipped.
+//
+// # Example
+//
+//	hal.Register(hal.Compose(selfLinks, auditLinks,
+//	    hal.When(isAdmin, adminLinks),
+//	))
+func Compose[T any](gens ...func(context.Context, *T) []Link) func(context.Context, *T) []Link {
+	return func(ctx context.Context, v *T) []Link {
+		var links []Link
+		for _, gen := range gens {
+			if gen == nil {
+				continue
+			}
+			links = append(links, gen(ctx, v)...)
+		}
+		return links
+	}
+}
+
+// When returns a generator that calls gen only if pred reports true for the
+// same arguments, and returns no links otherwise.
+//
+// # Example
+//
+//	adminLinks := hal.When(isAdmin, func(_ context.Context, u *User) []hal.Link {
+//	    return []hal.Link{{Rel: "delete", Href: fmt.Sprintf("/users/%d", u.ID), Method: "DELETE"}}
+//	})
+func When[T any](pred func(context.Context, *T) bool, gen func(context.Context, *T) []Link) func(context.Context, *T) []Link {
+	return func(ctx context.Context, v *T) []Link {
+		if !pred(ctx, v) {
+			return nil
+		}
+		return gen(ctx, v)
+	}
+}
+
+// RegisterAppend registers gen for *T on i after any generator already
+// registered for that type, instead of replacing it. The generators run in
+// registration order and their links are concatenated; links sharing a rel
+// become an array, as with Envelope.AddLink. Use it when several packages
+// contribute links to the same type. T may be an interface type, as with
+// RegisterInstance.
+//
+// # Example
+//
+//	hal.RegisterInstance(inst, userLinks)        // package users
+//	hal.RegisterAppend(inst, billingLinksForUser) // package billing
+func RegisterAppend[T any](i *Instance, gen func(context.Context, *T) []Link) {
+	registerGenerator(i, gen, true)
+}
+
+// registerGenerator implements RegisterInstance and RegisterAppend.
+func registerGenerator[T any](i *Instance, gen func(context.Context, *T) []Link, chain bool) {
+	targetType := reflect.TypeOf((*T)(nil))
+	if iface := targetType.Elem(); iface.Kind() == reflect.Interface {
+		i.registerInterface(iface, func(ctx context.Context, v any) []Link {
+			iv := v.(T)
+			return gen(ctx, &iv)
+		}, chain)
+		return
+	}
+	adapter := func(ctx context.Context, v any) []Link {
+		return gen(ctx, v.(*T))
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.generators[targetType] = i.replaceGenerator(targetType, i.generators[targetType], adapter, chain)
+	i.chainResource(targetType, adapter, chain)
+	i.registryChanged()
+}
+
+// chainResource updates the RegisterResource generator for t, if any, when
+// gen is registered for t: with chain, gen's links are appended to the
+// resource's; otherwise the resource generator is removed, so gen replaces
+// it. The caller must hold i.mu for writing.
+func (i *Instance) chainResource(t reflect.Type, gen Generator, chain bool) {
+	res := i.resources[t]
+	switch {
+	case res == nil:
+	case chain:
+		i.resources[t] = func(ctx context.Context, v any) ([]Link, map[string]any) {
+			links, embeds := res(ctx, v)
+			return append(links[:len(links):len(links)], gen(ctx, v)...), embeds
+		}
+	default:
+		delete(i.resources, t)
+	}
+}
+
+// replaceGenerator returns the generator to store for t when gen is
+// registered over prev. With chain, gen runs after prev. Otherwise replacing
+// prev is reported: strict instances panic with an error wrapping
+// ErrDuplicateGenerator, others notify the lint hook.
+// The caller must hold i.mu for writing.
+func (i *Instance) replaceGenerator(t reflect.Type, prev, gen Generator, chain bool) Generator {
+	switch {
+	case prev == nil:
+		return gen
+	case chain:
+		return func(ctx context.Context, v any) []Link {
+			links := prev(ctx, v)
+			return append(links[:len(links):len(links)], gen(ctx, v)...)
+		}
+	}
+	err := fmt.Errorf("%w for %v; use RegisterAppend to add links", ErrDuplicateGenerator, t)
+	if i.strictMode {
+		panic(err)
+	}
+	i.lintf("%v", err)
+	return gen
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type adminKey struct{}
+
+func composedUserLinks() func(context.Context, *collectionUser) []Link {
+	self := func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	}
+	audit := func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "audit", Href: "/users/" + itoa(u.ID) + "/audit"}}
+	}
+	isAdmin := func(ctx context.Context, _ *collectionUser) bool {
+		return ctx.Value(adminKey{}) == true
+	}
+	admin := func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "delete", Href: "/users/" + itoa(u.ID), Method: "DELETE"}}
+	}
+	return Compose(self, nil, When(isAdmin, admin), audit)
+}
+
+func TestCompose_WithConditionalPiece(t *testing.T) {
+	gen := composedUserLinks()
+	u := &collectionUser{ID: 1}
+
+	got := gen(context.WithValue(context.Background(), adminKey{}, true), u)
+	want := []Link{
+		{Rel: "self", Href: "/users/1"},
+		{Rel: "delete", Href: "/users/1", Method: "DELETE"},
+		{Rel: "audit", Href: "/users/1/audit"},
+	}
+	if !slices.EqualFunc(got, want, Link.Equal) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	got = gen(context.Background(), u)
+	want = []Link{
+		{Rel: "self", Href: "/users/1"},
+		{Rel: "audit", Href: "/users/1/audit"},
+	}
+	if !slices.EqualFunc(got, want, Link.Equal) {
+		t.Fatalf("expected conditional piece to be skipped, got %v", got)
+	}
+}
+
+func TestCompose_Empty(t *testing.T) {
+	if got := Compose[collectionUser]()(context.Background(), &collectionUser{}); got != nil {
+		t.Fatalf("expected no links, got %v", got)
+	}
+}
+
+func TestWhen_SkipsGeneratorCall(t *testing.T) {
+	called := false
+	gen := When(
+		func(context.Context, *collectionUser) bool { return false },
+		func(context.Context, *collectionUser) []Link {
+			called = true
+			return nil
+		},
+	)
+
+	gen(context.Background(), &collectionUser{})
+	if called {
+		t.Fatal("expected generator not to be called when predicate is false")
+	}
+}
+
+func TestRegisterAppend(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}, {Rel: "related", Href: "/teams/1"}}
+	})
+	RegisterAppend(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "invoices", Href: "/users/" + itoa(u.ID) + "/invoices"}, {Rel: "related", Href: "/accounts/9"}}
+	})
+
+	want := `{"id":1,"_links":{"invoices":{"href":"/users/1/invoices"},` +
+		`"related":[{"href":"/teams/1"},{"href":"/accounts/9"}],"self":{"href":"/users/1"}}}`
+	if got := marshalString(t, inst.Wrap(context.Background(), &collectionUser{ID: 1})); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestRegisterAppend_FirstRegistration(t *testing.T) {
+	inst := New(WithStrictMode())
+	RegisterAppend(inst, func(context.Context, *collectionUser) []Link { return []Link{{Rel: "self", Href: "/u"}} })
+	RegisterAppend(inst, func(context.Context, *collectionUser) []Link { return []Link{{Rel: "edit", Href: "/u"}} })
+	if env := inst.Wrap(context.Background(), &collectionUser{}); !env.HasLink("self") || !env.HasLink("edit") {
+		t.Fatalf("expected both generators to run, got %v", env.Links())
+	}
+}
+
+func TestRegisterInstance_OverwriteReported(t *testing.T) {
+	gen := func(context.Context, *collectionUser) []Link { return nil }
+
+	var msgs []string
+	inst := New(WithLintHook(func(msg string) { msgs = append(msgs, msg) }))
+	RegisterInstance(inst, gen)
+	RegisterInstance(inst, gen)
+	if len(msgs) != 1 || !strings.Contains(msgs[0], "already registered for *hal.collectionUser") {
+		t.Fatalf("expected one lint warning, got %q", msgs)
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrDuplicateGenerator) {
+			t.Fatalf("expected strict mode to panic with ErrDuplicateGenerator, got %v", err)
+		}
+	}()
+	strict := New(WithStrictMode())
+	RegisterInstance(strict, gen)
+	RegisterInstance(strict, gen)
+}
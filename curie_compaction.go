@@ -0,0 +1,121 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"slices"
+	"strings"
+)
+
+// curieRelPlaceholder is the template variable a CURIE href expands.
+const curieRelPlaceholder = "{rel}"
+
+// WithCurieCompaction rewrites fully-qualified rel URIs into CURIE form at
+// marshal time. A rel matching a registered curie href template, with {rel}
+// capturing a non-empty suffix, is serialized as "prefix:suffix" and the curie
+// definition is emitted. Rels matching no template pass through untouched.
+//
+// When several templates match, the one with the longest literal part wins,
+// as it is the most specific; ties are broken by the lexically smallest prefix.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithCurieCompaction())
+//	inst.RegisterCurie("acme", "https://docs.acme.com/rels/{rel}")
+//	// rel "https://docs.acme.com/rels/orders" is emitted as "acme:orders"
+func WithCurieCompaction() InstanceOption {
+	return func(i *Instance) {
+		i.compactCuries = true
+	}
+}
+
+// compactRels returns links with rel URIs rewritten to CURIE form using the
+// given curie definitions. The input map is returned unchanged if no rel
+// matches; otherwise a new map is built. If a compacted rel collides with an
+// existing one, the compacted links are appended after the existing ones.
+func compactRels(links map[string]any, curies map[string]string) map[string]any {
+	if len(curies) == 0 {
+		return links
+	}
+
+	var compacted map[string]string
+	for rel := range links {
+		if c, ok := compactRel(rel, curies); ok {
+			if compacted == nil {
+				compacted = make(map[string]string)
+			}
+			compacted[rel] = c
+		}
+	}
+	if compacted == nil {
+		return links
+	}
+
+	out := make(map[string]any, len(links))
+	for rel, v := range links {
+		if _, ok := compacted[rel]; !ok {
+			out[rel] = v
+		}
+	}
+	rels := make([]string, 0, len(compacted))
+	for rel := range compacted {
+		rels = append(rels, rel)
+	}
+	slices.Sort(rels)
+	for _, rel := range rels {
+		mergeRel(out, compacted[rel], links[rel])
+	}
+	return out
+}
+
+// compactRel returns the CURIE form of rel, if one of the curie templates matches.
+func compactRel(rel string, curies map[string]string) (string, bool) {
+	if !strings.Contains(rel, "://") {
+		return "", false
+	}
+	bestPrefix, bestSuffix, bestLen := "", "", -1
+	for prefix, href := range curies {
+		before, after, ok := strings.Cut(href, curieRelPlaceholder)
+		if !ok || len(rel) <= len(before)+len(after) ||
+			!strings.HasPrefix(rel, before) || !strings.HasSuffix(rel, after) {
+			continue
+		}
+		literal := len(before) + len(after)
+		if literal > bestLen || (literal == bestLen && prefix < bestPrefix) {
+			bestPrefix, bestLen = prefix, literal
+			bestSuffix = rel[len(before) : len(rel)-len(after)]
+		}
+	}
+	if bestLen < 0 {
+		return "", false
+	}
+	return bestPrefix + ":" + bestSuffix, true
+}
+
+// mergeRel stores v under rel, appending to an existing entry using the same
+// promotion rules as AddLink. Existing slices are copied, never appended to.
+func mergeRel(m map[string]any, rel string, v any) {
+	existing, ok := m[rel]
+	if !ok {
+		m[rel] = v
+		return
+	}
+	m[rel] = append(relValues(existing), relValues(v)...)
+}
+
+// relValues returns the entries stored under a rel as a new slice.
+func relValues(v any) []any {
+	switch vv := v.(type) {
+	case []any:
+		return slices.Clone(vv)
+	case []Link:
+		out := make([]any, len(vv))
+		for i, l := range vv {
+			out[i] = l
+		}
+		return out
+	default:
+		return []any{v}
+	}
+}
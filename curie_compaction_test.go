@@ -0,0 +1,91 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import "testing"
+
+func TestCurieCompaction_MatchingURI(t *testing.T) {
+	inst := New(WithCurieCompaction())
+	inst.RegisterCurie("acme", "https://docs.acme.com/rels/{rel}")
+
+	env := inst.WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "https://docs.acme.com/rels/orders", Href: "/orders"})
+
+	want := `{"id":1,"_links":{"acme:orders":{"href":"/orders"},` +
+		`"curies":[{"href":"https://docs.acme.com/rels/{rel}","templated":true,"name":"acme"}]}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestCurieCompaction_NonMatchingURI(t *testing.T) {
+	inst := New(WithCurieCompaction())
+	inst.RegisterCurie("acme", "https://docs.acme.com/rels/{rel}")
+
+	env := inst.WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "https://other.example.com/rels/orders", Href: "/orders"})
+	env.AddLink(Link{Rel: "https://docs.acme.com/rels/", Href: "/empty"})
+
+	want := `{"id":1,"_links":{"https://docs.acme.com/rels/":{"href":"/empty"},` +
+		`"https://other.example.com/rels/orders":{"href":"/orders"}}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestCurieCompaction_MostSpecificTemplateWins(t *testing.T) {
+	inst := New(WithCurieCompaction())
+	inst.RegisterCurie("acme", "https://docs.acme.com/{rel}")
+	inst.RegisterCurie("ord", "https://docs.acme.com/rels/orders/{rel}")
+
+	env := inst.WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "https://docs.acme.com/rels/orders/cancel", Href: "/cancel"})
+
+	want := `{"id":1,"_links":{"curies":[{"href":"https://docs.acme.com/rels/orders/{rel}","templated":true,"name":"ord"}],` +
+		`"ord:cancel":{"href":"/cancel"}}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestCurieCompaction_TieBrokenByPrefix(t *testing.T) {
+	inst := New(WithCurieCompaction())
+	inst.RegisterCurie("zeta", "https://docs.acme.com/rels/{rel}")
+	inst.RegisterCurie("alpha", "https://docs.acme.com/rels/{rel}")
+
+	env := inst.WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "https://docs.acme.com/rels/orders", Href: "/orders"})
+
+	if got := marshalString(t, env); !containsString(got, `"alpha:orders"`) {
+		t.Fatalf("expected alpha prefix to win the tie, got %s", got)
+	}
+}
+
+func TestCurieCompaction_MergesWithExistingRel(t *testing.T) {
+	inst := New(WithCurieCompaction())
+	inst.RegisterCurie("acme", "https://docs.acme.com/rels/{rel}")
+
+	env := inst.WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "acme:orders", Href: "/a"})
+	env.AddLink(Link{Rel: "https://docs.acme.com/rels/orders", Href: "/b"})
+
+	want := `{"id":1,"_links":{"acme:orders":[{"href":"/a"},{"href":"/b"}],` +
+		`"curies":[{"href":"https://docs.acme.com/rels/{rel}","templated":true,"name":"acme"}]}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestCurieCompaction_Disabled(t *testing.T) {
+	inst := New()
+	inst.RegisterCurie("acme", "https://docs.acme.com/rels/{rel}")
+
+	env := inst.WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "https://docs.acme.com/rels/orders", Href: "/orders"})
+
+	want := `{"id":1,"_links":{"https://docs.acme.com/rels/orders":{"href":"/orders"}}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+// WithHoistedCuries emits a single curies array at the root of each
+// document instead of one per embedded resource. Resources embedded with
+// AddEmbedded, SetEmbedded or embed tags leave their curies to the envelope
+// embedding them, whose curies array covers the prefixes used anywhere in
+// its tree. Envelopes passed to AddEmbedded as-is keep their own curies.
+//
+// Collection pages always hoist the curies of the items they wrap; with this
+// option the resources embedded in those items are covered too.
+//
+// An envelope embedded this way lacks curies when marshaled on its own.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithHoistedCuries())
+//	inst.RegisterCurie("acme", "https://docs.acme.com/rels/{rel}")
+func WithHoistedCuries() InstanceOption {
+	return func(i *Instance) {
+		i.hoistCuries = true
+	}
+}
+
+// usedCuries returns the curie links for the prefixes used by links and by
+// the embedded resources hoisting their curies to e, or nil if e hoists its
+// own to an ancestor.
+func (e *Envelope) usedCuries(links map[string]any, curies map[string]string) []Link {
+	if e.hoistedCuries {
+		return nil
+	}
+	if !e.instance.hoistCuries || len(e.embedded) == 0 {
+		return resolveCuries(links, curies)
+	}
+	rels := make(map[string]any, len(links))
+	for rel := range links {
+		rels[rel] = nil
+	}
+	for _, v := range e.embedded {
+		e.instance.collectHoistedRels(rels, v, curies)
+	}
+	return resolveCuries(rels, curies)
+}
+
+// collectHoistedRels adds to rels the link rels of the envelopes in v, an
+// _embedded value, that hoist their curies, and recursively of the
+// resources embedded in them.
+func (i *Instance) collectHoistedRels(rels map[string]any, v any, curies map[string]string) {
+	switch v := v.(type) {
+	case *Envelope:
+		if v == nil || !v.hoistedCuries || v.plain {
+			return
+		}
+		links := v.links
+		if i.compactCuries {
+			links = compactRels(links, curies)
+		}
+		for rel := range links {
+			rels[rel] = nil
+		}
+		for _, child := range v.embedded {
+			i.collectHoistedRels(rels, child, curies)
+		}
+	case []any:
+		for _, item := range v {
+			i.collectHoistedRels(rels, item, curies)
+		}
+	case []*Envelope:
+		for _, item := range v {
+			i.collectHoistedRels(rels, item, curies)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func hoistingInstance(opts ...InstanceOption) *Instance {
+	inst := New(append(opts, WithCuries(map[string]string{
+		"acme": "https://docs.acme.com/rels/{rel}",
+		"org":  "https://org.example.com/rels/{rel}",
+	}))...)
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}, {Rel: "acme:orders", Href: "/users/" + itoa(u.ID) + "/orders"}}
+	})
+	RegisterInstance(inst, func(_ context.Context, o *mergeOrder) []Link {
+		return []Link{{Rel: "self", Href: "/orders/" + itoa(o.ID)}, {Rel: "org:invoice", Href: "/invoices/" + itoa(o.ID)}}
+	})
+	return inst
+}
+
+func TestHoistedCuries_Envelope(t *testing.T) {
+	ctx := context.Background()
+	build := func(inst *Instance) string {
+		root := inst.Wrap(ctx, &mergeOrder{ID: 1})
+		for n := 0; n < 200; n++ {
+			root.AddEmbedded(ctx, "users", &collectionUser{ID: n})
+		}
+		return marshalString(t, root)
+	}
+
+	hoisted, plain := build(hoistingInstance(WithHoistedCuries())), build(hoistingInstance())
+	if n := strings.Count(hoisted, `"curies"`); n != 1 {
+		t.Fatalf("expected one curies array, got %d in %s", n, hoisted)
+	}
+	if n := strings.Count(plain, `"curies"`); n != 201 {
+		t.Fatalf("expected curies per envelope without hoisting, got %d", n)
+	}
+	if !strings.Contains(hoisted, `"name":"acme"`) || !strings.Contains(hoisted, `"name":"org"`) {
+		t.Fatalf("expected the root curies to cover the embedded prefixes, got %s", hoisted[:300])
+	}
+	if len(hoisted) >= len(plain)*2/3 {
+		t.Fatalf("expected hoisting to shrink the payload, got %d bytes vs %d", len(hoisted), len(plain))
+	}
+	t.Logf("payload: %d bytes hoisted, %d bytes without", len(hoisted), len(plain))
+}
+
+func TestHoistedCuries_Nested(t *testing.T) {
+	inst := hoistingInstance(WithHoistedCuries())
+	ctx := context.Background()
+
+	user := inst.Wrap(ctx, &collectionUser{ID: 1})
+	user.AddEmbedded(ctx, "order", &mergeOrder{ID: 2})
+	got := marshalString(t, inst.Collection(ctx, []*Envelope{user}, 1, SelfLink("/users")))
+	if n := strings.Count(got, `"curies"`); n != 1 || !strings.Contains(got, `"name":"org"`) {
+		t.Fatalf("expected one curies array covering the nested order, got %s", got)
+	}
+
+	page := inst.Collection(ctx, []*collectionUser{{ID: 1}}, 1, SelfLink("/users"))
+	page.Embedded["items"].([]*Envelope)[0].AddEmbedded(ctx, "order", &mergeOrder{ID: 2})
+	got = marshalString(t, page)
+	if n := strings.Count(got, `"curies"`); n != 1 || !strings.Contains(got, `"name":"org"`) {
+		t.Fatalf("expected the page to hoist curies of nested resources, got %s", got)
+	}
+
+	env := inst.Wrap(ctx, &mergeOrder{ID: 4})
+	env.AddEmbedded(ctx, "kept", inst.Wrap(ctx, &mergeOrder{ID: 3}))
+	if got := marshalString(t, env); strings.Count(got, `"curies"`) != 2 {
+		t.Fatalf("expected an envelope embedded as-is to keep its curies, got %s", got)
+	}
+}
@@ -4,8 +4,12 @@
 package hal
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"maps"
+	"strings"
 	"testing"
 )
 
@@ -68,3 +72,262 @@ func TestCurie_MultiplePrefixes(t *testing.T) {
 		t.Fatalf("expected 2 curies, got %d", len(curies))
 	}
 }
+
+func TestSetCuries_ReplacesAll(t *testing.T) {
+	inst := New()
+	inst.RegisterCurie("old", "https://old.example.com/{rel}")
+
+	err := inst.SetCuries(map[string]string{
+		"acme": "https://docs.example.com/rels/{rel}",
+		"bil":  "https://docs.example.com/billing/{rel}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := inst.Curies()
+	if len(got) != 2 || got["acme"] != "https://docs.example.com/rels/{rel}" || got["old"] != "" {
+		t.Fatalf("expected the new set only, got %v", got)
+	}
+
+	// Curies returns a copy.
+	got["acme"] = "mutated"
+	if inst.Curies()["acme"] == "mutated" {
+		t.Fatal("expected Curies to return a copy")
+	}
+}
+
+func TestSetCuries_InvalidKeepsCurrent(t *testing.T) {
+	tests := map[string]map[string]string{
+		"missing placeholder": {"acme": "https://docs.example.com/rels/"},
+		"empty prefix":        {"": "https://docs.example.com/{rel}"},
+		"colon prefix":        {"a:b": "https://docs.example.com/{rel}"},
+	}
+
+	for name, curies := range tests {
+		t.Run(name, func(t *testing.T) {
+			inst := New()
+			inst.RegisterCurie("acme", "https://docs.example.com/{rel}")
+
+			curies["valid"] = "https://valid.example.com/{rel}"
+			if err := inst.SetCuries(curies); !errors.Is(err, ErrInvalidCurie) {
+				t.Fatalf("expected ErrInvalidCurie, got %v", err)
+			}
+			if got := inst.Curies(); len(got) != 1 || got["acme"] == "" {
+				t.Fatalf("expected current curies to be kept, got %v", got)
+			}
+		})
+	}
+}
+
+func TestSetCuries_ConcurrentMarshalSeesCompleteSet(t *testing.T) {
+	type CurieUser struct {
+		ID int `json:"id"`
+	}
+	sets := []map[string]string{
+		{"a": "https://one.example.com/a/{rel}", "b": "https://one.example.com/b/{rel}"},
+		{"a": "https://two.example.com/a/{rel}", "b": "https://two.example.com/b/{rel}"},
+	}
+
+	inst := New()
+	if err := inst.SetCuries(sets[0]); err != nil {
+		t.Fatal(err)
+	}
+	RegisterInstance(inst, func(_ context.Context, _ *CurieUser) []Link {
+		return []Link{{Rel: "a:x", Href: "/x"}, {Rel: "b:y", Href: "/y"}}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for n := 0; n < 500; n++ {
+			_ = inst.SetCuries(sets[n%2])
+		}
+	}()
+
+	for n := 0; n < 500; n++ {
+		b, err := json.Marshal(inst.Wrap(context.Background(), &CurieUser{}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		one := bytes.Count(b, []byte("one.example.com"))
+		two := bytes.Count(b, []byte("two.example.com"))
+		if one+two != 2 || (one != 0 && two != 0) {
+			t.Fatalf("expected curies from a single set, got %s", b)
+		}
+	}
+	<-done
+}
+
+func TestCurie_AlwaysArray(t *testing.T) {
+	acme := Link{Rel: "curies", Name: "acme", Href: "https://docs.example.com/{rel}", Templated: true}
+	acmeJSON := `{"href":"https://docs.example.com/{rel}","templated":true,"name":"acme"}`
+
+	t.Run("single manual curie", func(t *testing.T) {
+		e := New().Wrap(context.Background(), map[string]any{})
+		e.AddLink(acme)
+		want := `{"_links":{"curies":[` + acmeJSON + `]}}`
+		if got := marshalString(t, e); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("envelope without instance", func(t *testing.T) {
+		e := &Envelope{Data: map[string]any{}}
+		e.AddLink(acme)
+		want := `{"_links":{"curies":[` + acmeJSON + `]}}`
+		if got := marshalString(t, e); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("manual and resolved curies merge flat", func(t *testing.T) {
+		inst := New()
+		inst.RegisterCurie("ord", "https://docs.example.com/orders/{rel}")
+		e := inst.Wrap(context.Background(), map[string]any{})
+		e.AddLink(acme)
+		e.AddLink(Link{Rel: "ord:list", Href: "/orders"})
+		want := `{"_links":{"curies":[` + acmeJSON +
+			`,{"href":"https://docs.example.com/orders/{rel}","templated":true,"name":"ord"}],"ord:list":{"href":"/orders"}}}`
+		if got := marshalString(t, e); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("deduplicated to one", func(t *testing.T) {
+		e := New(WithLinkDeduplication()).Wrap(context.Background(), map[string]any{})
+		e.AddLink(acme)
+		e.AddLink(acme)
+		want := `{"_links":{"curies":[` + acmeJSON + `]}}`
+		if got := marshalString(t, e); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("static links", func(t *testing.T) {
+		inst := New()
+		RegisterStatic(inst, &TestData{}, []Link{acme})
+		want := `{"id":0,"name":"","email":"","age":0,"_links":{"curies":[` + acmeJSON + `]}}`
+		if got := marshalString(t, inst.Wrap(context.Background(), &TestData{})); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	})
+}
+
+func TestRegisterCurieE(t *testing.T) {
+	valid := map[string]string{
+		"acme":     "https://docs.example.com/rels/{rel}",
+		"_v2.beta": "https://docs.example.com/{rel}{?lang}",
+		"ex-1":     "/docs/{rel}",
+	}
+	for prefix, href := range valid {
+		inst := New()
+		if err := inst.RegisterCurieE(prefix, href); err != nil {
+			t.Errorf("%s: unexpected error %v", prefix, err)
+		}
+		if got := inst.Curies()[prefix]; got != href {
+			t.Errorf("%s: expected %q registered, got %q", prefix, href, got)
+		}
+	}
+
+	invalid := []struct{ prefix, href string }{
+		{"", "https://docs.example.com/{rel}"},
+		{"a:b", "https://docs.example.com/{rel}"},
+		{"1acme", "https://docs.example.com/{rel}"},
+		{"ac me", "https://docs.example.com/{rel}"},
+		{"acme", "https://docs.example.com/rels/"},
+		{"acme", "https://docs.example.com/{relation}"},
+		{"acme", "https://docs.example.com/{rel}{"},
+	}
+	for _, tt := range invalid {
+		inst := New()
+		if err := inst.RegisterCurieE(tt.prefix, tt.href); !errors.Is(err, ErrInvalidCurie) {
+			t.Errorf("%q %q: expected ErrInvalidCurie, got %v", tt.prefix, tt.href, err)
+		}
+		if len(inst.Curies()) != 0 {
+			t.Errorf("%q %q: expected nothing registered", tt.prefix, tt.href)
+		}
+	}
+}
+
+func TestRegisterCurie_Invalid(t *testing.T) {
+	var msgs []string
+	inst := New(WithLintHook(func(msg string) { msgs = append(msgs, msg) }))
+	inst.RegisterCurie("acme", "https://docs.example.com/rels/")
+	if inst.Curies()["acme"] == "" || len(msgs) != 1 {
+		t.Fatalf("expected invalid curie registered with a lint warning, got %v, %q", inst.Curies(), msgs)
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrInvalidCurie) {
+			t.Fatalf("expected strict mode to panic with ErrInvalidCurie, got %v", err)
+		}
+	}()
+	New(WithStrictMode()).RegisterCurie("acme", "https://docs.example.com/rels/")
+}
+
+func TestWithCuries(t *testing.T) {
+	defs := map[string]string{
+		"acme": "https://docs.example.com/acme/{rel}",
+		"ord":  "https://docs.example.com/orders/{rel}",
+	}
+	inst := New(WithCuries(defs), WithStrictMode())
+	defs["late"] = "https://docs.example.com/late/{rel}"
+
+	inst.RegisterCurie("ord", "https://docs.example.com/v2/orders/{rel}")
+	got := inst.Curies()
+	want := map[string]string{
+		"acme": "https://docs.example.com/acme/{rel}",
+		"ord":  "https://docs.example.com/v2/orders/{rel}",
+	}
+	if !maps.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	got["acme"] = "mutated"
+	if inst.Curies()["acme"] == "mutated" {
+		t.Fatal("expected Curies to return a copy")
+	}
+}
+
+func TestWithCuries_InvalidPanicsInStrictMode(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrInvalidCurie) {
+			t.Fatalf("expected panic with ErrInvalidCurie, got %v", err)
+		}
+	}()
+	New(WithCuries(map[string]string{"acme": "https://docs.example.com/"}), WithStrictMode())
+}
+
+func TestCurie_CollectionPage(t *testing.T) {
+	inst := New(WithCuries(map[string]string{
+		"acme": "https://docs.example.com/rels/{rel}",
+		"org":  "https://org.example.com/rels/{rel}",
+	}))
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}, {Rel: "org:manager", Href: "/managers/1"}}
+	})
+	page := inst.Collection(context.Background(), []*collectionUser{{ID: 1}, {ID: 2}}, 2, SelfLink("/users"),
+		WithPageLinks(Link{Rel: "acme:export", Href: "/users.csv"}))
+
+	got := marshalString(t, page)
+	want := `{"_links":{"acme:export":{"href":"/users.csv"},"curies":[` +
+		`{"href":"https://docs.example.com/rels/{rel}","templated":true,"name":"acme"},` +
+		`{"href":"https://org.example.com/rels/{rel}","templated":true,"name":"org"}],` +
+		`"self":{"href":"/users"}},"_embedded":{"items":[` +
+		`{"id":1,"_links":{"org:manager":{"href":"/managers/1"},"self":{"href":"/users/1"}}},` +
+		`{"id":2,"_links":{"org:manager":{"href":"/managers/1"},"self":{"href":"/users/2"}}}]},"count":2,"total":2}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if n := strings.Count(got, `"curies"`); n != 1 {
+		t.Fatalf("expected exactly one curies array, got %d", n)
+	}
+
+	// An item marshaled on its own still gets its curies.
+	if got := marshalString(t, inst.Wrap(context.Background(), &collectionUser{ID: 1})); !strings.Contains(got, `"curies"`) {
+		t.Fatalf("expected a standalone envelope to keep its curies, got %s", got)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+
+	json "github.com/goccy/go-json"
+)
+
+// WithDataMetaPrecedence makes rels in the data's own _links and _embedded
+// members win over computed ones of the same name. By default computed
+// links and embedded resources win. See Envelope.MarshalJSON.
+//
+// # Example
+//
+//	// Legacy structs carry hand-built links that must not be overridden.
+//	inst := hal.New(hal.WithDataMetaPrecedence())
+func WithDataMetaPrecedence() InstanceOption {
+	return func(i *Instance) {
+		i.dataMetaWins = true
+	}
+}
+
+var (
+	quotedLinks    = []byte(`"_links"`)
+	quotedEmbedded = []byte(`"_embedded"`)
+)
+
+// hasDataMeta reports whether the encoded data may have its own _links or
+// _embedded member. It can report false positives, such as nested members
+// of that name, but no false negatives.
+func hasDataMeta(data []byte) bool {
+	return bytes.Contains(data, quotedLinks) || bytes.Contains(data, quotedEmbedded)
+}
+
+// dataMetaMembers returns the values of the top-level _links and _embedded
+// members of data, an encoded JSON object, or nil for absent members.
+func dataMetaMembers(data []byte) (links, embedded []byte) {
+	if len(data) == 0 || data[0] != '{' || !hasDataMeta(data) {
+		return nil, nil
+	}
+	for pos := 1; ; {
+		pos = skipSpace(data, pos)
+		if pos >= len(data) || data[pos] == '}' {
+			return links, embedded
+		}
+		keyStart := pos
+		pos = skipValue(data, pos)
+		key := string(data[keyStart+1 : pos-1])
+		pos = skipSpace(data, pos) + 1 // ':'
+		pos = skipSpace(data, pos)
+		valueStart := pos
+		pos = skipValue(data, pos)
+		switch key {
+		case "_links":
+			links = data[valueStart:pos]
+		case "_embedded":
+			embedded = data[valueStart:pos]
+		}
+		pos = skipSpace(data, pos)
+		if pos < len(data) && data[pos] == ',' {
+			pos++
+		}
+	}
+}
+
+// reconcileDataMeta merges the _links and _embedded members of data, the
+// encoded Data object, into the computed links and embedded maps when both
+// are present, and removes them from data, so the output has a single
+// member of each name. In strict mode such a collision is an error wrapping
+// ErrDataMetaCollision instead. The maps are cloned before they are changed.
+func (e *Envelope) reconcileDataMeta(data []byte, links, embedded map[string]any) ([]byte, map[string]any, map[string]any, error) {
+	if len(links) == 0 && len(embedded) == 0 {
+		return data, links, embedded, nil
+	}
+	dataLinks, dataEmbedded := dataMetaMembers(data)
+	if len(links) == 0 {
+		dataLinks = nil
+	}
+	if len(embedded) == 0 {
+		dataEmbedded = nil
+	}
+	var keys []string
+	if dataLinks != nil {
+		keys = append(keys, "_links")
+	}
+	if dataEmbedded != nil {
+		keys = append(keys, "_embedded")
+	}
+	if len(keys) == 0 {
+		return data, links, embedded, nil
+	}
+	if e.instance != nil && e.instance.strictMode {
+		return nil, nil, nil, fmt.Errorf("hal: marshaling data for %T: %w: %q", e.Data, ErrDataMetaCollision, keys)
+	}
+
+	dataWins := e.instance != nil && e.instance.dataMetaWins
+	var err error
+	if dataLinks != nil {
+		if links, err = mergeDataMember(links, dataLinks, dataWins); err != nil {
+			return nil, nil, nil, fmt.Errorf("hal: merging _links of %T: %w", e.Data, err)
+		}
+	}
+	if dataEmbedded != nil {
+		if embedded, err = mergeDataMember(embedded, dataEmbedded, dataWins); err != nil {
+			return nil, nil, nil, fmt.Errorf("hal: merging _embedded of %T: %w", e.Data, err)
+		}
+	}
+	return omitMembers(data, keys), links, embedded, nil
+}
+
+// mergeDataMember returns computed with the members of the JSON object raw
+// added as raw values. Rels present in both keep the computed value unless
+// dataWins is set.
+func mergeDataMember(computed map[string]any, raw []byte, dataWins bool) (map[string]any, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &members); err != nil {
+		return nil, err
+	}
+	out := maps.Clone(computed)
+	for rel, v := range members {
+		if _, ok := out[rel]; ok && !dataWins {
+			continue
+		}
+		out[rel] = v
+	}
+	return out, nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// legacyOrder carries links of its own, as structs from hand-rolled HAL
+// implementations do.
+type legacyOrder struct {
+	ID       int            `json:"id"`
+	Links    map[string]any `json:"_links,omitempty"`
+	Embedded map[string]any `json:"_embedded,omitempty"`
+}
+
+func legacyInstance(opts ...InstanceOption) *Instance {
+	inst := New(opts...)
+	RegisterInstance(inst, func(_ context.Context, o *legacyOrder) []Link {
+		return []Link{{Rel: "self", Href: "/orders/" + itoa(o.ID)}}
+	})
+	return inst
+}
+
+func legacyData() *legacyOrder {
+	return &legacyOrder{ID: 1, Links: map[string]any{
+		"self":    map[string]any{"href": "/legacy/orders/1"},
+		"invoice": map[string]any{"href": "/invoices/9"},
+	}}
+}
+
+func TestDataMeta_MergesLinks(t *testing.T) {
+	got := marshalString(t, legacyInstance().Wrap(context.Background(), legacyData()))
+	want := `{"id":1,"_links":{"invoice":{"href":"/invoices/9"},"self":{"href":"/orders/1"}}}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if n := strings.Count(got, `"_links"`); n != 1 {
+		t.Fatalf("expected a single _links member, got %d", n)
+	}
+}
+
+func TestDataMeta_DataPrecedence(t *testing.T) {
+	inst := legacyInstance(WithDataMetaPrecedence())
+	got := marshalString(t, inst.Clone().Wrap(context.Background(), legacyData()))
+	want := `{"id":1,"_links":{"invoice":{"href":"/invoices/9"},"self":{"href":"/legacy/orders/1"}}}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestDataMeta_MergesEmbedded(t *testing.T) {
+	ctx := context.Background()
+	data := &legacyOrder{ID: 1, Embedded: map[string]any{"customer": map[string]any{"name": "Ada"}}}
+	env := legacyInstance().Wrap(ctx, data)
+	env.AddEmbedded(ctx, "items", &embeddedComment{Text: "a"})
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(marshalString(t, env)), &doc); err != nil {
+		t.Fatal(err)
+	}
+	var embedded map[string]json.RawMessage
+	if err := json.Unmarshal(doc["_embedded"], &embedded); err != nil {
+		t.Fatal(err)
+	}
+	if len(embedded) != 2 || embedded["customer"] == nil || embedded["items"] == nil {
+		t.Fatalf("expected customer and items embedded, got %s", doc["_embedded"])
+	}
+}
+
+func TestDataMeta_NoComputedMeta(t *testing.T) {
+	// Without computed links the data's own member is written unchanged.
+	got := marshalString(t, New().WrapRaw(legacyData()))
+	want := `{"id":1,"_links":{"invoice":{"href":"/invoices/9"},"self":{"href":"/legacy/orders/1"}}}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestDataMeta_OnlyLinks(t *testing.T) {
+	env := legacyInstance(WithDataMetaPrecedence()).WrapRaw(json.RawMessage(`{"_links":{"self":{"href":"/legacy"}}}`))
+	env.AddLink(Link{Rel: "next", Href: "/next"})
+	want := `{"_links":{"next":{"href":"/next"},"self":{"href":"/legacy"}}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestDataMeta_Precomputed(t *testing.T) {
+	inst := New()
+	RegisterStatic(inst, &legacyOrder{}, []Link{SelfLink("/orders")})
+	got := marshalString(t, inst.Wrap(context.Background(), legacyData()))
+	want := `{"id":1,"_links":{"invoice":{"href":"/invoices/9"},"self":{"href":"/orders"}}}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestDataMeta_NestedMembersUntouched(t *testing.T) {
+	type wrapper struct {
+		Order legacyOrder `json:"order"`
+	}
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, _ *wrapper) []Link { return []Link{SelfLink("/w")} })
+	got := marshalString(t, inst.Wrap(context.Background(), &wrapper{Order: *legacyData()}))
+	if !strings.HasPrefix(got, `{"order":{"id":1,"_links":{`) || !strings.HasSuffix(got, `,"_links":{"self":{"href":"/w"}}}`) {
+		t.Fatalf("expected the nested _links to stay in place, got %s", got)
+	}
+}
+
+func TestDataMeta_Strict(t *testing.T) {
+	env := legacyInstance(WithStrictMode()).Wrap(context.Background(), legacyData())
+	_, err := env.MarshalJSON()
+	if !errors.Is(err, ErrDataMetaCollision) || !strings.Contains(err.Error(), "_links") {
+		t.Fatalf("expected ErrDataMetaCollision naming _links, got %v", err)
+	}
+}
+
+func TestDataMeta_Encode(t *testing.T) {
+	env := legacyInstance().Wrap(context.Background(), legacyData())
+	var buf strings.Builder
+	if err := env.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if want := marshalString(t, env); buf.String() != want {
+		t.Fatalf("expected Encode to match MarshalJSON %s, got %s", want, buf.String())
+	}
+}
@@ -6,6 +6,7 @@ package hal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 )
 
@@ -159,26 +160,28 @@ func TestWrap_ContextCancellation(t *testing.T) {
 	}
 
 	inst := New()
-	RegisterInstance(inst, func(ctx context.Context, _ *User) []Link {
-		select {
-		case <-ctx.Done():
-			return []Link{{Rel: "canceled", Href: "/canceled"}}
-		default:
-			return []Link{{Rel: "self", Href: "/users"}}
-		}
+	called := false
+	RegisterInstance(inst, func(_ context.Context, _ *User) []Link {
+		called = true
+		return []Link{{Rel: "self", Href: "/users"}}
 	})
 
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
 	env := inst.Wrap(ctx, &User{ID: 1})
+	if called {
+		t.Fatal("generator ran for a cancelled context")
+	}
+	if !errors.Is(env.Err(), context.Canceled) {
+		t.Fatalf("expected context.Canceled on envelope, got %v", env.Err())
+	}
 	out, err := json.Marshal(env)
 	if err != nil {
 		t.Fatal(err)
 	}
-
-	if !containsString(string(out), "canceled") {
-		t.Fatalf("expected canceled link, got %s", out)
+	if string(out) != wantUserID1 {
+		t.Fatalf("expected data without links, got %s", out)
 	}
 }
 
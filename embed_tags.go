@@ -0,0 +1,289 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// embedField describes a struct field tagged for auto-embedding.
+type embedField struct {
+	index   []int
+	name    string // Go field name, for error messages
+	rel     string
+	omit    bool
+	jsonKey string // Key of the field in the marshaled data; empty if not marshaled
+}
+
+// embedPlans caches the tagged fields of each struct type.
+var embedPlans sync.Map // map[reflect.Type][]embedField
+
+// embedPlan returns the auto-embed fields of struct type t.
+func embedPlan(t reflect.Type) []embedField {
+	if plan, ok := embedPlans.Load(t); ok {
+		return plan.([]embedField)
+	}
+	var plan []embedField
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() {
+			continue
+		}
+		field, ok := parseEmbedTag(f)
+		if ok {
+			plan = append(plan, field)
+		}
+	}
+	embedPlans.Store(t, plan)
+	return plan
+}
+
+// parseEmbedTag parses a `hal:"embed,rel=author,omit"` tag. The rel defaults
+// to the field's JSON key.
+func parseEmbedTag(f reflect.StructField) (embedField, bool) {
+	opts := strings.Split(f.Tag.Get("hal"), ",")
+	if opts[0] != "embed" {
+		return embedField{}, false
+	}
+	field := embedField{index: f.Index, name: f.Name, jsonKey: f.Name}
+	if tag, ok := f.Tag.Lookup("json"); ok {
+		if key, _, _ := strings.Cut(tag, ","); key == "-" {
+			field.jsonKey = ""
+		} else if key != "" {
+			field.jsonKey = key
+		}
+	}
+	field.rel = field.jsonKey
+	for _, opt := range opts[1:] {
+		switch {
+		case opt == "omit":
+			field.omit = true
+		case strings.HasPrefix(opt, "rel="):
+			field.rel = strings.TrimPrefix(opt, "rel=")
+		}
+	}
+	if field.rel == "" {
+		field.rel = f.Name
+	}
+	return field, true
+}
+
+// autoEmbed embeds the fields of e.Data tagged with `hal:"embed"`. Pointer
+// fields are embedded as single resources, slice fields as arrays; nil fields
+// are skipped. Struct values are embedded by address, so generators
+// registered for *T apply to them.
+func (i *Instance) autoEmbed(ctx context.Context, e *Envelope) {
+	v := reflect.ValueOf(e.Data)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	plan := embedPlan(v.Type())
+	if len(plan) == 0 {
+		return
+	}
+	ctx, ok := i.nestedEmbedContext(ctx, e.Data)
+	if !ok {
+		return
+	}
+	for _, f := range plan {
+		fv, err := v.FieldByIndexErr(f.index)
+		if err != nil {
+			continue // Field of a nil embedded struct pointer
+		}
+		if i.strictMode {
+			if err := i.checkEmbedType(v.Type(), f, fv.Type()); err != nil {
+				e.strictErr = err
+				return
+			}
+		}
+		if f.omit && f.jsonKey != "" {
+			e.omitKeys = append(e.omitKeys, f.jsonKey)
+		}
+		i.embedField(ctx, e, f.rel, fv)
+	}
+}
+
+// embedField embeds the value fv under rel: pointers as single resources,
+// slices and arrays as arrays. Nil values are skipped.
+func (i *Instance) embedField(ctx context.Context, e *Envelope, rel string, fv reflect.Value) {
+	if fv.Type() == rawMessageType {
+		e.AddEmbedded(ctx, rel, fv.Interface())
+		return
+	}
+	switch fv.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map:
+		if fv.IsNil() {
+			return
+		}
+		e.AddEmbedded(ctx, rel, fv.Interface())
+	case reflect.Slice, reflect.Array:
+		if fv.Kind() == reflect.Slice && fv.IsNil() {
+			return
+		}
+		if fv.Len() == 0 {
+			if e.embedded == nil {
+				e.embedded = make(map[string]any, 1)
+			}
+			e.embedded[rel] = []any{}
+			return
+		}
+		for idx := 0; idx < fv.Len(); idx++ {
+			e.AddEmbedded(ctx, rel, addressOf(fv.Index(idx)))
+		}
+		if e.arrayRels == nil {
+			e.arrayRels = make(map[string]struct{}, 1)
+		}
+		e.arrayRels[rel] = struct{}{}
+	default:
+		e.AddEmbedded(ctx, rel, addressOf(fv))
+	}
+}
+
+// addressOf returns a pointer to struct values that are addressable, and the
+// value itself otherwise.
+func addressOf(v reflect.Value) any {
+	if v.Kind() == reflect.Struct && v.CanAddr() {
+		return v.Addr().Interface()
+	}
+	return v.Interface()
+}
+
+// checkEmbedType returns an error wrapping ErrNoGenerator when a tagged
+// field's element type has no links registered.
+func (i *Instance) checkEmbedType(owner reflect.Type, f embedField, t reflect.Type) error {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		t = reflect.PointerTo(t)
+	}
+	if t.Kind() == reflect.Interface {
+		return nil // Dynamic type is checked when the value is wrapped
+	}
+	if !i.hasLinksFor(t) {
+		return fmt.Errorf("%w for type %v, referenced by embed tag on %v.%s", ErrNoGenerator, t, owner, f.name)
+	}
+	return nil
+}
+
+// hasLinksFor reports whether a generator or precomputed links are registered
+// for t.
+func (i *Instance) hasLinksFor(t reflect.Type) bool {
+	if f := i.frozen.Load(); f != nil {
+		_, gen := f.generators[t]
+		_, pre := f.precomputed[t]
+		return gen || pre
+	}
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if _, ok := i.generators[t]; ok {
+		return true
+	}
+	_, ok := i.precomputed[t]
+	return ok
+}
+
+// omitMembers returns the JSON object obj without the top-level members whose
+// keys are listed. obj must be a valid, trimmed JSON object.
+func omitMembers(obj []byte, keys []string) []byte {
+	out := make([]byte, 0, len(obj))
+	out = append(out, '{')
+	pos := 1
+	for {
+		pos = skipSpace(obj, pos)
+		if pos >= len(obj) || obj[pos] == '}' {
+			break
+		}
+		keyStart := pos
+		pos = skipValue(obj, pos)
+		key := obj[keyStart+1 : pos-1]
+		pos = skipSpace(obj, pos) + 1 // ':'
+		pos = skipSpace(obj, pos)
+		pos = skipValue(obj, pos)
+		member := obj[keyStart:pos]
+		if !containsKey(keys, key) {
+			if len(out) > 1 {
+				out = append(out, ',')
+			}
+			out = append(out, member...)
+		}
+		pos = skipSpace(obj, pos)
+		if pos < len(obj) && obj[pos] == ',' {
+			pos++
+		}
+	}
+	return append(out, '}')
+}
+
+// containsKey reports whether the raw JSON string contents key match one of
+// keys. Escaped keys never match; struct field keys are plain identifiers.
+func containsKey(keys []string, key []byte) bool {
+	for _, k := range keys {
+		if k == string(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func skipSpace(b []byte, pos int) int {
+	for pos < len(b) && isJSONSpace(b[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// skipValue returns the position just past the JSON value starting at pos.
+func skipValue(b []byte, pos int) int {
+	switch b[pos] {
+	case '"':
+		return skipString(b, pos)
+	case '{', '[':
+		depth := 0
+		for pos < len(b) {
+			switch b[pos] {
+			case '"':
+				pos = skipString(b, pos)
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return pos + 1
+				}
+			}
+			pos++
+		}
+		return pos
+	}
+	// Number, true, false or null.
+	for pos < len(b) && b[pos] != ',' && b[pos] != '}' && b[pos] != ']' && !isJSONSpace(b[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// skipString returns the position just past the JSON string starting at pos.
+func skipString(b []byte, pos int) int {
+	for pos++; pos < len(b); pos++ {
+		switch b[pos] {
+		case '\\':
+			pos++
+		case '"':
+			return pos + 1
+		}
+	}
+	return pos
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type tagAuthor struct {
+	ID int `json:"id"`
+}
+
+type tagComment struct {
+	Text string `json:"text"`
+}
+
+type tagOrder struct {
+	ID       int          `json:"id"`
+	Author   *tagAuthor   `json:"author" hal:"embed,rel=author,omit"`
+	Reviewer *tagAuthor   `json:"reviewer,omitempty" hal:"embed"`
+	Comments []tagComment `json:"comments" hal:"embed,omit"`
+	Note     string       `json:"note"`
+}
+
+func tagInstance(opts ...InstanceOption) *Instance {
+	inst := New(opts...)
+	RegisterInstance(inst, func(_ context.Context, o *tagOrder) []Link {
+		return []Link{{Rel: "self", Href: "/orders/" + itoa(o.ID)}}
+	})
+	RegisterInstance(inst, func(_ context.Context, a *tagAuthor) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(a.ID)}}
+	})
+	RegisterInstance(inst, func(_ context.Context, c *tagComment) []Link {
+		return []Link{{Rel: "self", Href: "/comments/" + c.Text}}
+	})
+	return inst
+}
+
+func marshalTagged(t *testing.T, inst *Instance, v any) string {
+	t.Helper()
+	b, err := json.Marshal(inst.Wrap(context.Background(), v))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestAutoEmbed_PointerWithOmit(t *testing.T) {
+	got := marshalTagged(t, tagInstance(), &tagOrder{ID: 1, Author: &tagAuthor{ID: 7}, Note: "n"})
+
+	want := `{"id":1,"note":"n","_embedded":{"author":{"id":7,"_links":{"self":{"href":"/users/7"}}}},` +
+		`"_links":{"self":{"href":"/orders/1"}}}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestAutoEmbed_WithoutOmitKeepsField(t *testing.T) {
+	got := marshalTagged(t, tagInstance(), &tagOrder{ID: 1, Reviewer: &tagAuthor{ID: 8}})
+
+	if !strings.Contains(got, `"reviewer":{"id":8}`) {
+		t.Fatalf("expected reviewer kept in data, got %s", got)
+	}
+	if !strings.Contains(got, `"_embedded":{"reviewer":{"id":8,"_links":{"self":{"href":"/users/8"}}}}`) {
+		t.Fatalf("expected reviewer embedded, got %s", got)
+	}
+}
+
+func TestAutoEmbed_NilFieldsSkipped(t *testing.T) {
+	got := marshalTagged(t, tagInstance(), &tagOrder{ID: 1})
+
+	want := `{"id":1,"note":"","_links":{"self":{"href":"/orders/1"}}}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestAutoEmbed_SliceBecomesArray(t *testing.T) {
+	got := marshalTagged(t, tagInstance(), &tagOrder{ID: 1, Comments: []tagComment{{Text: "a"}}})
+
+	want := `"_embedded":{"comments":[{"text":"a","_links":{"self":{"href":"/comments/a"}}}]}`
+	if !strings.Contains(got, want) {
+		t.Fatalf("expected single comment as array, got %s", got)
+	}
+	if strings.Contains(got, `"comments":[{"text":"a"}]`) {
+		t.Fatalf("expected comments omitted from data, got %s", got)
+	}
+}
+
+func TestAutoEmbed_EmptySliceEmbedsEmptyArray(t *testing.T) {
+	got := marshalTagged(t, tagInstance(), &tagOrder{ID: 1, Comments: []tagComment{}})
+
+	if !strings.Contains(got, `"_embedded":{"comments":[]}`) {
+		t.Fatalf("expected empty array, got %s", got)
+	}
+}
+
+func TestAutoEmbed_StrictModeUnregisteredType(t *testing.T) {
+	inst := New(WithStrictMode())
+	RegisterInstance(inst, func(_ context.Context, o *tagOrder) []Link {
+		return []Link{{Rel: "self", Href: "/orders/" + itoa(o.ID)}}
+	})
+
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok || !errors.Is(err, ErrNoGenerator) || !strings.Contains(err.Error(), "tagOrder.Author") {
+			t.Fatalf("expected strict mode panic naming the field, got %v", r)
+		}
+	}()
+	inst.Wrap(context.Background(), &tagOrder{ID: 1})
+}
+
+func TestOmitMembers(t *testing.T) {
+	tests := []struct {
+		in, want string
+		keys     []string
+	}{
+		{`{"a":1,"b":{"x":[1,"}"]},"c":"q\"}"}`, `{"a":1,"c":"q\"}"}`, []string{"b"}},
+		{`{"a":1,"b":2}`, `{}`, []string{"a", "b"}},
+		{`{"a":true,"b":null}`, `{"b":null}`, []string{"a"}},
+		{`{}`, `{}`, []string{"a"}},
+	}
+	for _, tt := range tests {
+		if got := string(omitMembers([]byte(tt.in), tt.keys)); got != tt.want {
+			t.Errorf("omitMembers(%s, %v) = %s, want %s", tt.in, tt.keys, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// EmbeddedError reports a failure to marshal an embedded resource. Path
+// locates the resource in the document, such as "_embedded.items[3]" or,
+// for resources embedded in embedded resources,
+// "_embedded.orders[0]._embedded.customer".
+//
+// # Example
+//
+//	var embErr *hal.EmbeddedError
+//	if errors.As(err, &embErr) {
+//	    log.Printf("bad resource at %s: %v", embErr.Path, embErr.Err)
+//	}
+type EmbeddedError struct {
+	Path string
+	Err  error
+}
+
+func (e *EmbeddedError) Error() string {
+	return fmt.Sprintf("hal: marshaling %s: %v", e.Path, e.Err)
+}
+
+func (e *EmbeddedError) Unwrap() error {
+	return e.Err
+}
+
+// embeddedError returns err, the failure to marshal embedded, as an
+// *EmbeddedError locating the failing resource. The resources are marshaled
+// again one by one to find it; if none fails on its own, err is returned
+// wrapped.
+func embeddedError(embedded map[string]any, err error) error {
+	rels := make([]string, 0, len(embedded))
+	for rel := range embedded {
+		rels = append(rels, rel)
+	}
+	slices.Sort(rels)
+	for _, rel := range rels {
+		path := "_embedded." + rel
+		switch v := embedded[rel].(type) {
+		case []any:
+			for idx, item := range v {
+				if itemErr := marshalEmbedded(item); itemErr != nil {
+					return newEmbeddedError(path+"["+strconv.Itoa(idx)+"]", itemErr)
+				}
+			}
+		case []*Envelope:
+			for idx, item := range v {
+				if itemErr := marshalEmbedded(item); itemErr != nil {
+					return newEmbeddedError(path+"["+strconv.Itoa(idx)+"]", itemErr)
+				}
+			}
+		default:
+			if itemErr := marshalEmbedded(v); itemErr != nil {
+				return newEmbeddedError(path, itemErr)
+			}
+		}
+	}
+	return fmt.Errorf("hal: marshaling _embedded: %w", err)
+}
+
+// marshalEmbedded returns the error marshaling the embedded value v fails
+// with, if any. Envelopes are marshaled directly, so their errors are not
+// wrapped by the encoder.
+func marshalEmbedded(v any) error {
+	var err error
+	if env, ok := v.(*Envelope); ok {
+		_, err = env.MarshalJSON()
+	} else {
+		_, err = appendJSON(nil, v)
+	}
+	return err
+}
+
+// newEmbeddedError returns an *EmbeddedError for err at path, joining the
+// paths when err itself locates a resource nested inside the one at path.
+func newEmbeddedError(path string, err error) *EmbeddedError {
+	if inner, ok := err.(*EmbeddedError); ok {
+		return &EmbeddedError{Path: path + "." + inner.Path, Err: inner.Err}
+	}
+	return &EmbeddedError{Path: path, Err: err}
+}
+
+// AddEmbedded wraps data with the envelope's instance and embeds it under rel,
+// so the nested resource carries its own _links. Like AddLink, a second call
+// with the same rel promotes the entry to an array, preserving call order.
+// Data that is already an *Envelope of the same instance is embedded as-is.
+// Nil data, including typed nil pointers, is skipped rather than embedded as
+// null. Nothing is embedded once the context's wrap budget is spent (see
+// WithWrapBudget).
+//
+// # Example
+//
+//	env.AddEmbedded(ctx, "author", order.Author)
+func (e *Envelope) AddEmbedded(ctx context.Context, rel string, data any) {
+	if isNilData(data) || !allowEmbed(ctx) {
+		return
+	}
+	if e.embedded == nil {
+		e.embedded = make(map[string]any, 1)
+	}
+	appendRel(e.embedded, rel, e.wrapEmbedded(ctx, data))
+}
+
+// SetEmbedded wraps data like AddEmbedded but replaces anything previously
+// embedded under rel. Nil data removes the rel.
+func (e *Envelope) SetEmbedded(ctx context.Context, rel string, data any) {
+	if isNilData(data) {
+		delete(e.embedded, rel)
+		return
+	}
+	if !allowEmbed(ctx) {
+		return
+	}
+	if e.embedded == nil {
+		e.embedded = make(map[string]any, 1)
+	}
+	e.embedded[rel] = e.wrapEmbedded(ctx, data)
+}
+
+func (e *Envelope) wrapEmbedded(ctx context.Context, data any) *Envelope {
+	if e.instance == nil {
+		if env, ok := data.(*Envelope); ok && env != nil {
+			return env
+		}
+		return &Envelope{Data: data}
+	}
+	if env, ok := data.(*Envelope); ok && env != nil && env.instance == e.instance {
+		return env
+	}
+	// A strict failure in a nested resource fails the envelope embedding it.
+	child := e.instance.wrap(ctx, data)
+	if child.strictErr != nil && e.strictErr == nil {
+		e.strictErr = child.strictErr
+	}
+	child.hoistedCuries = e.instance.hoistCuries
+	return child
+}
+
+// isNilData reports whether data is nil or a nil pointer, map, slice or
+// interface value.
+func isNilData(data any) bool {
+	if data == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(data); v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// WithArrayEmbeddedRels forces the listed embedded rels to always serialize as
+// arrays, even when a single resource is embedded. Use it when consumers'
+// generated models expect a fixed shape.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithArrayEmbeddedRels("comments"))
+func WithArrayEmbeddedRels(rels ...string) InstanceOption {
+	return func(i *Instance) {
+		if i.arrayEmbeddedRels == nil {
+			i.arrayEmbeddedRels = make(map[string]struct{}, len(rels))
+		}
+		for _, rel := range rels {
+			i.arrayEmbeddedRels[rel] = struct{}{}
+		}
+	}
+}
+
+// embeddedForMarshal returns the embedded map to serialize, applying
+// instance-level and per-envelope shape rules on a copy so e.embedded is
+// never mutated.
+func (e *Envelope) embeddedForMarshal() map[string]any {
+	embedded := e.embedded
+	var instanceRels map[string]struct{}
+	if e.instance != nil {
+		instanceRels = e.instance.arrayEmbeddedRels
+	}
+	if len(instanceRels) == 0 && len(e.arrayRels) == 0 {
+		return embedded
+	}
+	var out map[string]any
+	for _, rels := range []map[string]struct{}{instanceRels, e.arrayRels} {
+		for rel := range rels {
+			v, ok := embedded[rel]
+			if !ok || isArrayValue(v) {
+				continue
+			}
+			if out == nil {
+				out = maps.Clone(embedded)
+			}
+			out[rel] = []any{v}
+		}
+	}
+	if out == nil {
+		return embedded
+	}
+	return out
+}
+
+// isArrayValue reports whether v is one of the slice shapes stored in
+// links and embedded maps.
+func isArrayValue(v any) bool {
+	switch v.(type) {
+	case []any, []*Envelope, []Link:
+		return true
+	}
+	return false
+}
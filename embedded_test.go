@@ -0,0 +1,118 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"testing"
+)
+
+type embeddedComment struct {
+	Text string `json:"text"`
+}
+
+func embeddingInstance(opts ...InstanceOption) *Instance {
+	inst := New(opts...)
+	RegisterInstance(inst, func(_ context.Context, c *embeddedComment) []Link {
+		return []Link{{Rel: "self", Href: "/comments/" + c.Text}}
+	})
+	return inst
+}
+
+func TestAddEmbedded_SingleValue(t *testing.T) {
+	ctx := context.Background()
+	env := embeddingInstance().WrapRaw(&collectionUser{ID: 1})
+	env.AddEmbedded(ctx, "comments", &embeddedComment{Text: "a"})
+
+	want := `{"id":1,"_embedded":{"comments":{"text":"a","_links":{"self":{"href":"/comments/a"}}}}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestAddEmbedded_PromotesToArray(t *testing.T) {
+	ctx := context.Background()
+	env := embeddingInstance().WrapRaw(&collectionUser{ID: 1})
+	env.AddEmbedded(ctx, "comments", &embeddedComment{Text: "a"})
+	env.AddEmbedded(ctx, "comments", &embeddedComment{Text: "b"})
+
+	want := `{"id":1,"_embedded":{"comments":[` +
+		`{"text":"a","_links":{"self":{"href":"/comments/a"}}},` +
+		`{"text":"b","_links":{"self":{"href":"/comments/b"}}}]}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestSetEmbedded_Replaces(t *testing.T) {
+	ctx := context.Background()
+	env := embeddingInstance().WrapRaw(&collectionUser{ID: 1})
+	env.AddEmbedded(ctx, "comments", &embeddedComment{Text: "a"})
+	env.AddEmbedded(ctx, "comments", &embeddedComment{Text: "b"})
+	env.SetEmbedded(ctx, "comments", &embeddedComment{Text: "c"})
+
+	want := `{"id":1,"_embedded":{"comments":{"text":"c","_links":{"self":{"href":"/comments/c"}}}}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestArrayEmbeddedRels_ForcesArray(t *testing.T) {
+	ctx := context.Background()
+	env := embeddingInstance(WithArrayEmbeddedRels("comments")).WrapRaw(&collectionUser{ID: 1})
+	env.AddEmbedded(ctx, "comments", &embeddedComment{Text: "a"})
+	env.AddEmbedded(ctx, "author", &embeddedComment{Text: "x"})
+
+	want := `{"id":1,"_embedded":{"author":{"text":"x","_links":{"self":{"href":"/comments/x"}}},` +
+		`"comments":[{"text":"a","_links":{"self":{"href":"/comments/a"}}}]}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	// Forcing is marshal-time only; a second element still promotes normally.
+	env.AddEmbedded(ctx, "comments", &embeddedComment{Text: "b"})
+	if n := len(env.embedded["comments"].([]any)); n != 2 {
+		t.Fatalf("expected 2 embedded comments, got %d", n)
+	}
+}
+
+func TestAddEmbedded_EnvelopeLiteral(t *testing.T) {
+	env := &Envelope{Data: &collectionUser{ID: 1}}
+	env.AddEmbedded(context.Background(), "comments", &embeddedComment{Text: "a"})
+
+	want := `{"id":1,"_embedded":{"comments":{"text":"a"}}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestAddEmbedded_SkipsNil(t *testing.T) {
+	ctx := context.Background()
+	env := embeddingInstance().WrapRaw(&collectionUser{ID: 1})
+	env.AddEmbedded(ctx, "comments", nil)
+	env.AddEmbedded(ctx, "comments", (*embeddedComment)(nil))
+	env.AddEmbedded(ctx, "author", (*Envelope)(nil))
+
+	if got := marshalString(t, env); got != wantUserID1 {
+		t.Fatalf("expected nil data skipped, got %s", got)
+	}
+
+	env.AddEmbedded(ctx, "comments", &embeddedComment{Text: "a"})
+	env.AddEmbedded(ctx, "comments", nil)
+	want := `{"id":1,"_embedded":{"comments":{"text":"a","_links":{"self":{"href":"/comments/a"}}}}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected single comment, got %s", got)
+	}
+}
+
+func TestSetEmbedded_NilRemovesRel(t *testing.T) {
+	ctx := context.Background()
+	env := embeddingInstance().WrapRaw(&collectionUser{ID: 1})
+	env.SetEmbedded(ctx, "comments", &embeddedComment{Text: "a"})
+	env.SetEmbedded(ctx, "comments", (*embeddedComment)(nil))
+
+	if got := marshalString(t, env); got != wantUserID1 {
+		t.Fatalf("expected rel removed, got %s", got)
+	}
+}
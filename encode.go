@@ -0,0 +1,106 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"io"
+)
+
+// Encode writes the envelope's JSON document to w. The output is identical
+// to MarshalJSON, but the data and the HAL members are written separately,
+// so the spliced document is never assembled in memory. For large payloads
+// this saves a copy of the whole document.
+//
+// Errors from building the document are returned before anything is
+// written; only errors from w can leave a partial document behind.
+//
+// # Example
+//
+//	w.Header().Set("Content-Type", hal.MediaType)
+//	if err := env.Encode(w); err != nil {
+//	    log.Print(err)
+//	}
+func (e *Envelope) Encode(w io.Writer) error {
+	_, err := e.WriteTo(w)
+	return err
+}
+
+// WriteTo implements io.WriterTo. It writes the same output as Encode and
+// returns the number of bytes written.
+func (e *Envelope) WriteTo(w io.Writer) (int64, error) {
+	dataBuf, metaBuf := getBuffer(), getBuffer()
+	defer putBuffer(dataBuf)
+	defer putBuffer(metaBuf)
+	parts, err := e.encodeParts(*dataBuf, *metaBuf)
+	if err != nil {
+		return 0, err
+	}
+	return writeParts(w, parts)
+}
+
+// encodeParts returns the envelope's JSON document as consecutive pieces:
+// the data object without its closing brace, a separator and the HAL
+// members with their closing brace, or fewer pieces when there is no data
+// or no HAL member. Concatenated, they equal the output of MarshalJSON.
+// The data and the members are appended to the scratch buffers dataBuf and
+// metaBuf, which the pieces may reference.
+func (e *Envelope) encodeParts(dataBuf, metaBuf []byte) ([][]byte, error) {
+	if err := e.marshalErr(); err != nil {
+		return nil, err
+	}
+	data, err := e.appendData(dataBuf)
+	if err != nil {
+		return nil, err
+	}
+	if links, embedded := dataMetaMembers(data); links != nil || embedded != nil {
+		// Members the data shares with the envelope are merged by MarshalJSON.
+		b, err := e.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{b}, nil
+	}
+	isDataNull, isEmptyObj, err := e.checkData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := e.precomputedJSON
+	if meta == nil {
+		if meta, err = e.appendMetaObject(metaBuf); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case len(meta) == 0 && isDataNull:
+		return [][]byte{[]byte("{}")}, nil
+	case len(meta) == 0:
+		return [][]byte{data}, nil
+	case isDataNull || isEmptyObj:
+		return [][]byte{meta}, nil
+	}
+	return [][]byte{data[:len(data)-1], []byte(","), meta[1:]}, nil
+}
+
+// writeParts writes parts to w in order.
+func writeParts(w io.Writer, parts [][]byte) (int64, error) {
+	var total int64
+	for _, p := range parts {
+		n, err := w.Write(p)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// partsLen returns the combined length of parts.
+func partsLen(parts [][]byte) int {
+	n := 0
+	for _, p := range parts {
+		n += len(p)
+	}
+	return n
+}
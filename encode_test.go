@@ -0,0 +1,143 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type encodeEmpty struct{}
+
+func TestEncode_MatchesMarshalJSON(t *testing.T) {
+	inst := New(WithCuries(map[string]string{"acme": "https://docs.example.com/{rel}"}))
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}, {Rel: "acme:orders", Href: "/orders"}}
+	})
+	RegisterStatic(inst, &TestData{}, []Link{{Rel: "self", Href: "/static"}})
+	ctx := context.Background()
+
+	withEmbedded := inst.Wrap(ctx, &collectionUser{ID: 1})
+	withEmbedded.AddEmbedded(ctx, "friend", &collectionUser{ID: 2})
+	emptyWithLink := inst.WrapRaw(&encodeEmpty{})
+	emptyWithLink.AddLink(SelfLink("/empty"))
+
+	envelopes := map[string]*Envelope{
+		"links":            inst.Wrap(ctx, &collectionUser{ID: 1}),
+		"embedded":         withEmbedded,
+		"no links":         inst.WrapRaw(&collectionUser{ID: 3}),
+		"nil data":         &Envelope{},
+		"empty object":     emptyWithLink,
+		"precomputed":      inst.Wrap(ctx, &TestData{ID: 4}),
+		"plain":            inst.WrapWith(ctx, &collectionUser{ID: 5}, PlainOutput()),
+		"raw message":      inst.WrapRaw(json.RawMessage(` {"a":1} `)),
+		"envelope literal": {Data: map[string]int{"b": 2}},
+	}
+	for name, env := range envelopes {
+		t.Run(name, func(t *testing.T) {
+			want, err := env.MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			n, err := env.WriteTo(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != string(want) || n != int64(len(want)) {
+				t.Fatalf("expected %s, got %s (%d bytes)", want, buf.String(), n)
+			}
+		})
+	}
+}
+
+func TestEncode_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	err := New().WrapRaw([]int{1}).Encode(&buf)
+	if !errors.Is(err, ErrNonObjectData) || buf.Len() != 0 {
+		t.Fatalf("expected ErrNonObjectData before any write, got %v and %q", err, buf.String())
+	}
+
+	env := New().WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(SelfLink("/users/1"))
+	if err := env.Encode(failingWriter{}); err == nil || !strings.Contains(err.Error(), "write failed") {
+		t.Fatalf("expected the writer's error, got %v", err)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+// largeDoc is a Data payload of about 1 MB.
+type largeDoc struct {
+	ID    int      `json:"id"`
+	Lines []string `json:"lines"`
+}
+
+func largeEnvelope() *Envelope {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, d *largeDoc) []Link {
+		return []Link{{Rel: "self", Href: "/docs/" + itoa(d.ID)}, {Rel: "raw", Href: "/docs/" + itoa(d.ID) + "/raw"}}
+	})
+	doc := &largeDoc{ID: 1, Lines: make([]string, 16*1024)}
+	for idx := range doc.Lines {
+		doc.Lines[idx] = strings.Repeat("x", 60)
+	}
+	return inst.Wrap(context.Background(), doc)
+}
+
+func BenchmarkEncode_1MB(b *testing.B) {
+	env := largeEnvelope()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if err := env.Encode(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshal_1MB(b *testing.B) {
+	env := largeEnvelope()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		body, err := json.Marshal(env)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, _ = io.Discard.Write(body)
+	}
+}
+
+func BenchmarkEnvelope_MarshalJSON(b *testing.B) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, d *TestData) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(d.ID)}, {Rel: "orders", Href: "/users/" + itoa(d.ID) + "/orders"}}
+	})
+	RegisterStatic(inst, &collectionUser{}, []Link{{Rel: "self", Href: "/static"}})
+	envelopes := []struct {
+		name string
+		env  *Envelope
+	}{
+		{"generated", inst.Wrap(context.Background(), &TestData{ID: 42, Name: "Alice Johnson", Email: "alice@example.com", Age: 30})},
+		{"precomputed", inst.Wrap(context.Background(), &collectionUser{ID: 1})},
+	}
+	for _, bb := range envelopes {
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if _, err := bb.env.MarshalJSON(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
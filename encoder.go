@@ -4,6 +4,7 @@
 package hal
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -15,50 +16,95 @@ import (
 // MarshalJSON implements the json.Marshaler interface.
 // It serializes the wrapped Data and splices in the HAL "_links" and "_embedded"
 // fields into the resulting JSON object.
+//
+// If the data has its own _links or _embedded member, such as a field
+// tagged json:"_links" left over from a hand-rolled HAL implementation, its
+// rels are merged into the computed ones, which win unless the instance was
+// created with WithDataMetaPrecedence; merged data rels are written as they
+// are, without CURIE or rewriting passes. In strict mode the collision is an
+// error wrapping ErrDataMetaCollision.
 func (e *Envelope) MarshalJSON() ([]byte, error) {
+	if err := e.marshalErr(); err != nil {
+		return nil, err
+	}
 	// OPTIMIZATION: Fast path for pre-computed JSON
+	if e.precomputedJSON != nil && e.Data == nil {
+		return e.precomputedJSON, nil
+	}
+
+	// 1. Marshal the underlying data into a pooled scratch buffer; the
+	// result is copied out before the buffer is released.
+	buf := getBuffer()
+	defer putBuffer(buf)
+	dataBytes, err := e.appendData(*buf)
+	if err != nil {
+		return nil, err
+	}
 	if e.precomputedJSON != nil {
-		if e.Data == nil {
-			return e.precomputedJSON, nil
+		if links, _ := dataMetaMembers(dataBytes); links != nil {
+			// The data's own links must be merged with the precomputed ones.
+			c := *e
+			c.materializePrecomputed()
+			return c.MarshalJSON()
 		}
-		dataBytes, err := json.Marshal(e.Data)
-		if err != nil {
+		if _, _, err := e.checkData(dataBytes); err != nil {
 			return nil, err
 		}
 		// Splice data with pre-computed links
 		return splicePrecomputed(dataBytes, e.precomputedJSON), nil
 	}
 
-	// 1. Marshal the underlying data
-	dataBytes, err := e.marshalData()
-	if err != nil {
-		return nil, err
-	}
-
 	// 2. Validate data is an object (so we can inject fields)
-	isDataNull, isEmptyObj, err := checkJSONStructure(dataBytes)
+	isDataNull, isEmptyObj, err := e.checkData(dataBytes)
 	if err != nil {
 		return nil, err
 	}
 
 	// 3. Prepare HAL metadata (_links, _embedded)
-	metaBytes, err := e.marshalMeta()
-	if err != nil {
-		return nil, err
+	var links, embedded map[string]any
+	templates := e.templatesForMarshal()
+	if !e.plain {
+		links = e.linksForMarshal()
+		embedded = e.embeddedForMarshal()
+		if err := e.checkCuries(links); err != nil {
+			return nil, err
+		}
+		if dataBytes, links, embedded, err = e.reconcileDataMeta(dataBytes, links, embedded); err != nil {
+			return nil, err
+		}
+		isEmptyObj = isEmptyObj || string(dataBytes) == "{}"
+	}
+	if len(links) == 0 && len(embedded) == 0 && len(templates) == 0 {
+		if isDataNull {
+			return []byte("{}"), nil
+		}
+		return bytes.Clone(dataBytes), nil
 	}
 
-	// 4. Combine
-	return spliceJSON(dataBytes, metaBytes, isDataNull, isEmptyObj), nil
+	// 4. Combine into a single buffer sized from the estimate
+	out := make([]byte, 0, len(dataBytes)+estimateMetaSize(links, embedded))
+	if isDataNull || isEmptyObj {
+		out = append(out, '{')
+	} else {
+		out = append(out, dataBytes[:len(dataBytes)-1]...)
+		out = append(out, ',')
+	}
+	if out, err = appendMeta(out, links, embedded, templates, e.sortedOutput()); err != nil {
+		return nil, err
+	}
+	return append(out, '}'), nil
 }
 
+// splicePrecomputed returns data with the members of linksJSON added. The
+// result never aliases data, which may be a pooled buffer.
 func splicePrecomputed(data, linksJSON []byte) []byte {
 	// linksJSON is already {"_links":{...}}
 	// We need to combine data and linksJSON
-	if len(data) == 0 || string(data) == "{}" {
+	if isNull, isEmpty, _ := checkJSONStructure(data); isNull || isEmpty {
 		return linksJSON
 	}
 	if len(linksJSON) == 0 {
-		return data
+		return bytes.Clone(data)
 	}
 	// Remove trailing } from data and , from linksJSON
 	result := make([]byte, 0, len(data)+len(linksJSON)-jsonTrailingChars)
@@ -69,91 +115,207 @@ func splicePrecomputed(data, linksJSON []byte) []byte {
 }
 
 func (e *Envelope) marshalData() ([]byte, error) {
-	if e.Data == nil {
+	return e.appendData(nil)
+}
+
+// appendData is like marshalData, but the default encoder appends to dst.
+// The result may alias dst, and for json.RawMessage data the Data itself.
+func (e *Envelope) appendData(dst []byte) ([]byte, error) {
+	switch v := e.Data.(type) {
+	case nil:
 		return nil, nil
+	case json.RawMessage:
+		// Raw documents are passed through verbatim; they may originate from
+		// other producers and carry a BOM or surrounding whitespace.
+		return trimJSON(v), nil
+	case *json.RawMessage:
+		if v == nil {
+			return nil, nil
+		}
+		return trimJSON(*v), nil
+	}
+	var b []byte
+	var err error
+	if fn := e.instance.customMarshalFunc(e.Data); fn != nil {
+		b, err = fn(e.Data)
+	} else {
+		b, err = appendJSON(dst, e.Data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("hal: marshaling data for %T: %w", e.Data, err)
+	}
+	if len(e.omitKeys) > 0 {
+		if obj := trimJSON(b); len(obj) > 0 && obj[0] == '{' {
+			b = omitMembers(obj, e.omitKeys)
+		}
+	}
+	if e.instance != nil && e.instance.canonicalData && isMapData(e.Data) {
+		if b, err = canonicalJSON(b); err != nil {
+			return nil, fmt.Errorf("hal: canonicalizing data for %T: %w", e.Data, err)
+		}
 	}
-	return json.Marshal(e.Data)
+	return trimJSON(b), nil
 }
 
-// checkJSONStructure returns (isNull, isEmptyObject, error).
-// Optimized: uses bytes comparison to avoid string allocation.
-func checkJSONStructure(b []byte) (bool, bool, error) {
-	if len(b) == 0 {
-		return true, false, nil
-	}
+// utf8BOM is the byte order mark some producers prepend to JSON documents.
+const utf8BOM = "\xef\xbb\xbf"
 
-	// Must start with '{'
-	if b[0] != '{' {
-		return false, false, errors.New("hal: data must be a JSON object to splice")
+// trimJSON strips an optional leading UTF-8 BOM and insignificant JSON
+// whitespace from both ends of b, so the first and last bytes are the
+// delimiters of the top-level value. It returns a subslice and never copies.
+func trimJSON(b []byte) []byte {
+	if len(b) >= len(utf8BOM) && string(b[:len(utf8BOM)]) == utf8BOM {
+		b = b[len(utf8BOM):]
 	}
-
-	// Check for empty object "{}"
-	if len(b) == 2 && b[1] == '}' {
-		return false, true, nil
+	for len(b) > 0 && isJSONSpace(b[0]) {
+		b = b[1:]
+	}
+	for len(b) > 0 && isJSONSpace(b[len(b)-1]) {
+		b = b[:len(b)-1]
 	}
+	return b
+}
+
+// isJSONSpace reports whether c is insignificant whitespace per RFC 8259.
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
 
-	// Check for null - bytes comparison (no allocation)
-	if len(b) >= 4 && b[0] == 'n' && b[1] == 'u' && b[2] == 'l' && b[3] == 'l' {
+// checkJSONStructure returns (isNull, isEmptyObject, error).
+// b is expected to have been passed through trimJSON, so insignificant
+// whitespace can only appear inside the value. Empty input and a null
+// produced by a custom marshaler count as null; an object holding only
+// whitespace, such as "{ }", counts as empty. Members are not validated.
+func checkJSONStructure(b []byte) (bool, bool, error) {
+	switch {
+	case len(b) == 0 || string(b) == "null":
 		return true, false, nil
+	case b[0] != '{' || len(b) < 2 || b[len(b)-1] != '}':
+		return false, false, ErrNonObjectData
 	}
-
-	return false, false, nil
+	return false, skipSpace(b, 1) == len(b)-1, nil
 }
 
-func (e *Envelope) marshalMeta() ([]byte, error) {
-	if e.instance != nil {
-		if curies := e.instance.resolveCuries(e.links); len(curies) > 0 {
-			e.addLinkRaw("curies", curies)
+// maxDataExcerpt is the number of bytes of non-object data quoted in errors.
+const maxDataExcerpt = 32
+
+// checkData is checkJSONStructure for b, the encoded e.Data. A non-object
+// error names the type of the data and quotes the start of b.
+func (e *Envelope) checkData(b []byte) (bool, bool, error) {
+	isNull, isEmpty, err := checkJSONStructure(b)
+	if err != nil {
+		excerpt := string(b)
+		if len(b) > maxDataExcerpt {
+			excerpt = string(b[:maxDataExcerpt]) + "..."
 		}
+		return false, false, fmt.Errorf("hal: marshaling data for %T: %w: got %q", e.Data, err, excerpt)
 	}
+	return isNull, isEmpty, nil
+}
 
-	// Fast path: no links
-	if len(e.links) == 0 {
-		if e.embedded != nil && len(e.embedded) > 0 {
-			return json.Marshal(map[string]any{"_embedded": e.embedded})
-		}
+// marshalMeta returns the _links and _embedded members as a JSON object,
+// or nil if there are none.
+func (e *Envelope) marshalMeta() ([]byte, error) {
+	return e.appendMetaObject(nil)
+}
+
+// appendMetaObject is like marshalMeta, but appends the object to dst, or
+// to a new buffer sized from the estimate if dst is nil. It returns nil if
+// there are no members.
+func (e *Envelope) appendMetaObject(dst []byte) ([]byte, error) {
+	if e.plain {
 		return nil, nil
 	}
-
-	// Only create map if needed
-	if e.embedded != nil && len(e.embedded) > 0 {
-		return json.Marshal(map[string]any{
-			"_links":    e.links,
-			"_embedded": e.embedded,
-		})
+	links := e.linksForMarshal()
+	embedded := e.embeddedForMarshal()
+	templates := e.templatesForMarshal()
+	if err := e.checkCuries(links); err != nil {
+		return nil, err
 	}
-
-	return json.Marshal(map[string]any{"_links": e.links})
+	if len(links) == 0 && len(embedded) == 0 && len(templates) == 0 {
+		return nil, nil
+	}
+	meta := dst
+	if meta == nil {
+		meta = make([]byte, 0, estimateMetaSize(links, embedded))
+	}
+	meta = append(meta, '{')
+	meta, err := appendMeta(meta, links, embedded, templates, e.sortedOutput())
+	if err != nil {
+		return nil, err
+	}
+	return append(meta, '}'), nil
 }
 
-func spliceJSON(data []byte, meta []byte, isDataNull, isDataEmptyObj bool) []byte {
-	if len(meta) == 0 {
-		if isDataNull {
-			return []byte("{}")
+// appendMeta appends the _embedded, _links and _templates members to dst, in
+// sorted key order to match map serialization. Rels within each section are
+// ordered as described by WithSortedOutput when ordered is set. Each section
+// is encoded separately so failures are attributed to it.
+func appendMeta(dst []byte, links, embedded map[string]any, templates map[string]Template, ordered bool) ([]byte, error) {
+	var err error
+	if len(embedded) > 0 {
+		dst = append(dst, `"_embedded":`...)
+		if dst, err = appendObject(dst, embedded, ordered); err != nil {
+			return nil, embeddedError(embedded, err)
 		}
-		return data
 	}
-
-	if isDataNull || isDataEmptyObj {
-		return meta
+	if len(links) > 0 {
+		if len(embedded) > 0 {
+			dst = append(dst, ',')
+		}
+		dst = append(dst, `"_links":`...)
+		if dst, err = appendLinks(dst, links, ordered); err != nil {
+			return nil, fmt.Errorf("hal: marshaling _links: %w", err)
+		}
 	}
-
-	// Direct slice allocation - avoid bytes.Buffer overhead
-	result := make([]byte, len(data)+len(meta)-1)
-	n := copy(result, data[:len(data)-1])
-	result[n] = ','
-	copy(result[n+1:], meta[1:])
-	return result
+	if len(templates) > 0 {
+		if len(embedded) > 0 || len(links) > 0 {
+			dst = append(dst, ',')
+		}
+		if dst, err = appendTemplates(dst, templates); err != nil {
+			return nil, fmt.Errorf("hal: marshaling _templates: %w", err)
+		}
+	}
+	return dst, nil
 }
 
-func (e *Envelope) computeLinks(ctx context.Context) {
-	if e.Data == nil {
+// computeLinks runs the generator r resolved for t, the link type of e.Data.
+func (e *Envelope) computeLinks(ctx context.Context, t reflect.Type, r resolution) {
+	if e.Data == nil || e.instance == nil {
+		return
+	}
+	if !e.instance.nilGenerators && isNilPointer(e.Data) {
+		return
+	}
+	// A cancelled request gains nothing from running the generator.
+	if err := contextErr(ctx); err != nil {
+		e.err = fmt.Errorf("hal: generator for %T skipped: %w", e.Data, err)
 		return
 	}
 
-	t := reflect.TypeOf(e.Data)
-	if gen, ok := e.instance.lookupGenerator(t); ok {
-		links := gen(ctx, e.Data)
+	target := linkValue(e.Data, t)
+	if r.res != nil {
+		e.computeResource(ctx, r.res, t, target)
+		return
+	}
+	if r.genErr != nil {
+		e.strictErr = r.genErr
+		return
+	}
+	if gen := r.gen; gen != nil {
+		links, err := e.instance.generateLinks(ctx, gen, t, target)
+		if err != nil {
+			if _, ok := err.(*GeneratorError); ok {
+				e.linkErr = err
+			} else {
+				e.err = err
+			}
+			return
+		}
+		if err := e.instance.checkGeneratorOutput(t, links); err != nil {
+			e.strictErr = err
+			return
+		}
 		for _, l := range links {
 			e.AddLink(l)
 		}
@@ -163,19 +325,77 @@ func (e *Envelope) computeLinks(ctx context.Context) {
 	if e.instance.strictMode {
 		ptrT := reflect.PointerTo(t)
 		if _, ok := e.instance.lookupGenerator(ptrT); ok {
-			panic(fmt.Sprintf("hal: strict mode error. Passed value type %v, but generator registered for pointer type %v", t, ptrT))
+			e.strictErr = fmt.Errorf("%w: passed %v, but generator registered for %v", ErrPointerMismatch, t, ptrT)
+			return
+		}
+		if _, ok := e.instance.collectionItems(e.Data); ok {
+			e.strictErr = fmt.Errorf("%w: %v is a list; use Collection, or WithAutoCollection", ErrNonObjectData, t)
+			return
 		}
-		// Strict check: if it's a struct (or ptr to struct) and no generator exists, panic.
-		if t.Kind() == reflect.Struct || (t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct) {
-			panic(fmt.Sprintf("hal: strict mode error. No generator registered for type %v", t))
+		// Strict check: data of a kind requiring links must have a generator.
+		if e.instance.requiresGenerator(e.Data) {
+			e.strictErr = fmt.Errorf("%w for type %v", ErrNoGenerator, t)
 		}
 	}
 }
 
+// callGenerator invokes gen for v. A panicking generator is reported to the
+// WithGeneratorRecovery callback, then recovered and returned as an error
+// wrapping ErrGeneratorPanic, which is also passed to the lint hook. Strict
+// instances without WithStrictErrors, and instances configured with
+// WithPropagateGeneratorPanics, re-panic instead. Failures of
+// RegisterInstanceE generators are always returned as a *GeneratorError.
+func (i *Instance) callGenerator(ctx context.Context, gen Generator, t reflect.Type, v any) (links []Link, err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		links = nil
+		if f, ok := r.(generatorFailure); ok {
+			err = &GeneratorError{Type: t, Err: f.err}
+			return
+		}
+		if i.generatorRecovery != nil {
+			i.generatorRecovery(r, t)
+		}
+		if i.strictMode && !i.strictErrors || i.propagatePanics {
+			panic(r)
+		}
+		err = fmt.Errorf("%w: %v: %v", ErrGeneratorPanic, t, r)
+		i.lintf("%v", err)
+	}()
+	return gen(ctx, v), nil
+}
+
+// Err returns the errors recorded while the envelope was built, joined:
+//   - a recovered generator panic, wrapping ErrGeneratorPanic; the links of
+//     the failed generator are dropped
+//   - a *GeneratorError from a RegisterInstanceE generator
+//   - a failed Transformer
+//   - a generator skipped because the context was already cancelled
+//   - on the outermost envelope of a request, a *BudgetExceededError (see
+//     WithWrapBudget)
+//   - with WithStrictErrors, a strict mode violation
+func (e *Envelope) Err() error {
+	return errors.Join(e.strictErr, e.linkErr, e.err, e.transformErr, e.budget.err())
+}
+
 // AddLink appends a link to the envelope.
 // If a link with the same Relation (Rel) already exists, it is converted to a slice
 // of links as per the HAL specification.
+//
+// # Ordering
+//
+// Within a rel, links appear in the order their sources ran: first the
+// registered generator's output, in slice order, then links added with AddLink,
+// in call order. WithSortedLinkArrays replaces this with a sort by Href and Name.
+//
+// AddLink works on envelopes constructed directly, such as &Envelope{Data: v}:
+// the links map is allocated on first use.
 func (e *Envelope) AddLink(l Link) {
+	l = e.instance.autoTemplate(l)
+	e.lintLink(l)
 	e.addLinkRaw(l.Rel, l)
 }
 
@@ -183,13 +403,19 @@ func (e *Envelope) addLinkRaw(rel string, val any) {
 	if e.links == nil {
 		e.links = make(map[string]any, defaultLinksCapacity)
 	}
-	if existing, ok := e.links[rel]; ok {
+	appendRel(e.links, rel, val)
+}
+
+// appendRel stores val under rel, promoting the entry to a slice when the rel
+// is already present.
+func appendRel(m map[string]any, rel string, val any) {
+	if existing, ok := m[rel]; ok {
 		if slice, isSlice := existing.([]any); isSlice {
-			e.links[rel] = append(slice, val)
+			m[rel] = append(slice, val)
 		} else {
-			e.links[rel] = []any{existing, val}
+			m[rel] = []any{existing, val}
 		}
 	} else {
-		e.links[rel] = val
+		m[rel] = val
 	}
 }
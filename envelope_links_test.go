@@ -59,3 +59,26 @@ func TestAllocationEfficientHALAugmentationDuringJSONSerialization_AutoInjection
 		t.Fatal("expected curies to be injected")
 	}
 }
+
+func TestEnvelopeLiteral_AddLinkAndMarshal(t *testing.T) {
+	type User struct {
+		ID int `json:"id"`
+	}
+
+	env := &Envelope{Data: &User{ID: 1}}
+	env.AddLink(Link{Rel: "self", Href: "/users/1"})
+	env.AddLink(Link{Rel: "acme:friends", Href: "/users/1/friends"})
+
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"id":1,"_links":{"acme:friends":{"href":"/users/1/friends"},"self":{"href":"/users/1"}}}`
+	if string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+	if env.Err() != nil {
+		t.Fatalf("expected no error, got %v", env.Err())
+	}
+}
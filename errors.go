@@ -0,0 +1,129 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import "errors"
+
+// Sentinel errors returned (wrapped) by the encoder and collection paths.
+// Use errors.Is to test for them:
+//
+//	if errors.Is(err, hal.ErrNonObjectData) { ... }
+var (
+	// ErrNonObjectData is returned when the wrapped data does not serialize
+	// to a JSON object, so HAL fields cannot be spliced into it. The error
+	// names the data's type and quotes the start of its JSON; for embedded
+	// resources it is wrapped in an *EmbeddedError locating the resource.
+	ErrNonObjectData = errors.New("hal: data must be a JSON object to splice")
+
+	// ErrNotASlice is returned when collection items are not a slice.
+	ErrNotASlice = errors.New("hal: collection items must be a slice")
+
+	// ErrNilData is returned when an operation requires data but received nil.
+	ErrNilData = errors.New("hal: data is nil")
+
+	// ErrGeneratorPanic is recorded on an Envelope when its generator
+	// panicked and the panic was recovered. See Envelope.Err.
+	ErrGeneratorPanic = errors.New("hal: generator panicked")
+
+	// ErrUnsupportedQueryType is returned by QueryOf for values and fields
+	// that cannot be encoded as query parameters.
+	ErrUnsupportedQueryType = errors.New("hal: unsupported query parameter type")
+
+	// ErrUnknownRouteKey is returned by a Router with RequireKnownKey set
+	// when no instance was added for the resolved key.
+	ErrUnknownRouteKey = errors.New("hal: no instance for route key")
+
+	// ErrUnknownRoute is returned by Instance.LinkFor for a route name that
+	// was not registered with RegisterRoute.
+	ErrUnknownRoute = errors.New("hal: unknown route")
+
+	// ErrInvalidCurie is returned for CURIE definitions whose prefix is not
+	// an XML NCName (a letter or underscore followed by letters, digits,
+	// '.', '-' or '_'), or whose href lacks the {rel} placeholder or is not
+	// a valid URI template.
+	ErrInvalidCurie = errors.New("hal: invalid curie")
+
+	// ErrCurieConflict is returned by Instance.Install when a module
+	// redefines a CURIE prefix installed by an earlier module with a
+	// different href, or removes it.
+	ErrCurieConflict = errors.New("hal: conflicting curie")
+
+	// ErrInvalidTemplate is returned by Expand for malformed URI templates.
+	ErrInvalidTemplate = errors.New("hal: invalid URI template")
+
+	// ErrMissingTemplateVar is returned by Link.Expand with RequireAll when
+	// a template variable has no value.
+	ErrMissingTemplateVar = errors.New("hal: missing URI template variable")
+
+	// ErrNoGenerator is returned by WrapE in strict mode when no generator
+	// is registered for the data's type.
+	ErrNoGenerator = errors.New("hal: no generator registered")
+
+	// ErrAmbiguousGenerator is returned by WrapE in strict mode when the
+	// data's type has no generator of its own and implements several
+	// interfaces that have one.
+	ErrAmbiguousGenerator = errors.New("hal: several interface generators match")
+
+	// ErrDuplicateGenerator is the panic value, wrapped, when a strict
+	// instance registers a second generator for a type with
+	// RegisterInstance. RegisterAppend adds generators instead.
+	ErrDuplicateGenerator = errors.New("hal: generator already registered")
+
+	// ErrPointerMismatch is returned by WrapE in strict mode when a value
+	// is wrapped but the generator is registered for a pointer to its type.
+	ErrPointerMismatch = errors.New("hal: value passed for a type registered by pointer")
+
+	// ErrInvalidLink is returned by WrapE when a generator's output fails
+	// the instance's strict checks (see WithStrictChecks).
+	ErrInvalidLink = errors.New("hal: generator returned an invalid link")
+
+	// ErrExtensionConflict is returned when marshaling a Link whose
+	// Extensions use the name of a standard link property, such as "href".
+	ErrExtensionConflict = errors.New("hal: link extension collides with a standard property")
+
+	// ErrUnregisteredCurie is returned by MarshalJSON in strict mode for a
+	// curied rel, such as "acme:orders", whose prefix has no CURIE definition.
+	ErrUnregisteredCurie = errors.New("hal: rel uses unregistered curie prefix")
+
+	// ErrRegistrationConflict is returned by Instance.Merge when both
+	// instances have registrations for the same type, route or CURIE prefix.
+	ErrRegistrationConflict = errors.New("hal: conflicting registrations")
+
+	// ErrInvalidTag is returned by RegisterTagged for malformed link
+	// declarations in hal struct tags.
+	ErrInvalidTag = errors.New("hal: invalid hal struct tag")
+
+	// ErrReservedMetaKey is returned for CollectionPage metadata keys that
+	// start with "_" or collide with "count" or "total".
+	ErrReservedMetaKey = errors.New("hal: reserved collection metadata key")
+
+	// ErrConflictingCounts is returned when marshaling a CollectionPage
+	// whose Count64 or Total64 disagrees with Count or Total.
+	ErrConflictingCounts = errors.New("hal: conflicting collection counts")
+
+	// ErrReservedProblemMember is returned for Problem extension keys that
+	// start with "_" or collide with a standard problem details member.
+	ErrReservedProblemMember = errors.New("hal: reserved problem member")
+
+	// ErrDataMetaCollision is returned in strict mode when the data has its
+	// own _links or _embedded member and the envelope writes one too.
+	ErrDataMetaCollision = errors.New("hal: data has its own HAL member")
+
+	// ErrEmbeddedNotFound is returned by EmbeddedAs when the document has
+	// no embedded resources under the requested rel.
+	ErrEmbeddedNotFound = errors.New("hal: no embedded resources for rel")
+
+	// ErrEmbeddedShape is returned by EmbeddedAs when the embedded value is
+	// neither an object nor an array of objects, or does not decode into
+	// the requested type.
+	ErrEmbeddedShape = errors.New("hal: malformed embedded resource")
+
+	// ErrLinkNotFound is returned by Client.Follow when the resource has no
+	// link for the requested rel, or none with the requested name.
+	ErrLinkNotFound = errors.New("hal: no link for rel")
+
+	// ErrAmbiguousLink is returned by Client.Follow when the resource has
+	// several links for the requested rel; use FollowName to pick one.
+	ErrAmbiguousLink = errors.New("hal: several links for rel")
+)
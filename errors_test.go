@@ -0,0 +1,151 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var errBoom = errors.New("boom")
+
+type failingData struct {
+	ID int `json:"id"`
+}
+
+func (failingData) MarshalJSON() ([]byte, error) {
+	return nil, errBoom
+}
+
+func TestErrors_DataMarshalWrapped(t *testing.T) {
+	env := New().Wrap(context.Background(), &failingData{ID: 1})
+
+	_, err := json.Marshal(env)
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("expected wrapped errBoom, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "hal: marshaling data for *hal.failingData") {
+		t.Fatalf("expected data stage in error, got %v", err)
+	}
+}
+
+func TestErrors_LinksMarshalWrapped(t *testing.T) {
+	env := New().WrapRaw(&collectionUser{ID: 1})
+	env.addLinkRaw("broken", failingData{})
+
+	_, err := env.MarshalJSON()
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("expected wrapped errBoom, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "hal: marshaling _links") {
+		t.Fatalf("expected _links stage in error, got %v", err)
+	}
+}
+
+func TestErrors_EmbeddedMarshalWrapped(t *testing.T) {
+	env := New().WrapRaw(&collectionUser{ID: 1})
+	env.embedded = map[string]any{"broken": failingData{}}
+
+	_, err := env.MarshalJSON()
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("expected wrapped errBoom, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "hal: marshaling _embedded") {
+		t.Fatalf("expected _embedded stage in error, got %v", err)
+	}
+}
+
+func TestErrors_NonObjectData(t *testing.T) {
+	env := New().Wrap(context.Background(), []int{1, 2})
+
+	_, err := json.Marshal(env)
+	if !errors.Is(err, ErrNonObjectData) {
+		t.Fatalf("expected ErrNonObjectData, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "[]int") {
+		t.Fatalf("expected type name in error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `got "[1,2]"`) {
+		t.Fatalf("expected the data in error, got %v", err)
+	}
+}
+
+func TestErrors_NonObjectDataExcerpt(t *testing.T) {
+	env := New().WrapRaw(json.RawMessage(`"` + strings.Repeat("x", 100) + `"`))
+
+	_, err := env.MarshalJSON()
+	if !errors.Is(err, ErrNonObjectData) {
+		t.Fatalf("expected ErrNonObjectData, got %v", err)
+	}
+	want := `got "\"` + strings.Repeat("x", maxDataExcerpt-1) + `..."`
+	if !strings.HasSuffix(err.Error(), want) {
+		t.Fatalf("expected a truncated excerpt, got %v", err)
+	}
+}
+
+func TestErrors_EmbeddedPath(t *testing.T) {
+	ctx := context.Background()
+	inst := New()
+	owner := inst.WrapRaw(&collectionUser{ID: 9})
+	owner.AddEmbedded(ctx, "pets", &collectionUser{ID: 10})
+	owner.AddEmbedded(ctx, "pets", []int{1})
+	order := inst.WrapRaw(&collectionUser{ID: 2})
+	order.AddEmbedded(ctx, "owner", owner)
+
+	env := inst.WrapRaw(&collectionUser{ID: 1})
+	for _, item := range []any{&collectionUser{ID: 1}, &collectionUser{ID: 2}, &collectionUser{ID: 3}, order} {
+		env.AddEmbedded(ctx, "items", item)
+	}
+
+	_, err := json.Marshal(env)
+	if !errors.Is(err, ErrNonObjectData) {
+		t.Fatalf("expected ErrNonObjectData, got %v", err)
+	}
+	var embErr *EmbeddedError
+	if !errors.As(err, &embErr) || embErr.Path != "_embedded.items[3]._embedded.owner._embedded.pets[1]" {
+		t.Fatalf("expected the path of the failing resource, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "[]int") {
+		t.Fatalf("expected the type name in error, got %v", err)
+	}
+}
+
+func TestErrors_CollectionItemWrapped(t *testing.T) {
+	items := []any{&collectionUser{ID: 1}, &failingData{ID: 2}}
+	page := New().Collection(context.Background(), items, 2, Link{Rel: "self", Href: "/items"})
+
+	_, err := json.Marshal(page)
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("expected wrapped errBoom, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "hal: collection item 1") {
+		t.Fatalf("expected item index in error, got %v", err)
+	}
+}
+
+func TestErrors_CollectionPanicValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		items any
+		want  error
+	}{
+		{"nil", nil, ErrNilData},
+		{"non-slice", 123, ErrNotASlice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				err, ok := recover().(error)
+				if !ok || !errors.Is(err, tt.want) {
+					t.Fatalf("expected panic wrapping %v, got %v", tt.want, err)
+				}
+			}()
+			New().Collection(context.Background(), tt.items, 0, Link{})
+		})
+	}
+}
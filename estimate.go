@@ -0,0 +1,176 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"bytes"
+	"sync"
+
+	json "github.com/goccy/go-json"
+)
+
+// Size estimates for values whose encoding is not known ahead of time.
+const (
+	estimatedUnknownValue   = 64  // values other than links and raw JSON
+	estimatedEmbeddedData   = 128 // data of an embedded envelope that is not raw JSON
+	estimatedLinkFieldExtra = 6   // ,"":"" around a link field
+	estimatedRelOverhead    = 4   // "":, around a rel key
+)
+
+// EstimateSize returns the approximate length in bytes of the envelope's JSON
+// encoding. Links are estimated from their field lengths and embedded
+// resources from their raw or precomputed JSON where available. Data is
+// measured by encoding it, so the call costs about as much as marshaling the
+// data once.
+//
+// The estimate is meant for preallocating response buffers; it is not exact
+// when strings need escaping or embedded data has to be encoded.
+//
+// # Example
+//
+//	buf := bytes.NewBuffer(make([]byte, 0, env.EstimateSize()))
+func (e *Envelope) EstimateSize() int {
+	data, err := e.marshalData()
+	if err != nil {
+		data = nil
+	}
+	return e.estimateSize(len(data))
+}
+
+// estimateSize estimates the envelope's encoding given its data length.
+func (e *Envelope) estimateSize(dataLen int) int {
+	if e.precomputedJSON != nil {
+		return dataLen + len(e.precomputedJSON)
+	}
+	if e.plain {
+		return dataLen
+	}
+	size := dataLen + estimateMetaSize(e.linksForMarshal(), e.embeddedForMarshal())
+	if dataLen == 0 {
+		size += len("{}")
+	}
+	return size
+}
+
+// estimateMetaSize estimates the length of the _links and _embedded members.
+func estimateMetaSize(links, embedded map[string]any) int {
+	if len(links) == 0 && len(embedded) == 0 {
+		return 0
+	}
+	return metaOverheadLen + estimateObjectSize(links) + estimateObjectSize(embedded)
+}
+
+func estimateObjectSize(m map[string]any) int {
+	size := len("{}")
+	for rel, v := range m {
+		size += len(rel) + estimatedRelOverhead + estimateValueSize(v)
+	}
+	return size
+}
+
+func estimateValueSize(v any) int {
+	switch vv := v.(type) {
+	case Link:
+		return estimateLinkSize(vv)
+	case json.RawMessage:
+		return len(vv)
+	case *Envelope:
+		if vv == nil {
+			return len("null")
+		}
+		dataLen := estimatedEmbeddedData
+		switch raw := vv.Data.(type) {
+		case nil:
+			dataLen = 0
+		case json.RawMessage:
+			dataLen = len(raw)
+		}
+		return vv.estimateSize(dataLen)
+	case []any:
+		size := len("[]")
+		for _, item := range vv {
+			size += estimateValueSize(item) + 1
+		}
+		return size
+	case []Link:
+		size := len("[]")
+		for _, l := range vv {
+			size += estimateLinkSize(l) + 1
+		}
+		return size
+	case []*Envelope:
+		size := len("[]")
+		for _, item := range vv {
+			size += estimateValueSize(item) + 1
+		}
+		return size
+	}
+	return estimatedUnknownValue
+}
+
+func estimateLinkSize(l Link) int {
+	size := len(`{"href":""}`) + len(l.Href)
+	if l.Templated {
+		size += len(`,"templated":true`)
+	}
+	for _, f := range [...]struct {
+		name, value string
+	}{
+		{"type", l.Type},
+		{"deprecation", l.Deprecation},
+		{"name", l.Name},
+		{"profile", l.Profile},
+		{"title", l.Title},
+		{"hreflang", l.HrefLang},
+		{"method", l.Method},
+	} {
+		if f.value != "" {
+			size += len(f.name) + len(f.value) + estimatedLinkFieldExtra
+		}
+	}
+	for k, v := range l.Extensions {
+		size += len(k) + estimateValueSize(v) + estimatedLinkFieldExtra
+	}
+	return size
+}
+
+// sliceWriter is an io.Writer appending to a byte slice.
+type sliceWriter struct {
+	b []byte
+}
+
+func (w *sliceWriter) Write(p []byte) (int, error) {
+	w.b = append(w.b, p...)
+	return len(p), nil
+}
+
+// appendEncoder encodes values directly into a caller-owned buffer.
+type appendEncoder struct {
+	w   sliceWriter
+	enc *json.Encoder
+}
+
+var appendEncoders = sync.Pool{
+	New: func() any {
+		a := new(appendEncoder)
+		a.enc = json.NewEncoder(&a.w)
+		return a
+	},
+}
+
+// appendJSON appends the JSON encoding of v to dst, matching json.Marshal.
+// Unlike json.Marshal, it writes into dst without an intermediate copy.
+func appendJSON(dst []byte, v any) ([]byte, error) {
+	a := appendEncoders.Get().(*appendEncoder)
+	a.w.b = dst
+	err := a.enc.Encode(v)
+	out := a.w.b
+	a.w.b = nil
+	appendEncoders.Put(a)
+	if err != nil {
+		return dst, err
+	}
+	// Encode terminates each value with a newline.
+	return bytes.TrimSuffix(out, []byte{'\n'}), nil
+}
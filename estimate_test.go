@@ -0,0 +1,80 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+// manyLinksEnvelope returns an envelope with n links, each carrying a title.
+func manyLinksEnvelope(n int) *Envelope {
+	env := New().Wrap(context.Background(), &collectionUser{ID: 1})
+	for idx := 0; idx < n; idx++ {
+		env.AddLink(Link{Rel: "rel" + itoa(idx), Href: "/users/1/things/" + itoa(idx), Title: "Thing " + itoa(idx)})
+	}
+	return env
+}
+
+func TestEstimateSize_CoversOutput(t *testing.T) {
+	ctx := context.Background()
+	inst := New()
+
+	links := manyLinksEnvelope(24)
+
+	mixed := inst.Wrap(ctx, json.RawMessage(`{"name":"order"}`))
+	mixed.AddLink(Link{Rel: "self", Href: "/orders/1", Templated: true, Method: "GET", Type: "application/hal+json"})
+	mixed.AddLink(Link{Rel: "item", Href: "/items/1"})
+	mixed.AddLink(Link{Rel: "item", Href: "/items/2", Name: "second"})
+	mixed.AddEmbedded(ctx, "customer", json.RawMessage(`{"id":9}`))
+
+	for name, env := range map[string]*Envelope{"links": links, "mixed": mixed, "empty": inst.Wrap(ctx, nil)} {
+		out, err := json.Marshal(env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := env.EstimateSize(); got < len(out) || got > 2*len(out)+metaOverheadLen {
+			t.Errorf("%s: estimate %d for output of %d bytes", name, got, len(out))
+		}
+	}
+}
+
+func TestEstimateSize_Precomputed(t *testing.T) {
+	inst := New()
+	env := inst.WrapPrecomputed(context.Background(), &collectionUser{ID: 1}, []byte(`{"self":{"href":"/users/1"}}`))
+
+	out, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := env.EstimateSize(); got < len(out) {
+		t.Fatalf("estimate %d below output of %d bytes", got, len(out))
+	}
+}
+
+func TestMarshal_ManyLinksSingleOutputBuffer(t *testing.T) {
+	env := manyLinksEnvelope(24)
+
+	out, err := env.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Output was assembled in the buffer sized by the estimate, not grown.
+	if cap(out) != len(wantUserID1)+estimateMetaSize(env.linksForMarshal(), nil) {
+		t.Fatalf("output buffer was reallocated: len %d, cap %d", len(out), cap(out))
+	}
+}
+
+func BenchmarkMarshal_ManyLinks(b *testing.B) {
+	env := manyLinksEnvelope(24)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := env.MarshalJSON(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
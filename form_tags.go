@@ -0,0 +1,184 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+
+	json "github.com/goccy/go-json"
+)
+
+// TemplateOption configures TemplateFor.
+type TemplateOption func(*templateConfig)
+
+type templateConfig struct {
+	tmpl    Template
+	flatten bool
+}
+
+// TemplateTitle sets the title of the template built by TemplateFor.
+func TemplateTitle(title string) TemplateOption {
+	return func(c *templateConfig) {
+		c.tmpl.Title = title
+	}
+}
+
+// TemplateContentType sets the content type of the template built by
+// TemplateFor.
+func TemplateContentType(contentType string) TemplateOption {
+	return func(c *templateConfig) {
+		c.tmpl.ContentType = contentType
+	}
+}
+
+// TemplateTarget sets the target of the template built by TemplateFor.
+func TemplateTarget(target string) TemplateOption {
+	return func(c *templateConfig) {
+		c.tmpl.Target = target
+	}
+}
+
+// FlattenNested makes TemplateFor describe the fields of nested struct
+// fields as properties of their own, named by their path with dots, such as
+// "address.city". By default nested struct fields are skipped.
+func FlattenNested() TemplateOption {
+	return func(c *templateConfig) {
+		c.flatten = true
+	}
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// TemplateFor builds a HAL-FORMS template for method whose properties
+// describe the exported fields of struct T, in declaration order. Fields
+// are named by their json tag, or the Go field name if untagged; fields
+// tagged json:"-" are skipped, and untagged embedded structs are flattened,
+// as encoding/json does. Struct fields that marshal themselves, such as
+// time.Time, are single properties; other nested structs are skipped unless
+// FlattenNested is given.
+//
+// Constraints are declared with hal struct tag options: required, prompt=
+// and regex=. The regex option takes the rest of the tag, commas included,
+// so it must come last. Such tags are not link declarations (see
+// RegisterTagged). TemplateFor panics with an error wrapping ErrInvalidTag
+// if T is not a struct or a tag holds an unknown option.
+//
+// # Example
+//
+//	type NewUser struct {
+//	    Name  string `json:"name" hal:"required,prompt=Full name"`
+//	    Email string `json:"email" hal:"required,regex=^[^@]+@[^@]+$"`
+//	    Notes string `json:"notes,omitempty"`
+//	}
+//
+//	create := hal.TemplateFor[NewUser](http.MethodPost, hal.TemplateTitle("Sign up"))
+func TemplateFor[T any](method string, opts ...TemplateOption) Template {
+	cfg := templateConfig{tmpl: Template{Method: method}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Errorf("%w: %v is not a struct", ErrInvalidTag, t))
+	}
+	props, err := cfg.properties(nil, t, "")
+	if err != nil {
+		panic(err)
+	}
+	cfg.tmpl.Properties = props
+	return cfg.tmpl
+}
+
+// properties appends the properties describing struct type t to props,
+// prefixing their names with prefix.
+func (c *templateConfig) properties(props []TemplateProperty, t reflect.Type, prefix string) ([]TemplateProperty, error) {
+	for idx := 0; idx < t.NumField(); idx++ {
+		f := t.Field(idx)
+		tag, hasTag := f.Tag.Lookup("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if tag == "-" {
+			continue
+		}
+		ft := baseType(f.Type)
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			var err error
+			if props, err = c.properties(props, ft, prefix); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if !hasTag || name == "" {
+			name = f.Name
+		}
+		if ft.Kind() == reflect.Struct && !marshalsItself(ft) {
+			if c.flatten {
+				var err error
+				if props, err = c.properties(props, ft, prefix+name+"."); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		p, err := parseFormTag(f.Tag.Get("hal"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v.%s: %w", ErrInvalidTag, t, f.Name, err)
+		}
+		p.Name = prefix + name
+		props = append(props, p)
+	}
+	return props, nil
+}
+
+// marshalsItself reports whether values of struct type t, or pointers to
+// them, encode themselves, like time.Time.
+func marshalsItself(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return t.Implements(jsonMarshalerType) || pt.Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || pt.Implements(textMarshalerType)
+}
+
+// isFormTag reports whether the hal tag declares template constraints
+// rather than links or embedding.
+func isFormTag(tag string) bool {
+	first, _, _ := strings.Cut(tag, ",")
+	return first == "required" || strings.HasPrefix(first, "prompt=") || strings.HasPrefix(first, "regex=")
+}
+
+// parseFormTag parses a `hal:"required,prompt=Name,regex=^\w+$"` tag. Tags
+// that are not form tags describe no constraints.
+func parseFormTag(tag string) (TemplateProperty, error) {
+	var p TemplateProperty
+	if !isFormTag(tag) {
+		return p, nil
+	}
+	for tag != "" {
+		var opt string
+		if strings.HasPrefix(tag, "regex=") {
+			opt, tag = tag, ""
+		} else {
+			opt, tag, _ = strings.Cut(tag, ",")
+		}
+		key, val, _ := strings.Cut(opt, "=")
+		switch key {
+		case "required":
+			p.Required = true
+		case "prompt":
+			p.Prompt = val
+		case "regex":
+			p.Regex = val
+		default:
+			return p, fmt.Errorf("unknown option %q", opt)
+		}
+	}
+	return p, nil
+}
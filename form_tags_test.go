@@ -0,0 +1,106 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type formAddress struct {
+	City string `json:"city" hal:"required"`
+	Zip  string `hal:"regex=^[0-9]{4,5}$"`
+}
+
+type formAudit struct {
+	Source string `json:"source"`
+}
+
+type formUser struct {
+	_ struct{} `hal:"self,href=/users/{ID}"`
+	formAudit
+	ID       int          `json:"-"`
+	Name     string       `json:"name" hal:"required,prompt=Full name"`
+	Email    string       `json:"email,omitempty" hal:"prompt=E-mail,regex=^[^@,]+@[^@]+$"`
+	Nickname string       // untagged
+	Born     time.Time    `json:"born"`
+	Home     formAddress  `json:"home"`
+	Work     *formAddress `json:",omitempty"`
+	secret   string
+}
+
+func TestTemplateFor(t *testing.T) {
+	got := TemplateFor[formUser](http.MethodPost, TemplateTitle("Sign up"), TemplateContentType("application/json"), TemplateTarget("/users"))
+	want := Template{
+		Method:      http.MethodPost,
+		Title:       "Sign up",
+		ContentType: "application/json",
+		Target:      "/users",
+		Properties: []TemplateProperty{
+			{Name: "source"},
+			{Name: "name", Required: true, Prompt: "Full name"},
+			{Name: "email", Prompt: "E-mail", Regex: "^[^@,]+@[^@]+$"},
+			{Name: "Nickname"},
+			{Name: "born"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestTemplateFor_FlattenNested(t *testing.T) {
+	got := TemplateFor[formUser](http.MethodPut, FlattenNested()).Properties
+	var names []string
+	for _, p := range got {
+		names = append(names, p.Name)
+	}
+	wantNames := []string{"source", "name", "email", "Nickname", "born", "home.city", "home.Zip", "Work.city", "Work.Zip"}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Fatalf("expected %v, got %v", wantNames, names)
+	}
+	if zip := got[6]; zip.Regex != "^[0-9]{4,5}$" || !got[5].Required {
+		t.Fatalf("expected nested constraints to be kept, got %+v", got[5:7])
+	}
+}
+
+func TestTemplateFor_Invalid(t *testing.T) {
+	type badTag struct {
+		Name string `hal:"required,hidden"`
+	}
+	for name, build := range map[string]func(){
+		"not a struct":   func() { TemplateFor[int](http.MethodPost) },
+		"unknown option": func() { TemplateFor[badTag](http.MethodPost) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, ErrInvalidTag) {
+					t.Fatalf("expected a panic wrapping ErrInvalidTag, got %v", err)
+				}
+			}()
+			build()
+		})
+	}
+}
+
+func TestTemplateFor_WithRegisterTagged(t *testing.T) {
+	inst := New()
+	if err := RegisterTagged[formUser](inst); err != nil {
+		t.Fatalf("expected form tags to be ignored by RegisterTagged, got %v", err)
+	}
+	RegisterTemplates(inst, func(_ context.Context, _ *formUser) map[string]Template {
+		return map[string]Template{"default": TemplateFor[formUser](http.MethodPut)}
+	})
+	env := inst.Wrap(context.Background(), &formUser{ID: 1})
+	if self, ok := env.Links()["self"]; !ok || self[0].Href != "/users/1" {
+		t.Fatalf("expected the tagged self link, got %v", env.Links())
+	}
+	if tmpl := env.templates["default"]; len(tmpl.Properties) != 5 {
+		t.Fatalf("expected the derived template, got %+v", tmpl)
+	}
+}
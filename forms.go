@@ -0,0 +1,135 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"reflect"
+)
+
+// FormsMediaType is the Content-Type written by Respond for envelopes with
+// templates, when the client accepts HAL-FORMS.
+const FormsMediaType = "application/prs.hal-forms+json; charset=utf-8"
+
+// Template is a HAL-FORMS template: a state transition, such as creating
+// or updating a resource, advertised to clients under _templates. By
+// convention the template named "default" describes the main transition.
+//
+// # Example
+//
+//	hal.Template{
+//	    Method:      http.MethodPost,
+//	    Title:       "Create order",
+//	    ContentType: "application/json",
+//	    Properties:  []hal.TemplateProperty{{Name: "sku", Required: true, Regex: "^[A-Z0-9]+$"}},
+//	}
+type Template struct {
+	Method      string             `json:"method"`
+	Title       string             `json:"title,omitempty"`
+	ContentType string             `json:"contentType,omitempty"`
+	Target      string             `json:"target,omitempty"` // Defaults to the resource's self link
+	Properties  []TemplateProperty `json:"properties,omitempty"`
+}
+
+// TemplateProperty describes one field a client fills in to submit a
+// Template.
+type TemplateProperty struct {
+	Name     string `json:"name"`
+	Prompt   string `json:"prompt,omitempty"`
+	Required bool   `json:"required,omitempty"`
+	Regex    string `json:"regex,omitempty"`
+}
+
+// AddTemplate sets the template named key, replacing any template of that
+// name. Templates are written under _templates, which is omitted when the
+// envelope has none.
+//
+// # Example
+//
+//	env.AddTemplate("default", hal.Template{Method: http.MethodPut, Title: "Edit"})
+func (e *Envelope) AddTemplate(key string, t Template) {
+	// Templates are not part of precomputed JSON.
+	e.materializePrecomputed()
+	if e.templates == nil {
+		e.templates = make(map[string]Template, 1)
+	}
+	e.templates[key] = t
+}
+
+// RegisterTemplates registers gen to produce the templates of *T, keyed by
+// name. Wrap adds them to every envelope of *T, as if by AddTemplate, after
+// its links. Panics are handled as for generators.
+//
+// # Example
+//
+//	hal.RegisterTemplates(inst, func(ctx context.Context, o *Order) map[string]hal.Template {
+//	    if o.Shipped {
+//	        return nil
+//	    }
+//	    return map[string]hal.Template{"cancel": {Method: http.MethodDelete, Title: "Cancel"}}
+//	})
+func RegisterTemplates[T any](i *Instance, gen func(context.Context, *T) map[string]Template) {
+	targetType := reflect.TypeOf((*T)(nil))
+	adapter := func(ctx context.Context, v any) map[string]Template {
+		return gen(ctx, v.(*T))
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.templates == nil {
+		i.templates = make(map[reflect.Type]func(context.Context, any) map[string]Template)
+	}
+	i.templates[targetType] = adapter
+	i.registryChanged()
+}
+
+// templateGenerator returns the template generator registered for t.
+func (i *Instance) templateGenerator(t reflect.Type) (func(context.Context, any) map[string]Template, bool) {
+	if f := i.frozen.Load(); f != nil {
+		gen, ok := f.templates[t]
+		return gen, ok
+	}
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	gen, ok := i.templates[t]
+	return gen, ok
+}
+
+// computeTemplates runs the template generator registered for t, the link
+// type of e.Data, if any.
+func (e *Envelope) computeTemplates(ctx context.Context, t reflect.Type) {
+	if e.Data == nil || t == nil || !e.instance.nilGenerators && isNilPointer(e.Data) {
+		return
+	}
+	gen, ok := e.instance.templateGenerator(t)
+	if !ok {
+		return
+	}
+	var templates map[string]Template
+	call := func(ctx context.Context, v any) []Link {
+		templates = gen(ctx, v)
+		return nil
+	}
+	if _, err := e.instance.callGenerator(ctx, call, t, linkValue(e.Data, t)); err != nil {
+		e.err = err
+		return
+	}
+	for key, tmpl := range templates {
+		e.AddTemplate(key, tmpl)
+	}
+}
+
+// templatesForMarshal returns the templates to serialize.
+func (e *Envelope) templatesForMarshal() map[string]Template {
+	if e.plain {
+		return nil
+	}
+	return e.templates
+}
+
+// appendTemplates appends the _templates member to dst.
+func appendTemplates(dst []byte, templates map[string]Template) ([]byte, error) {
+	dst = append(dst, `"_templates":`...)
+	return appendJSON(dst, templates)
+}
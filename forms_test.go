@@ -0,0 +1,179 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var editTemplate = Template{
+	Method:      http.MethodPut,
+	Title:       "Edit",
+	ContentType: "application/json",
+	Properties:  []TemplateProperty{{Name: "name", Prompt: "Name", Required: true, Regex: "^\\w+$"}},
+}
+
+const editTemplateJSON = `{"method":"PUT","title":"Edit","contentType":"application/json",` +
+	`"properties":[{"name":"name","prompt":"Name","required":true,"regex":"^\\w+$"}]}`
+
+func formsInstance() *Instance {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	RegisterTemplates(inst, func(_ context.Context, u *collectionUser) map[string]Template {
+		if u.ID == 0 {
+			return nil
+		}
+		return map[string]Template{"default": editTemplate, "delete": {Method: http.MethodDelete}}
+	})
+	return inst
+}
+
+func TestAddTemplate(t *testing.T) {
+	env := New().WrapRaw(&collectionUser{ID: 1})
+	if got := marshalString(t, env); got != `{"id":1}` {
+		t.Fatalf("expected no _templates without templates, got %s", got)
+	}
+
+	env.AddLink(SelfLink("/users/1"))
+	env.AddTemplate("default", Template{Method: http.MethodPost})
+	env.AddTemplate("default", editTemplate)
+	want := `{"id":1,"_links":{"self":{"href":"/users/1"}},"_templates":{"default":` + editTemplateJSON + `}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	var buf bytes.Buffer
+	if err := env.Encode(&buf); err != nil || buf.String() != want {
+		t.Fatalf("expected Encode to match MarshalJSON, got %s (%v)", buf.String(), err)
+	}
+
+	bare := &Envelope{}
+	bare.AddTemplate("create", Template{Method: http.MethodPost})
+	if got := marshalString(t, bare); got != `{"_templates":{"create":{"method":"POST"}}}` {
+		t.Fatalf("expected only _templates, got %s", got)
+	}
+}
+
+func TestRegisterTemplates(t *testing.T) {
+	inst := formsInstance()
+	ctx := context.Background()
+
+	want := `{"id":1,"_links":{"self":{"href":"/users/1"}},` +
+		`"_templates":{"default":` + editTemplateJSON + `,"delete":{"method":"DELETE"}}}`
+	if got := marshalString(t, inst.Wrap(ctx, &collectionUser{ID: 1})); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if got := marshalString(t, inst.Wrap(ctx, &collectionUser{})); strings.Contains(got, "_templates") {
+		t.Fatalf("expected no templates when the generator returns none, got %s", got)
+	}
+
+	page := inst.Collection(ctx, []collectionUser{{ID: 2}}, 1, SelfLink("/users"))
+	if got := marshalString(t, page); !strings.Contains(got, `"_templates":{"default":`) {
+		t.Fatalf("expected templates on collection items, got %s", got)
+	}
+	if got := marshalString(t, inst.WrapWith(ctx, &collectionUser{ID: 1}, PlainOutput())); got != `{"id":1}` {
+		t.Fatalf("expected plain output to omit templates, got %s", got)
+	}
+
+	UnregisterInstance[collectionUser](inst)
+	if got := marshalString(t, inst.Wrap(ctx, &collectionUser{ID: 1})); got != `{"id":1}` {
+		t.Fatalf("expected Unregister to remove templates, got %s", got)
+	}
+}
+
+func TestRegisterTemplates_StaticLinks(t *testing.T) {
+	inst := New()
+	RegisterStatic(inst, &collectionUser{}, []Link{SelfLink("/users")})
+	RegisterTemplates(inst, func(_ context.Context, _ *collectionUser) map[string]Template {
+		return map[string]Template{"create": {Method: http.MethodPost}}
+	})
+	inst.Freeze()
+
+	want := `{"id":1,"_links":{"self":{"href":"/users"}},"_templates":{"create":{"method":"POST"}}}`
+	if got := marshalString(t, inst.Wrap(context.Background(), &collectionUser{ID: 1})); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestRegisterTemplates_Panic(t *testing.T) {
+	inst := New()
+	RegisterTemplates(inst, func(_ context.Context, _ *collectionUser) map[string]Template {
+		panic("boom")
+	})
+	env := inst.Wrap(context.Background(), &collectionUser{ID: 1})
+	if !errors.Is(env.Err(), ErrGeneratorPanic) {
+		t.Fatalf("expected the panic to be recorded, got %v", env.Err())
+	}
+}
+
+func TestRegisterTemplates_CloneAndMerge(t *testing.T) {
+	base := formsInstance()
+	clone := base.Clone()
+	if got := marshalString(t, clone.Wrap(context.Background(), &collectionUser{ID: 1})); !strings.Contains(got, "_templates") {
+		t.Fatalf("expected the clone to keep templates, got %s", got)
+	}
+
+	other := New()
+	RegisterTemplates(other, func(_ context.Context, _ *collectionUser) map[string]Template { return nil })
+	if err := base.Merge(other); !errors.Is(err, ErrRegistrationConflict) || !strings.Contains(err.Error(), "templates for") {
+		t.Fatalf("expected a templates conflict, got %v", err)
+	}
+}
+
+func TestEnvelopeClone_Templates(t *testing.T) {
+	env := &Envelope{}
+	env.AddTemplate("default", editTemplate)
+	c := env.Clone()
+	c.AddTemplate("delete", Template{Method: http.MethodDelete})
+	if got := marshalString(t, env); strings.Contains(got, "delete") {
+		t.Fatalf("expected the original to be unchanged, got %s", got)
+	}
+}
+
+func TestRespond_Forms(t *testing.T) {
+	tests := []struct {
+		name      string
+		negotiate bool
+		accept    string
+		id        int
+		want      string
+		vary      bool
+	}{
+		{"forms accepted", false, "application/prs.hal-forms+json, application/hal+json;q=0.9", 1, FormsMediaType, true},
+		{"forms refused", false, "application/prs.hal-forms+json;q=0, application/hal+json", 1, MediaType, true},
+		{"wildcard", false, "*/*", 1, MediaType, true},
+		{"no templates", false, "application/prs.hal-forms+json", 0, MediaType, false},
+		{"negotiated forms only", true, "application/prs.hal-forms+json", 1, FormsMediaType, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inst := formsInstance()
+			if tt.negotiate {
+				WithContentNegotiation()(inst)
+			}
+			r := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+			r.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			inst.Respond(w, r, http.StatusOK, &collectionUser{ID: tt.id})
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if ct := w.Header().Get("Content-Type"); ct != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, ct)
+			}
+			if vary := w.Header().Values("Vary"); (len(vary) == 1) != tt.vary {
+				t.Errorf("expected Vary: Accept %v, got %v", tt.vary, vary)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// frozenRegistry is an immutable copy of the registrations read on every
+// Wrap and marshal, published by Freeze.
+type frozenRegistry struct {
+	generators       map[reflect.Type]Generator
+	interfaces       []interfaceGenerator
+	interfaceMatches *sync.Map
+	precomputed      map[reflect.Type]*PrecomputedLinks
+	resources        map[reflect.Type]func(context.Context, any) ([]Link, map[string]any)
+	templates        map[reflect.Type]func(context.Context, any) map[string]Template
+	marshalers       map[reflect.Type]MarshalFunc
+	curies           map[string]string
+}
+
+// Freeze publishes an immutable snapshot of the instance's generators,
+// static links, resource generators, templates, marshalers and CURIE
+// definitions. Afterwards Wrap and MarshalJSON read the snapshot without
+// taking the instance lock, which removes the lock contention between
+// requests served in parallel.
+//
+// Registrations after Freeze still take effect: each one rebuilds and
+// republishes the snapshot, at the cost of copying the registry. Freeze is
+// therefore meant to be called once setup is complete. Calling it again is
+// harmless. Clones start unfrozen.
+//
+// # Example
+//
+//	inst := hal.New()
+//	hal.RegisterInstance(inst, userLinks)
+//	inst.Freeze()
+func (i *Instance) Freeze() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.frozen.Store(i.freezeLocked())
+}
+
+// refreeze republishes the snapshot after a registration, if the instance
+// is frozen. The caller must hold i.mu for writing.
+func (i *Instance) refreeze() {
+	if i.frozen.Load() != nil {
+		i.frozen.Store(i.freezeLocked())
+	}
+}
+
+// freezeLocked copies the registrations read by frozenRegistry. The curies
+// map and interfaceMatches are replaced, never mutated, so they are shared.
+func (i *Instance) freezeLocked() *frozenRegistry {
+	return &frozenRegistry{
+		generators:       maps.Clone(i.generators),
+		interfaces:       slices.Clone(i.interfaces),
+		interfaceMatches: i.interfaceMatches,
+		precomputed:      maps.Clone(i.precomputed),
+		resources:        maps.Clone(i.resources),
+		templates:        maps.Clone(i.templates),
+		marshalers:       maps.Clone(i.marshalers),
+		curies:           i.curies,
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func frozenUsers() *Instance {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	inst.Freeze()
+	return inst
+}
+
+func TestFreeze_RegistrationsAfterFreeze(t *testing.T) {
+	inst := frozenUsers()
+	ctx := context.Background()
+
+	if got := marshalString(t, inst.Wrap(ctx, &collectionUser{ID: 1})); got != `{"id":1,"_links":{"self":{"href":"/users/1"}}}` {
+		t.Fatalf("expected the frozen generator, got %s", got)
+	}
+
+	RegisterInstance(inst, func(_ context.Context, o *mergeOrder) []Link {
+		return []Link{{Rel: "org:order", Href: "/orders/" + itoa(o.ID)}}
+	})
+	RegisterMarshaler(inst, func(o *mergeOrder) ([]byte, error) {
+		return []byte(`{"order":` + itoa(o.ID) + `}`), nil
+	})
+	inst.RegisterCurie("org", "https://org.example.com/{rel}")
+	got := marshalString(t, inst.Wrap(ctx, &mergeOrder{ID: 2}))
+	for _, want := range []string{`"order":2`, `"/orders/2"`, `"https://org.example.com/{rel}"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %s in %s", want, got)
+		}
+	}
+
+	UnregisterInstance[collectionUser](inst)
+	if got := marshalString(t, inst.Wrap(ctx, &collectionUser{ID: 1})); got != `{"id":1}` {
+		t.Fatalf("expected the generator to be removed, got %s", got)
+	}
+	inst.Reset()
+	if got := marshalString(t, inst.Wrap(ctx, &mergeOrder{ID: 3})); got != `{"id":3}` {
+		t.Fatalf("expected Reset to clear the snapshot, got %s", got)
+	}
+}
+
+func TestFreeze_InterfaceGenerators(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, identifiableLinks)
+	inst.Freeze()
+	if got := marshalString(t, inst.Wrap(context.Background(), &ifaceNote{ID: 2})); got != `{"id":2,"_links":{"self":{"href":"/notes/2"}}}` {
+		t.Fatalf("expected the interface generator, got %s", got)
+	}
+}
+
+func TestFreeze_ConcurrentRegister(t *testing.T) {
+	inst := frozenUsers()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 200; n++ {
+				if _, err := json.Marshal(inst.Wrap(ctx, &collectionUser{ID: n})); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := json.Marshal(WrapInstanceT(ctx, inst, &mergeOrder{ID: n})); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 50; n++ {
+				RegisterInstance(inst, func(_ context.Context, o *mergeOrder) []Link {
+					return []Link{{Rel: "self", Href: "/orders/" + itoa(o.ID)}}
+				})
+				inst.RegisterCurie("svc", "https://svc.example.com/{rel}")
+				UnregisterInstance[mergeOrder](inst)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// The parallel benchmarks show the read lock's contention, which grows with
+// GOMAXPROCS; compare with go test -bench Wrap_Parallel -cpu 1,2,4,8.
+func benchmarkWrapParallel(b *testing.B, freeze bool) {
+	inst := orgBase()
+	if freeze {
+		inst.Freeze()
+	}
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		u := &collectionUser{ID: 1}
+		for pb.Next() {
+			if _, err := inst.Wrap(ctx, u).MarshalJSON(); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+func BenchmarkWrap_Parallel(b *testing.B) {
+	b.Run("Unfrozen", func(b *testing.B) { benchmarkWrapParallel(b, false) })
+	b.Run("Frozen", func(b *testing.B) { benchmarkWrapParallel(b, true) })
+}
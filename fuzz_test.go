@@ -89,6 +89,33 @@ func FuzzLink_AddLink(f *testing.F) {
 	})
 }
 
+func FuzzMarshal_RawObject(f *testing.F) {
+	for _, seed := range []string{`{}`, `{ }`, "\n{\"a\":1}\n", "{\n\t\"a\": [1, {}]\n}", `null`, `[]`, `{`} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, raw string) {
+		env := New().WrapRaw(json.RawMessage(raw))
+		env.AddLink(Link{Rel: "self", Href: "/x"})
+		out, err := env.MarshalJSON()
+
+		var obj map[string]json.RawMessage
+		isObject := json.Unmarshal([]byte(raw), &obj) == nil && obj != nil
+		if !isObject {
+			return // Other input may be rejected, but must not panic.
+		}
+		if err != nil {
+			t.Fatalf("marshal error for object %q: %v", raw, err)
+		}
+		var result map[string]json.RawMessage
+		if err := json.Unmarshal(out, &result); err != nil {
+			t.Fatalf("invalid output %q for %q: %v", out, raw, err)
+		}
+		if _, ok := result["_links"]; !ok {
+			t.Fatalf("expected _links in %s", out)
+		}
+	})
+}
+
 func itoa(n int) string {
 	if n == 0 {
 		return "0"
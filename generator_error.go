@@ -0,0 +1,93 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// RegisterE registers a generator that can fail on the DefaultInstance.
+// See RegisterInstanceE.
+func RegisterE[T any](gen func(context.Context, *T) ([]Link, error)) {
+	RegisterInstanceE(DefaultInstance, gen)
+}
+
+// RegisterInstanceE registers a generator for *T that can fail, for links
+// built from URL parsing, ID encoding or permission lookups.
+//
+// When gen returns an error, none of its links are added. The error, naming
+// the type, is returned by WrapE and Envelope.Err, and MarshalJSON fails with
+// it, so a resource is never served with a partial set of links. Collections
+// handle failing items according to WithCollectionErrorPolicy.
+//
+// # Example
+//
+//	hal.RegisterInstanceE(inst, func(ctx context.Context, u *User) ([]hal.Link, error) {
+//	    id, err := codec.Encode(u.ID)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return []hal.Link{{Rel: "self", Href: "/users/" + id}}, nil
+//	})
+func RegisterInstanceE[T any](i *Instance, gen func(context.Context, *T) ([]Link, error)) {
+	RegisterInstance(i, func(ctx context.Context, v *T) []Link {
+		links, err := gen(ctx, v)
+		if err != nil {
+			// Carried to callGenerator, which turns it back into an error.
+			panic(generatorFailure{err})
+		}
+		return links
+	})
+}
+
+// generatorFailure is the panic value RegisterInstanceE adapters use to
+// report an error through the []Link-returning Generator signature.
+type generatorFailure struct {
+	err error
+}
+
+// GeneratorError is the error recorded on an Envelope when a generator
+// registered with RegisterInstanceE fails. Use errors.Is or errors.As with
+// the generator's own error to inspect the cause.
+type GeneratorError struct {
+	Type reflect.Type
+	Err  error
+}
+
+func (e *GeneratorError) Error() string {
+	return fmt.Sprintf("hal: generator for %v: %v", e.Type, e.Err)
+}
+
+func (e *GeneratorError) Unwrap() error {
+	return e.Err
+}
+
+// CollectionErrorPolicy selects how a collection treats items whose
+// generator returned an error.
+type CollectionErrorPolicy int
+
+const (
+	// AbortOnError fails the whole page: CollectionE returns the item's
+	// error and Collection records it on the page (see
+	// CollectionPage.Err). It is the default.
+	AbortOnError CollectionErrorPolicy = iota
+	// SkipOnError leaves failing items out of the page. Count reflects the
+	// items embedded; Total is kept as given.
+	SkipOnError
+)
+
+// WithCollectionErrorPolicy sets how one Collection call handles items whose
+// generator, registered with RegisterInstanceE, returned an error. Recovered
+// generator panics are not affected (see Envelope.Err).
+//
+// # Example
+//
+//	page, err := inst.CollectionE(ctx, users, total, self, hal.WithCollectionErrorPolicy(hal.SkipOnError))
+func WithCollectionErrorPolicy(p CollectionErrorPolicy) CollectionOption {
+	return func(c *collectionConfig) {
+		c.errorPolicy = p
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var errBadID = errors.New("bad id")
+
+// failingInstance registers a generator failing for negative IDs, after
+// having added a link, to check partial output is discarded.
+func failingInstance(opts ...InstanceOption) *Instance {
+	inst := New(opts...)
+	RegisterInstanceE(inst, func(_ context.Context, u *collectionUser) ([]Link, error) {
+		links := []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+		if u.ID < 0 {
+			return links, errBadID
+		}
+		return links, nil
+	})
+	return inst
+}
+
+func TestRegisterInstanceE_Success(t *testing.T) {
+	env, err := failingInstance().WrapE(context.Background(), &collectionUser{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := marshalString(t, env); got != `{"id":1,"_links":{"self":{"href":"/users/1"}}}` {
+		t.Fatalf("unexpected output %s", got)
+	}
+}
+
+func TestRegisterInstanceE_WrapE(t *testing.T) {
+	for name, inst := range map[string]*Instance{
+		"default": failingInstance(),
+		"strict":  failingInstance(WithStrictMode()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := inst.WrapE(context.Background(), &collectionUser{ID: -1})
+			var gerr *GeneratorError
+			if !errors.Is(err, errBadID) || !errors.As(err, &gerr) {
+				t.Fatalf("expected GeneratorError wrapping errBadID, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "*hal.collectionUser") {
+				t.Fatalf("expected the type in %q", err)
+			}
+		})
+	}
+}
+
+func TestRegisterInstanceE_WrapSurfacesAtMarshal(t *testing.T) {
+	env := failingInstance().Wrap(context.Background(), &collectionUser{ID: -1})
+	if len(env.links) != 0 || env.HasLink("self") {
+		t.Fatalf("expected no links from the failed generator, got %v", env.links)
+	}
+	if !errors.Is(env.Err(), errBadID) {
+		t.Fatalf("expected Err to report the failure, got %v", env.Err())
+	}
+	if _, err := json.Marshal(env); !errors.Is(err, errBadID) {
+		t.Fatalf("expected MarshalJSON to fail, got %v", err)
+	}
+}
+
+func TestRegisterInstanceE_Validate(t *testing.T) {
+	inst := failingInstance()
+	inst.RegisterSample(&collectionUser{ID: -1})
+	if err := inst.Validate(); err == nil || !strings.Contains(err.Error(), "bad id") || strings.Contains(err.Error(), "panicked") {
+		t.Fatalf("expected Validate to report the generator error, got %v", err)
+	}
+}
+
+func TestCollection_ErrorPolicy(t *testing.T) {
+	inst := failingInstance()
+	users := []*collectionUser{{ID: 1}, {ID: -2}, {ID: 3}}
+
+	_, err := inst.CollectionE(context.Background(), users, 3, SelfLink("/users"))
+	if !errors.Is(err, errBadID) || !strings.Contains(err.Error(), "item 1") {
+		t.Fatalf("expected the page to abort on item 1, got %v", err)
+	}
+
+	page, err := inst.CollectionE(context.Background(), users, 3, SelfLink("/users"), WithCollectionErrorPolicy(SkipOnError))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Count != 2 || page.Total != 3 {
+		t.Fatalf("expected 2 of 3 items, got count %d total %d", page.Count, page.Total)
+	}
+	if got := marshalString(t, page); strings.Contains(got, "/users/-2") {
+		t.Fatalf("expected failing item to be skipped, got %s", got)
+	}
+
+	page = inst.Collection(context.Background(), users, 3, SelfLink("/users"))
+	if !errors.Is(page.Err(), errBadID) {
+		t.Fatalf("expected Collection to record the item error, got %v", page.Err())
+	}
+	if _, err := json.Marshal(page); !errors.Is(err, errBadID) || !strings.Contains(err.Error(), "item 1") {
+		t.Fatalf("expected MarshalJSON to fail with the item error, got %v", err)
+	}
+}
+
+func TestCollection_ErrorPolicyStrict(t *testing.T) {
+	inst := failingInstance(WithStrictMode())
+	users := []*collectionUser{{ID: 1}, {ID: -2}}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, errBadID) {
+			t.Fatalf("expected Collection to panic with the item error, got %v", err)
+		}
+	}()
+	inst.Collection(context.Background(), users, 2, SelfLink("/users"))
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type panicUser struct {
+	ID    int                `json:"id"`
+	Attrs map[string]*string `json:"-"`
+}
+
+// nilMapGenerator dereferences a missing map entry, a typical generator bug.
+func nilMapGenerator(_ context.Context, u *panicUser) []Link {
+	return []Link{{Rel: "self", Href: *u.Attrs["href"]}}
+}
+
+func TestGeneratorPanic_RecoveredByDefault(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, nilMapGenerator)
+
+	env := inst.Wrap(context.Background(), &panicUser{ID: 1})
+
+	if !errors.Is(env.Err(), ErrGeneratorPanic) {
+		t.Fatalf("expected ErrGeneratorPanic, got %v", env.Err())
+	}
+
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != wantUserID1 {
+		t.Fatalf("expected links to be dropped, got %s", b)
+	}
+}
+
+func TestGeneratorPanic_Linted(t *testing.T) {
+	var lints []string
+	inst := New(WithLintHook(func(msg string) { lints = append(lints, msg) }))
+	RegisterInstance(inst, nilMapGenerator)
+
+	inst.Wrap(context.Background(), &panicUser{ID: 1})
+
+	if len(lints) != 1 || !strings.Contains(lints[0], "*hal.panicUser") || !strings.Contains(lints[0], "nil pointer dereference") {
+		t.Fatalf("expected the recovered panic to be linted with its type and value, got %q", lints)
+	}
+}
+
+func TestGeneratorPanic_NoErrorWhenGeneratorSucceeds(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, _ *panicUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/1"}}
+	})
+
+	if err := inst.Wrap(context.Background(), &panicUser{ID: 1}).Err(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestGeneratorPanic_PropagatedWithOption(t *testing.T) {
+	inst := New(WithPropagateGeneratorPanics())
+	RegisterInstance(inst, nilMapGenerator)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected generator panic to propagate")
+		}
+	}()
+
+	inst.Wrap(context.Background(), &panicUser{ID: 1})
+}
+
+func TestGeneratorPanic_PropagatedInStrictMode(t *testing.T) {
+	inst := New(WithStrictMode())
+	RegisterInstance(inst, nilMapGenerator)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected generator panic to propagate in strict mode")
+		}
+	}()
+
+	inst.Wrap(context.Background(), &panicUser{ID: 1})
+}
+
+func TestWithGeneratorRecovery(t *testing.T) {
+	var got []reflect.Type
+	inst := New(WithGeneratorRecovery(func(recovered any, typ reflect.Type) {
+		if _, ok := recovered.(error); !ok {
+			t.Errorf("expected the runtime error, got %v", recovered)
+		}
+		got = append(got, typ)
+	}))
+	RegisterInstance(inst, nilMapGenerator)
+
+	env := inst.Clone().Wrap(context.Background(), &panicUser{ID: 1})
+	env.AddLink(Link{Rel: "search", Href: "/users"})
+	if len(got) != 1 || got[0] != reflect.TypeOf(&panicUser{}) {
+		t.Fatalf("expected one call for *panicUser, got %v", got)
+	}
+	if !errors.Is(env.Err(), ErrGeneratorPanic) {
+		t.Fatalf("expected ErrGeneratorPanic, got %v", env.Err())
+	}
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"id":1,"_links":{"search":{"href":"/users"}}}`; string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+}
+
+func TestWithGeneratorRecovery_Collection(t *testing.T) {
+	var calls int
+	inst := New(WithGeneratorRecovery(func(any, reflect.Type) { calls++ }))
+	RegisterInstance(inst, func(_ context.Context, u *panicUser) []Link {
+		if u.ID == 2 {
+			return nilMapGenerator(context.Background(), u)
+		}
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+
+	page, err := inst.CollectionE(context.Background(), []panicUser{{ID: 1}, {ID: 2}, {ID: 3}}, 3, SelfLink("/users"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 || page.Count != 3 {
+		t.Fatalf("expected one recovered panic and 3 items, got %d and %d", calls, page.Count)
+	}
+}
+
+func TestWithGeneratorRecovery_StrictModeRepanics(t *testing.T) {
+	var calls int
+	inst := New(WithStrictMode(), WithGeneratorRecovery(func(any, reflect.Type) { calls++ }))
+	RegisterInstance(inst, nilMapGenerator)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected generator panic to propagate in strict mode")
+		}
+		if calls != 1 {
+			t.Fatalf("expected the hook to run before the panic, ran %d times", calls)
+		}
+	}()
+
+	inst.Wrap(context.Background(), &panicUser{ID: 1})
+}
+
+func TestWithGeneratorRecovery_GeneratorErrors(t *testing.T) {
+	inst := New(WithGeneratorRecovery(func(recovered any, _ reflect.Type) {
+		t.Errorf("expected no call for a returned error, got %v", recovered)
+	}))
+	RegisterInstanceE(inst, func(_ context.Context, _ *panicUser) ([]Link, error) {
+		return nil, errors.New("boom")
+	})
+	if _, err := inst.WrapE(context.Background(), &panicUser{ID: 1}); err == nil {
+		t.Fatal("expected the generator error")
+	}
+}
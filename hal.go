@@ -26,10 +26,16 @@
 //	json.Marshal(env) // => {"id":1,"name":"Alice","_links":{"self":{"href":"/users/1"}}}
 package hal
 
+import (
+	"net/url"
+	"reflect"
+)
+
 const (
 	jsonTrailingChars          = 2  // } to remove from JSON
 	precomputedLinksPrefixLen  = 10 // len(`{"_links":`)
 	precomputedLinksWrapperLen = 2  // {}
+	metaOverheadLen            = 26 // {"_embedded":,"_links":}
 )
 
 // Link represents a HAL Hypermedia link.
@@ -58,6 +64,19 @@ type Link struct {
 	Title       string `json:"title,omitempty"`
 	HrefLang    string `json:"hreflang,omitempty"` // OPTIONAL: language of target
 	Method      string `json:"method,omitempty"`   // Non-standard: common hint for HTTP methods
+
+	// Extensions holds vendor-specific properties serialized alongside the
+	// standard ones, such as "permissions". See Link.MarshalJSON.
+	Extensions map[string]any `json:"-"`
+}
+
+// Equal reports whether l and other are identical, comparing every field
+// including Rel. Extensions are compared deeply; nil and empty are equal.
+func (l Link) Equal(other Link) bool {
+	return l.Rel == other.Rel && l.Href == other.Href && l.Templated == other.Templated &&
+		l.Type == other.Type && l.Deprecation == other.Deprecation && l.Name == other.Name &&
+		l.Profile == other.Profile && l.Title == other.Title && l.HrefLang == other.HrefLang &&
+		l.Method == other.Method && extensionsEqual(l.Extensions, other.Extensions)
 }
 
 // Envelope is the container for your data with HAL metadata.
@@ -73,12 +92,28 @@ type Link struct {
 //	env := inst.Wrap(ctx, &MyStruct{...})
 //
 // The Envelope implements json.Marshaler and will inject HAL metadata automatically.
+//
+// The zero value is usable: an Envelope literal such as &Envelope{Data: v} has no
+// instance, so no generators run and no curies are resolved, but links added with
+// AddLink are serialized normally.
 type Envelope struct {
-	Data            any            // The user's struct
-	instance        *Instance      // The registry instance to use
-	links           map[string]any // Computed during marshal
-	embedded        map[string]any // Computed during marshal
-	precomputedJSON []byte         // OPTIMIZATION: pre-serialized links JSON
+	Data            any             // The user's struct
+	instance        *Instance       // The registry instance to use
+	links           map[string]any  // Computed during marshal
+	embedded        map[string]any  // Computed during marshal
+	precomputedJSON []byte          // OPTIMIZATION: pre-serialized links JSON
+	err             error           // Failure recorded while building links
+	transformErr    error           // Transformer failure; aborts marshaling
+	baseURL         *url.URL        // Relative hrefs are resolved against it
+	rewriter        func(Link) Link // Context-scoped rewriter (see WithContextLinkRewriter)
+	plain           bool            // Serialize Data only (see PlainOutput)
+	arrayRels       map[string]struct{}
+	budget          *wrapBudget         // Set on the first envelope built with a wrap budget
+	omitKeys        []string            // Data members moved to _embedded (see autoEmbed)
+	strictErr       error               // Strict mode failure; Wrap panics with it unless WithStrictErrors, WrapE returns it
+	linkErr         error               // RegisterInstanceE generator failure; aborts marshaling
+	hoistedCuries   bool                // Curies are emitted by an ancestor (see WithHoistedCuries)
+	templates       map[string]Template // HAL-FORMS templates (see AddTemplate)
 }
 
 // InstanceOption configures a new HAL Instance.
@@ -92,8 +127,10 @@ type InstanceOption func(*Instance)
 //
 //  1. You pass a value type T but registered a generator for *T
 //  2. You pass a type with no registered generator
+//  3. A generator returns a malformed link (see StrictCheck)
 //
 // This helps catch type mismatch errors during development.
+// All link checks are enabled unless narrowed with WithStrictChecks.
 //
 // # Example
 //
@@ -103,3 +140,61 @@ func WithStrictMode() InstanceOption {
 		i.strictMode = true
 	}
 }
+
+// WithStrictErrors enables the checks of WithStrictMode, but reports
+// violations found while wrapping as errors instead of panics, so a request
+// fails with an error response rather than a crashed handler. The violation
+// is recorded on the envelope: MarshalJSON, Encode and Respond fail with it,
+// WrapE returns it and Envelope.Err reports it. The error names the type
+// and wraps ErrPointerMismatch for a value passed where a generator is
+// registered for its pointer, or ErrNoGenerator for a missing registration.
+// A panicking generator is recovered as in non-strict instances.
+//
+// Invalid registrations, such as malformed CURIEs or routes, still panic:
+// they happen at startup, where failing fast is wanted.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithStrictErrors())
+//	hal.Respond(w, r, http.StatusOK, user) // 500 if *User has no generator
+func WithStrictErrors() InstanceOption {
+	return func(i *Instance) {
+		i.strictMode = true
+		i.strictErrors = true
+	}
+}
+
+// WithPropagateGeneratorPanics restores fail-fast behavior for generator panics.
+// By default, non-strict instances recover a panicking generator, drop its links,
+// record the failure on the Envelope (see Envelope.Err) and report it to the
+// lint hook (see WithLintHook). With this option the
+// panic propagates to the caller of Wrap, as it always does in strict mode.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithPropagateGeneratorPanics())
+func WithPropagateGeneratorPanics() InstanceOption {
+	return func(i *Instance) {
+		i.propagatePanics = true
+	}
+}
+
+// WithGeneratorRecovery installs a callback receiving each generator panic,
+// with the recovered value and the type the generator takes, such as *User,
+// for logging and metrics. It runs before the panic is recovered or, in
+// strict mode and with WithPropagateGeneratorPanics, re-raised. A recovered
+// panic drops the generator's links only: the envelope keeps those from
+// other sources, and a collection keeps its other items. The callback may
+// be called concurrently.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithGeneratorRecovery(func(recovered any, t reflect.Type) {
+//	    log.Printf("hal: generator for %v panicked: %v", t, recovered)
+//	    panics.Inc()
+//	}))
+func WithGeneratorRecovery(fn func(recovered any, t reflect.Type)) InstanceOption {
+	return func(i *Instance) {
+		i.generatorRecovery = fn
+	}
+}
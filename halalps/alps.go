@@ -0,0 +1,254 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+// Package halalps exports a hal.Instance as an ALPS (Application-Level
+// Profile Semantics) profile, so client tooling can discover the rels and
+// transitions an API offers without reading prose documentation.
+//
+// The profile is derived from sample resources: each sample is wrapped with
+// the instance, and the links its generator produces become transition
+// descriptors. Data fields, taken from the sample's json tags, become
+// semantic descriptors grouped under one descriptor per resource type.
+package halalps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
+)
+
+// ContentType is the media type of ALPS JSON documents.
+const ContentType = "application/alps+json"
+
+// ALPS descriptor types.
+const (
+	TypeSemantic   = "semantic"
+	TypeSafe       = "safe"
+	TypeIdempotent = "idempotent"
+	TypeUnsafe     = "unsafe"
+)
+
+// Document is the top-level ALPS JSON document.
+type Document struct {
+	ALPS Profile `json:"alps"`
+}
+
+// Profile is the body of an ALPS document.
+type Profile struct {
+	Version     string       `json:"version"`
+	Descriptors []Descriptor `json:"descriptor"`
+}
+
+// Descriptor is an ALPS descriptor: a data element or a transition.
+// A descriptor with only Href references another descriptor.
+type Descriptor struct {
+	ID          string       `json:"id,omitempty"`
+	Href        string       `json:"href,omitempty"`
+	Name        string       `json:"name,omitempty"`
+	Type        string       `json:"type,omitempty"`
+	Title       string       `json:"title,omitempty"`
+	Doc         *Doc         `json:"doc,omitempty"`
+	Descriptors []Descriptor `json:"descriptor,omitempty"`
+}
+
+// Doc links a descriptor to its human-readable documentation.
+type Doc struct {
+	Href string `json:"href"`
+}
+
+// Generate builds the ALPS profile of inst as indented JSON. samples maps
+// each resource type to a value of that type for its generator to run on.
+//
+// Every distinct rel becomes a transition descriptor whose type follows the
+// link's Method hint: safe for GET, HEAD or no hint, idempotent for PUT and
+// DELETE, unsafe otherwise. A curied rel whose prefix the instance defines
+// is identified by its expanded URI, which is also its doc link, and keeps
+// the rel as written in _links as its name. Each resource type becomes a
+// semantic descriptor listing its json fields and referencing its rels.
+//
+// # Example
+//
+//	doc, err := halalps.Generate(inst, map[reflect.Type]any{
+//	    reflect.TypeOf(&User{}): &User{ID: 1},
+//	})
+func Generate(inst *hal.Instance, samples map[reflect.Type]any) ([]byte, error) {
+	doc, err := Build(inst, samples)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// Samples returns the values recorded on inst with RegisterSample, keyed by
+// type, in the form Generate expects. Later samples of a type win.
+func Samples(inst *hal.Instance) map[reflect.Type]any {
+	samples := make(map[reflect.Type]any)
+	for _, s := range inst.Samples() {
+		if s != nil {
+			samples[reflect.TypeOf(s)] = s
+		}
+	}
+	return samples
+}
+
+// Build is like Generate but returns the document unserialized.
+func Build(inst *hal.Instance, samples map[reflect.Type]any) (*Document, error) {
+	types := make([]reflect.Type, 0, len(samples))
+	for t := range samples {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(a, b int) bool { return types[a].String() < types[b].String() })
+
+	curies := inst.Curies()
+	transitions := make(map[string]Descriptor)
+	var resources []Descriptor
+	for _, t := range types {
+		sample := samples[t]
+		if sample == nil || reflect.TypeOf(sample) != t {
+			return nil, fmt.Errorf("halalps: sample for %v has type %T", t, sample)
+		}
+		links, err := sampleLinks(inst, sample)
+		if err != nil {
+			return nil, fmt.Errorf("halalps: %v: %w", t, err)
+		}
+
+		resource := Descriptor{ID: typeName(t), Type: TypeSemantic}
+		resource.Descriptors = semanticFields(t, resource.ID)
+		rels := make([]string, 0, len(links))
+		for rel, l := range links {
+			rels = append(rels, rel)
+			if _, seen := transitions[rel]; !seen {
+				transitions[rel] = transition(rel, l, curies)
+			}
+		}
+		sort.Strings(rels)
+		for _, rel := range rels {
+			resource.Descriptors = append(resource.Descriptors, Descriptor{Href: "#" + transitions[rel].ID})
+		}
+		resources = append(resources, resource)
+	}
+
+	rels := make([]string, 0, len(transitions))
+	for rel := range transitions {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+	descriptors := resources
+	for _, rel := range rels {
+		descriptors = append(descriptors, transitions[rel])
+	}
+	return &Document{ALPS: Profile{Version: "1.0", Descriptors: descriptors}}, nil
+}
+
+// sampleLink holds the link fields relevant to a transition descriptor.
+type sampleLink struct {
+	Method string `json:"method"`
+	Title  string `json:"title"`
+}
+
+// sampleLinks wraps sample and returns the first link of each rel, as
+// serialized. The curies rel is HAL metadata and is left out.
+func sampleLinks(inst *hal.Instance, sample any) (map[string]sampleLink, error) {
+	env := inst.Wrap(context.Background(), sample)
+	if err := env.Err(); err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Links map[string]json.RawMessage `json:"_links"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	links := make(map[string]sampleLink, len(doc.Links))
+	for rel, raw := range doc.Links {
+		if rel == "curies" {
+			continue
+		}
+		var l sampleLink
+		if len(raw) > 0 && raw[0] == '[' {
+			var ls []sampleLink
+			if err := json.Unmarshal(raw, &ls); err != nil {
+				return nil, err
+			}
+			if len(ls) > 0 {
+				l = ls[0]
+			}
+		} else if err := json.Unmarshal(raw, &l); err != nil {
+			return nil, err
+		}
+		links[rel] = l
+	}
+	return links, nil
+}
+
+// transition returns the descriptor for rel. Curied rels are identified by
+// their expanded URI.
+func transition(rel string, l sampleLink, curies map[string]string) Descriptor {
+	d := Descriptor{ID: rel, Type: transitionType(l.Method), Title: l.Title}
+	if prefix, name, ok := strings.Cut(rel, ":"); ok {
+		if tmpl, ok := curies[prefix]; ok {
+			uri := strings.ReplaceAll(tmpl, "{rel}", name)
+			d.ID, d.Name = uri, rel
+			d.Doc = &Doc{Href: uri}
+		}
+	}
+	return d
+}
+
+// transitionType maps an HTTP method hint to an ALPS descriptor type.
+func transitionType(method string) string {
+	switch strings.ToUpper(method) {
+	case "", "GET", "HEAD", "OPTIONS":
+		return TypeSafe
+	case "PUT", "DELETE":
+		return TypeIdempotent
+	}
+	return TypeUnsafe
+}
+
+// semanticFields returns a descriptor for each json field of t. Ids are
+// qualified with the resource id, as ALPS ids are unique per document.
+func semanticFields(t reflect.Type, resourceID string) []Descriptor {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var fields []Descriptor
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || (f.Anonymous && f.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("json"); ok {
+			key, _, _ := strings.Cut(tag, ",")
+			if key == "-" {
+				continue
+			}
+			if key != "" {
+				name = key
+			}
+		}
+		fields = append(fields, Descriptor{ID: resourceID + "." + name, Name: name, Type: TypeSemantic})
+	}
+	return fields
+}
+
+// typeName returns the name of t, without pointers.
+func typeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
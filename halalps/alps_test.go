@@ -0,0 +1,125 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package halalps
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type Order struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"`
+	Secret string `json:"-"`
+}
+
+type Customer struct {
+	ID   int `json:"id"`
+	Name string
+}
+
+func testInstance(t *testing.T) *hal.Instance {
+	t.Helper()
+	inst := hal.New()
+	if err := inst.SetCuries(map[string]string{"shop": "https://docs.example.com/rels/{rel}"}); err != nil {
+		t.Fatal(err)
+	}
+	hal.RegisterInstance(inst, func(_ context.Context, o *Order) []hal.Link {
+		return []hal.Link{
+			{Rel: "self", Href: "/orders/1"},
+			{Rel: "shop:cancel", Href: "/orders/1/cancel", Method: "POST", Title: "Cancel order"},
+			{Rel: "edit", Href: "/orders/1", Method: "PUT"},
+			{Rel: "items", Href: "/orders/1/items/1"},
+			{Rel: "items", Href: "/orders/1/items/2"},
+		}
+	})
+	hal.RegisterInstance(inst, func(_ context.Context, c *Customer) []hal.Link {
+		return []hal.Link{
+			{Rel: "self", Href: "/customers/1"},
+			{Rel: "delete", Href: "/customers/1", Method: "DELETE"},
+		}
+	})
+	return inst
+}
+
+func testSamples() map[reflect.Type]any {
+	return map[reflect.Type]any{
+		reflect.TypeOf(&Order{}):    &Order{ID: 1},
+		reflect.TypeOf(&Customer{}): &Customer{ID: 1},
+	}
+}
+
+func TestGenerate_Golden(t *testing.T) {
+	got, err := Generate(testInstance(t), testSamples())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "profile.golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("profile differs from %s (run with -update):\n%s", golden, got)
+	}
+}
+
+func TestBuild_TransitionTypes(t *testing.T) {
+	doc, err := Build(testInstance(t), testSamples())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"self":                                 TypeSafe,
+		"items":                                TypeSafe,
+		"edit":                                 TypeIdempotent,
+		"delete":                               TypeIdempotent,
+		"https://docs.example.com/rels/cancel": TypeUnsafe,
+	}
+	got := make(map[string]string)
+	for _, d := range doc.ALPS.Descriptors {
+		if d.Type != TypeSemantic {
+			got[d.ID] = d.Type
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected transitions %v, got %v", want, got)
+	}
+}
+
+func TestBuild_SampleTypeMismatch(t *testing.T) {
+	_, err := Build(hal.New(), map[reflect.Type]any{reflect.TypeOf(&Order{}): Order{}})
+	if err == nil {
+		t.Fatal("expected error for mismatched sample")
+	}
+}
+
+func TestSamples_FromInstance(t *testing.T) {
+	inst := testInstance(t)
+	inst.RegisterSample(&Order{ID: 1}, &Customer{ID: 1}, &Order{ID: 2})
+
+	samples := Samples(inst)
+	if len(samples) != 2 {
+		t.Fatalf("expected one sample per type, got %v", samples)
+	}
+	if o := samples[reflect.TypeOf(&Order{})].(*Order); o.ID != 2 {
+		t.Fatalf("expected the last Order sample, got %+v", o)
+	}
+}
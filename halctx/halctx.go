@@ -0,0 +1,68 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+// Package halctx provides the context keys go-hal generators use for
+// request-scoped authorization data. Middleware stores the current actor and
+// granted scopes with the With functions, and generators read them back, so
+// generators written by different teams interoperate:
+//
+//	ctx = halctx.WithActor(ctx, halctx.Actor{ID: "u-42", Roles: []string{"admin"}})
+//	ctx = halctx.WithScopes(ctx, []string{"users:write"})
+//
+//	hal.Register(func(ctx context.Context, u *User) []hal.Link {
+//	    links := []hal.Link{{Rel: "self", Href: "/users/" + u.ID}}
+//	    if halctx.HasScope(ctx, "users:write") {
+//	        links = append(links, hal.Link{Rel: "edit", Href: "/users/" + u.ID})
+//	    }
+//	    return links
+//	})
+package halctx
+
+import (
+	"context"
+	"slices"
+)
+
+// Actor identifies the principal a resource is rendered for.
+type Actor struct {
+	ID    string
+	Roles []string
+}
+
+// HasRole reports whether the actor has role.
+func (a Actor) HasRole(role string) bool {
+	return slices.Contains(a.Roles, role)
+}
+
+type (
+	actorKey  struct{}
+	scopesKey struct{}
+)
+
+// WithActor returns a copy of ctx carrying actor.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the Actor stored in ctx, if any.
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
+
+// WithScopes returns a copy of ctx carrying the granted scopes.
+// The slice is copied, so later changes by the caller have no effect.
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, slices.Clone(scopes))
+}
+
+// Scopes returns the scopes stored in ctx, or nil if there are none.
+func Scopes(ctx context.Context) []string {
+	scopes, _ := ctx.Value(scopesKey{}).([]string)
+	return scopes
+}
+
+// HasScope reports whether scope was granted in ctx. Scopes match exactly.
+func HasScope(ctx context.Context, scope string) bool {
+	return slices.Contains(Scopes(ctx), scope)
+}
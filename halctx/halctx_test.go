@@ -0,0 +1,50 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package halctx
+
+import (
+	"context"
+	"testing"
+)
+
+func TestActor(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := ActorFromContext(ctx); ok {
+		t.Fatal("expected no actor in empty context")
+	}
+
+	ctx = WithActor(ctx, Actor{ID: "u-42", Roles: []string{"admin"}})
+	actor, ok := ActorFromContext(ctx)
+	if !ok || actor.ID != "u-42" {
+		t.Fatalf("expected stored actor, got %+v, %v", actor, ok)
+	}
+	if !actor.HasRole("admin") || actor.HasRole("editor") {
+		t.Fatalf("unexpected role matching for %+v", actor)
+	}
+}
+
+func TestScopes(t *testing.T) {
+	ctx := context.Background()
+	if HasScope(ctx, "users:read") || Scopes(ctx) != nil {
+		t.Fatal("expected no scopes in empty context")
+	}
+
+	granted := []string{"users:read", "users:write"}
+	ctx = WithScopes(ctx, granted)
+	granted[0] = "mutated"
+
+	tests := map[string]bool{
+		"users:read":  true,
+		"users:write": true,
+		"users":       false,
+		"users:*":     false,
+		"mutated":     false,
+		"":            false,
+	}
+	for scope, want := range tests {
+		if got := HasScope(ctx, scope); got != want {
+			t.Errorf("HasScope(%q) = %v, want %v", scope, got, want)
+		}
+	}
+}
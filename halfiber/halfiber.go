@@ -0,0 +1,134 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+// Package halfiber integrates go-hal with the Fiber web framework.
+//
+// Fiber is built on fasthttp, so there is no *http.Request to derive links
+// from. HAL adapts the Fiber request into hal.RequestInfo, wraps the data and
+// writes an application/hal+json response:
+//
+//	app.Get("/users/:id", func(c *fiber.Ctx) error {
+//	    return halfiber.HAL(c, fiber.StatusOK, user)
+//	})
+package halfiber
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+
+	"github.com/gofiber/fiber/v2"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
+)
+
+// ContentType is the media type of responses written by HAL.
+const ContentType = "application/hal+json"
+
+// InstanceKey is the fiber.Ctx locals key consulted for the *hal.Instance to
+// wrap with. If unset, hal.DefaultInstance is used.
+//
+//	app.Use(func(c *fiber.Ctx) error {
+//	    c.Locals(halfiber.InstanceKey, inst)
+//	    return c.Next()
+//	})
+const InstanceKey = "hal.instance"
+
+// errNilData is returned by HAL for a nil *hal.Envelope or
+// *hal.CollectionPage.
+var errNilData = errors.New("halfiber: nil envelope or collection page")
+
+// errorBody is the minimal document rendered for error values.
+type errorBody struct {
+	Message string `json:"message"`
+}
+
+// HAL writes data as a HAL document with the given status.
+//
+// The request is exposed to generators through hal.RequestInfoFromContext.
+// Data is handled by shape:
+//
+//   - *hal.Envelope and *hal.CollectionPage are written as-is
+//   - slices become a collection whose self link is the request URL
+//   - errors become {"message": ...} with a self link
+//   - anything else is wrapped with the instance
+//
+// Failures, such as a nil *hal.Envelope or a strict mode violation, are
+// returned for Fiber's error handler instead of writing a response.
+func HAL(c *fiber.Ctx, status int, data any) error {
+	inst := Instance(c)
+	ctx := hal.WithRequestInfo(c.UserContext(), RequestInfo(c))
+
+	var body []byte
+	var err error
+	switch v := data.(type) {
+	case *hal.Envelope:
+		if v == nil {
+			return errNilData
+		}
+		body, err = v.MarshalJSON()
+	case *hal.CollectionPage:
+		if v == nil {
+			return errNilData
+		}
+		body, err = v.MarshalJSON()
+	case error:
+		env := inst.WrapRaw(&errorBody{Message: v.Error()})
+		env.AddLink(hal.SelfLink(c.OriginalURL()))
+		body, err = env.MarshalJSON()
+	default:
+		if rv := reflect.ValueOf(data); rv.Kind() == reflect.Slice {
+			var page *hal.CollectionPage
+			if page, err = inst.CollectionE(ctx, data, rv.Len(), hal.SelfLink(c.OriginalURL())); err == nil {
+				body, err = page.MarshalJSON()
+			}
+		} else {
+			var env *hal.Envelope
+			if env, err = inst.WrapE(ctx, data); err == nil {
+				body, err = env.MarshalJSON()
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	c.Status(status)
+	c.Set(fiber.HeaderContentType, ContentType)
+	// Send hands the slice to fasthttp without copying it.
+	return c.Send(body)
+}
+
+// Instance returns the *hal.Instance stored under InstanceKey, or
+// hal.DefaultInstance.
+func Instance(c *fiber.Ctx) *hal.Instance {
+	if inst, ok := c.Locals(InstanceKey).(*hal.Instance); ok && inst != nil {
+		return inst
+	}
+	return hal.DefaultInstance
+}
+
+// RequestInfo describes the Fiber request. Scheme and host come from
+// c.Protocol and c.Hostname, which honor X-Forwarded-Proto and
+// X-Forwarded-Host according to the app's trusted proxy configuration.
+// Prefix is taken from X-Forwarded-Prefix under the same configuration.
+func RequestInfo(c *fiber.Ctx) hal.RequestInfo {
+	u, err := url.ParseRequestURI(c.OriginalURL())
+	if err != nil {
+		u = &url.URL{Path: c.Path()}
+	}
+	u.Scheme = c.Protocol()
+	u.Host = c.Hostname()
+	info := hal.RequestInfo{Method: c.Method(), URL: u}
+	if c.IsProxyTrusted() {
+		info.Prefix = c.Get("X-Forwarded-Prefix")
+	}
+	return info
+}
+
+// Context returns the request's user context enriched with its
+// hal.RequestInfo, for handlers that wrap data themselves.
+func Context(c *fiber.Ctx) context.Context {
+	return hal.WithRequestInfo(c.UserContext(), RequestInfo(c))
+}
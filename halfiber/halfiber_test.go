@@ -0,0 +1,167 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package halfiber
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
+)
+
+type user struct {
+	ID int `json:"id"`
+}
+
+func newApp(inst *hal.Instance, handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(InstanceKey, inst)
+		return c.Next()
+	})
+	app.Get("/*", handler)
+	return app
+}
+
+func absoluteSelf(ctx context.Context, u *user) []hal.Link {
+	req, ok := hal.RequestInfoFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return []hal.Link{hal.SelfLink(req.URL.Scheme + "://" + req.URL.Host + "/users/" + strconv.Itoa(u.ID))}
+}
+
+func do(t *testing.T, app *fiber.App, path string, headers map[string]string) (int, string, string) {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodGet, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, resp.Header.Get(fiber.HeaderContentType), string(body)
+}
+
+func TestHAL_WrapsWithForwardedRequestURL(t *testing.T) {
+	inst := hal.New()
+	hal.RegisterInstance(inst, absoluteSelf)
+	app := newApp(inst, func(c *fiber.Ctx) error {
+		return HAL(c, fiber.StatusOK, &user{ID: 1})
+	})
+
+	status, ct, body := do(t, app, "/users/1", map[string]string{
+		fiber.HeaderXForwardedProto: "https",
+		fiber.HeaderXForwardedHost:  "api.example.com",
+	})
+
+	if status != fiber.StatusOK || ct != ContentType {
+		t.Fatalf("unexpected status %d or content type %q", status, ct)
+	}
+	want := `{"id":1,"_links":{"self":{"href":"https://api.example.com/users/1"}}}`
+	if body != want {
+		t.Fatalf("expected %s, got %s", want, body)
+	}
+}
+
+func TestHAL_ForwardedPrefix(t *testing.T) {
+	inst := hal.New(hal.WithContextLinkRewriter(hal.RequestPrefixRewriter))
+	hal.RegisterInstance(inst, func(_ context.Context, u *user) []hal.Link {
+		return []hal.Link{hal.SelfLink("/users/" + strconv.Itoa(u.ID))}
+	})
+	app := newApp(inst, func(c *fiber.Ctx) error {
+		return HAL(c, fiber.StatusOK, &user{ID: 1})
+	})
+
+	_, _, body := do(t, app, "/users/1", map[string]string{"X-Forwarded-Prefix": "/api/v2"})
+	if want := `{"id":1,"_links":{"self":{"href":"/api/v2/users/1"}}}`; body != want {
+		t.Fatalf("expected %s, got %s", want, body)
+	}
+	_, _, body = do(t, app, "/users/1", nil)
+	if want := `{"id":1,"_links":{"self":{"href":"/users/1"}}}`; body != want {
+		t.Fatalf("expected %s, got %s", want, body)
+	}
+}
+
+func TestHAL_SliceBecomesCollection(t *testing.T) {
+	inst := hal.New()
+	app := newApp(inst, func(c *fiber.Ctx) error {
+		return HAL(c, fiber.StatusOK, []*user{{ID: 1}, {ID: 2}})
+	})
+
+	_, _, body := do(t, app, "/users?page=1", nil)
+
+	want := `{"_links":{"self":{"href":"/users?page=1"}},"_embedded":{"items":[{"id":1},{"id":2}]},"count":2,"total":2}`
+	if body != want {
+		t.Fatalf("expected %s, got %s", want, body)
+	}
+}
+
+func TestHAL_ErrorEnvelope(t *testing.T) {
+	app := newApp(hal.New(), func(c *fiber.Ctx) error {
+		return HAL(c, fiber.StatusNotFound, errors.New("user not found"))
+	})
+
+	status, ct, body := do(t, app, "/users/9", nil)
+
+	if status != fiber.StatusNotFound || ct != ContentType {
+		t.Fatalf("unexpected status %d or content type %q", status, ct)
+	}
+	want := `{"message":"user not found","_links":{"self":{"href":"/users/9"}}}`
+	if body != want {
+		t.Fatalf("expected %s, got %s", want, body)
+	}
+}
+
+func TestHAL_DefaultInstanceFallback(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		if Instance(c) != hal.DefaultInstance {
+			t.Error("expected DefaultInstance without locals")
+		}
+		env := hal.New().WrapRaw(&user{ID: 3})
+		return HAL(c, fiber.StatusCreated, env)
+	})
+
+	status, _, body := do(t, app, "/", nil)
+	if status != fiber.StatusCreated || body != `{"id":3}` {
+		t.Fatalf("unexpected response %d %s", status, body)
+	}
+}
+
+func TestHAL_Failures(t *testing.T) {
+	strict := hal.New(hal.WithStrictMode())
+	tests := []struct {
+		name string
+		inst *hal.Instance
+		data any
+	}{
+		{"nil envelope", hal.New(), (*hal.Envelope)(nil)},
+		{"nil page", hal.New(), (*hal.CollectionPage)(nil)},
+		{"strict mode", strict, &user{ID: 1}},
+		{"strict mode collection", strict, []*user{{ID: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newApp(tt.inst, func(c *fiber.Ctx) error {
+				return HAL(c, fiber.StatusOK, tt.data)
+			})
+			if status, _, body := do(t, app, "/users/1", nil); status != fiber.StatusInternalServerError {
+				t.Fatalf("expected 500, got %d %s", status, body)
+			}
+		})
+	}
+}
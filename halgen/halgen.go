@@ -0,0 +1,278 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+// Package halgen generates typed link builders from a route table, so that
+// links are constructed with compile-time checked parameters instead of
+// string concatenation. It backs the halgen command:
+//
+//	//go:generate go run github.com/Emin-ACIKGOZ/go-hal/cmd/halgen -in routes.json -pkg links -out links_gen.go
+//
+// A route {Name: "UserSelf", Rel: "self", Href: "/users/{id}"} produces
+//
+//	func UserSelfLink(id string) hal.Link
+//
+// Path-style expressions become parameters and are expanded at run time with
+// hal.Expand, so generated builders and runtime templates cannot drift.
+// Query-style expressions ({?q} and {&q}) are left in the href and the link
+// is marked templated, for clients to fill in.
+package halgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
+)
+
+// RouteSpec describes one link builder to generate.
+type RouteSpec struct {
+	// Name is the builder name without the "Link" suffix, such as "UserSelf".
+	// It is capitalized if necessary so the builder is exported.
+	Name string `json:"name"`
+	// Rel is the link relation of the built link.
+	Rel string `json:"rel"`
+	// Href is an RFC 6570 URI template.
+	Href   string `json:"href"`
+	Title  string `json:"title,omitempty"`
+	Method string `json:"method,omitempty"`
+}
+
+// ErrInvalidRoute is returned by Generate for unusable route specs.
+var ErrInvalidRoute = errors.New("halgen: invalid route")
+
+// Generate returns gofmt-formatted Go source declaring a builder function for
+// each route in package pkg, in the order given.
+func Generate(pkg string, routes []RouteSpec) ([]byte, error) {
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("halgen: invalid package name %q", pkg)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by halgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	buf.WriteString("import hal \"github.com/Emin-ACIKGOZ/go-hal\"\n")
+
+	seen := make(map[string]bool, len(routes))
+	for _, r := range routes {
+		fn, err := generateRoute(&buf, r)
+		if err != nil {
+			return nil, err
+		}
+		if seen[fn] {
+			return nil, fmt.Errorf("%w: duplicate builder %s", ErrInvalidRoute, fn)
+		}
+		seen[fn] = true
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("halgen: formatting generated source: %w", err)
+	}
+	return src, nil
+}
+
+// param is a builder function parameter bound to a template variable.
+type param struct {
+	ident, varName string
+	list           bool
+}
+
+func generateRoute(buf *bytes.Buffer, r RouteSpec) (string, error) {
+	name := exportName(r.Name)
+	if !token.IsIdentifier(name) {
+		return "", fmt.Errorf("%w: bad name %q", ErrInvalidRoute, r.Name)
+	}
+	fn := name + "Link"
+	if r.Rel == "" || r.Href == "" {
+		return "", fmt.Errorf("%w: %s needs a rel and an href", ErrInvalidRoute, fn)
+	}
+
+	hrefExpr, params, templated, err := hrefExpression(r.Href)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %w", ErrInvalidRoute, fn, err)
+	}
+
+	args := make([]string, len(params))
+	for i, p := range params {
+		typ := "string"
+		if p.list {
+			typ = "[]string"
+		}
+		args[i] = p.ident + " " + typ
+	}
+
+	fmt.Fprintf(buf, "\n// %s returns the %q link for %s.\n", fn, r.Rel, r.Href)
+	fmt.Fprintf(buf, "func %s(%s) hal.Link {\n", fn, strings.Join(args, ", "))
+	buf.WriteString("return hal.Link{\n")
+	fmt.Fprintf(buf, "Rel: %s,\n", strconv.Quote(r.Rel))
+	fmt.Fprintf(buf, "Href: %s,\n", hrefExpr)
+	if templated {
+		buf.WriteString("Templated: true,\n")
+	}
+	if r.Title != "" {
+		fmt.Fprintf(buf, "Title: %s,\n", strconv.Quote(r.Title))
+	}
+	if r.Method != "" {
+		fmt.Fprintf(buf, "Method: %s,\n", strconv.Quote(r.Method))
+	}
+	buf.WriteString("}\n}\n")
+	return fn, nil
+}
+
+// hrefExpression returns the Go expression building href. Consecutive
+// literals and path-style expressions are expanded together with
+// hal.MustExpand; query-style expressions are kept verbatim.
+func hrefExpression(href string) (expr string, params []param, templated bool, err error) {
+	var (
+		parts   []string        // Go expressions, concatenated with +
+		pending strings.Builder // template segment expanded at run time
+		baked   bool            // pending contains an expression
+		byVar   = make(map[string]param)
+		idents  = make(map[string]bool)
+	)
+	flush := func(vars []param) {
+		if pending.Len() == 0 {
+			return
+		}
+		if !baked {
+			parts = append(parts, strconv.Quote(pending.String()))
+		} else {
+			entries := make([]string, len(vars))
+			for i, p := range vars {
+				entries[i] = fmt.Sprintf("%s: %s", strconv.Quote(p.varName), p.ident)
+			}
+			parts = append(parts, fmt.Sprintf("hal.MustExpand(%s, map[string]any{%s})",
+				strconv.Quote(pending.String()), strings.Join(entries, ", ")))
+		}
+		pending.Reset()
+		baked = false
+	}
+
+	var segmentVars []param
+	rest := href
+	for rest != "" {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			pending.WriteString(rest)
+			break
+		}
+		pending.WriteString(rest[:open])
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return "", nil, false, fmt.Errorf("%w: unclosed expression in %q", hal.ErrInvalidTemplate, href)
+		}
+		raw := rest[open : open+end+1]
+		rest = rest[open+end+1:]
+
+		op, specs, err := hal.ParseTemplateExpression(raw[1 : len(raw)-1])
+		if err != nil {
+			return "", nil, false, err
+		}
+		if op == '?' || op == '&' {
+			flush(segmentVars)
+			segmentVars = nil
+			parts = append(parts, strconv.Quote(raw))
+			templated = true
+			continue
+		}
+
+		pending.WriteString(raw)
+		baked = true
+		for _, spec := range specs {
+			p, ok := byVar[spec.Name]
+			if !ok {
+				p = param{ident: paramIdent(spec.Name), varName: spec.Name, list: spec.Explode}
+				if idents[p.ident] {
+					return "", nil, false, fmt.Errorf("variables in %q map to the same parameter %s", href, p.ident)
+				}
+				byVar[spec.Name], idents[p.ident] = p, true
+				params = append(params, p)
+			}
+			if !containsParam(segmentVars, spec.Name) {
+				segmentVars = append(segmentVars, p)
+			}
+		}
+	}
+	flush(segmentVars)
+
+	// Catch templates hal.Expand rejects (such as stray braces) at
+	// generation time rather than with a panic in the generated code.
+	if _, err := hal.Expand(href, nil); err != nil {
+		return "", nil, false, err
+	}
+	return strings.Join(mergeLiterals(parts), " + "), params, templated, nil
+}
+
+func containsParam(params []param, varName string) bool {
+	for _, p := range params {
+		if p.varName == varName {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeLiterals joins adjacent quoted string literals.
+func mergeLiterals(parts []string) []string {
+	var out []string
+	for _, p := range parts {
+		if n := len(out); n > 0 && isQuoted(out[n-1]) && isQuoted(p) {
+			a, _ := strconv.Unquote(out[n-1])
+			b, _ := strconv.Unquote(p)
+			out[n-1] = strconv.Quote(a + b)
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func isQuoted(s string) bool {
+	return strings.HasPrefix(s, `"`)
+}
+
+// exportName capitalizes the first letter of name.
+func exportName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	if r == utf8.RuneError {
+		return name
+	}
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+// initialisms are capitalized as a whole in parameter names, as in userID.
+var initialisms = map[string]bool{"id": true, "url": true, "uri": true, "uuid": true}
+
+// paramIdent converts a template variable name such as "user_id" or
+// "page.size" to a camelCase Go identifier that is not a keyword.
+func paramIdent(varName string) string {
+	words := strings.FieldsFunc(varName, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for i, w := range words {
+		switch {
+		case i == 0:
+			b.WriteString(strings.ToLower(w[:1]) + w[1:])
+		case initialisms[strings.ToLower(w)]:
+			b.WriteString(strings.ToUpper(w))
+		default:
+			b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+	}
+	ident := b.String()
+	if ident == "" || unicode.IsDigit(rune(ident[0])) {
+		ident = "v" + ident
+	}
+	if token.IsKeyword(ident) || ident == "hal" {
+		ident += "Param"
+	}
+	return ident
+}
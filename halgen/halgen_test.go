@@ -0,0 +1,141 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package halgen
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+var testRoutes = []RouteSpec{
+	{Name: "UserSelf", Rel: "self", Href: "/users/{id}"},
+	{Name: "ordersSearch", Rel: "search", Href: "/orders{?q,page}", Title: "Search orders"},
+	{Name: "UserOrders", Rel: "orders", Href: "/users/{user_id}/orders{?page}{&limit}"},
+	{Name: "Files", Rel: "files", Href: "https://cdn.example.com{/path*}{?v}", Method: "GET"},
+	{Name: "Collection", Rel: "collection", Href: "/orders"},
+	{Name: "Typed", Rel: "typed", Href: "/types/{type}/{id}/{type}"},
+}
+
+func TestGenerate_Golden(t *testing.T) {
+	got, err := Generate("links", testRoutes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "links.golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("generated source differs from %s (run with -update):\n%s", golden, got)
+	}
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	tests := map[string][]RouteSpec{
+		"bad name":          {{Name: "user-self", Rel: "self", Href: "/users"}},
+		"missing href":      {{Name: "UserSelf", Rel: "self"}},
+		"missing rel":       {{Name: "UserSelf", Href: "/users"}},
+		"duplicate":         {{Name: "A", Rel: "a", Href: "/a"}, {Name: "a", Rel: "a", Href: "/b"}},
+		"unclosed template": {{Name: "A", Rel: "a", Href: "/a/{id"}},
+		"stray brace":       {{Name: "A", Rel: "a", Href: "/a/id}"}},
+		"bad variable":      {{Name: "A", Rel: "a", Href: "/a/{i d}"}},
+		"colliding params":  {{Name: "A", Rel: "a", Href: "/a/{user_id}/{user.id}"}},
+	}
+
+	for name, routes := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Generate("links", routes); !errors.Is(err, ErrInvalidRoute) && !errors.Is(err, hal.ErrInvalidTemplate) {
+				t.Fatalf("expected an invalid route error, got %v", err)
+			}
+		})
+	}
+
+	if _, err := Generate("my-links", nil); err == nil {
+		t.Fatal("expected error for invalid package name")
+	}
+}
+
+const runMain = `package main
+
+import (
+	"encoding/json"
+	"os"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
+)
+
+func main() {
+	links := []hal.Link{
+		UserSelfLink("a/b"),
+		OrdersSearchLink(),
+		UserOrdersLink("42"),
+		FilesLink([]string{"img", "logo 1.png"}),
+		CollectionLink(),
+		TypedLink("book", "7"),
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(links)
+}
+`
+
+// TestGenerate_CompileAndRun builds the generated builders into a program
+// and checks the links they produce.
+func TestGenerate_CompileAndRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go run in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not available")
+	}
+
+	src, err := Generate("main", testRoutes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The program must live inside this module to resolve the hal import.
+	dir, err := os.MkdirTemp("testdata", "run-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	if err := os.WriteFile(filepath.Join(dir, "links_gen.go"), src, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(runMain), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := exec.Command(goBin, "run", "./"+filepath.ToSlash(dir)).CombinedOutput()
+	if err != nil {
+		t.Fatalf("go run failed: %v\n%s", err, out)
+	}
+
+	want := `[{"href":"/users/a%2Fb"},` +
+		`{"href":"/orders{?q,page}","templated":true,"title":"Search orders"},` +
+		`{"href":"/users/42/orders{?page}{&limit}","templated":true},` +
+		`{"href":"https://cdn.example.com/img/logo%201.png{?v}","templated":true,"method":"GET"},` +
+		`{"href":"/orders"},` +
+		`{"href":"/types/book/7/book"}]` + "\n"
+	if string(out) != want {
+		t.Fatalf("expected %s, got %s", want, out)
+	}
+}
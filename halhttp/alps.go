@@ -0,0 +1,32 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package halhttp
+
+import (
+	"net/http"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
+	"github.com/Emin-ACIKGOZ/go-hal/halalps"
+)
+
+// ALPSHandler serves the ALPS profile of inst (see halalps.Generate) with
+// content type application/alps+json. Resources are described from the
+// samples recorded with Instance.RegisterSample. The profile is generated on
+// each request, so later registrations are included.
+//
+// # Example
+//
+//	inst.RegisterSample(&User{ID: 1}, &Order{ID: 1})
+//	http.Handle("/profile", halhttp.ALPSHandler(inst))
+func ALPSHandler(inst *hal.Instance) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		doc, err := halalps.Generate(inst, halalps.Samples(inst))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", halalps.ContentType)
+		_, _ = w.Write(doc)
+	})
+}
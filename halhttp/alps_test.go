@@ -0,0 +1,57 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package halhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
+	"github.com/Emin-ACIKGOZ/go-hal/halalps"
+)
+
+type user struct {
+	ID int `json:"id"`
+}
+
+func TestALPSHandler(t *testing.T) {
+	inst := hal.New()
+	hal.RegisterInstance(inst, func(_ context.Context, _ *user) []hal.Link {
+		return []hal.Link{{Rel: "self", Href: "/users/1"}}
+	})
+	inst.RegisterSample(&user{ID: 1})
+
+	rec := httptest.NewRecorder()
+	ALPSHandler(inst).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/profile", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/alps+json" {
+		t.Fatalf("expected ALPS content type, got %q", ct)
+	}
+	var doc halalps.Document
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.ALPS.Descriptors) != 2 {
+		t.Fatalf("expected resource and self descriptors, got %+v", doc.ALPS.Descriptors)
+	}
+}
+
+func TestALPSHandler_Error(t *testing.T) {
+	inst := hal.New()
+	hal.RegisterInstance(inst, func(context.Context, *user) []hal.Link { panic("boom") })
+	inst.RegisterSample(&user{ID: 1})
+
+	rec := httptest.NewRecorder()
+	ALPSHandler(inst).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/profile", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package halhttp
+
+import (
+	"context"
+	"net/http"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
+)
+
+// WithRequestCache returns a copy of ctx that memoizes generator results for
+// the lifetime of the request (see hal.NewCacheContext). When Wrap sees a
+// resource already wrapped with this context, it reuses the links computed
+// the first time instead of running the generator again.
+//
+// Only use it where generators are pure for the duration of a request. The
+// cache is dropped with ctx; there is no expiry.
+//
+// # Example
+//
+//	ctx := halhttp.WithRequestCache(r.Context())
+//	page := inst.Collection(ctx, projects, total, self) // each shared Team is generated once
+func WithRequestCache(ctx context.Context) context.Context {
+	return hal.NewCacheContext(ctx)
+}
+
+// RequestCache is middleware that installs a request cache, as
+// WithRequestCache does, on the context of every request passed to next.
+//
+// # Example
+//
+//	http.Handle("/projects", halhttp.RequestCache(projectsHandler))
+func RequestCache(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithRequestCache(r.Context())))
+	})
+}
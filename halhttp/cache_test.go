@@ -0,0 +1,44 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package halhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
+)
+
+type team struct {
+	ID int `json:"id"`
+}
+
+func TestRequestCache(t *testing.T) {
+	calls := 0
+	inst := hal.New()
+	hal.RegisterInstance(inst, func(_ context.Context, _ *team) []hal.Link {
+		calls++
+		return []hal.Link{{Rel: "self", Href: "/teams/7"}}
+	})
+
+	shared := &team{ID: 7}
+	handler := RequestCache(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		env := inst.Wrap(r.Context(), &user{ID: 1})
+		for range 10 {
+			env.AddEmbedded(r.Context(), "team", shared)
+		}
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	if calls != 1 {
+		t.Fatalf("expected 1 generator call within a request, got %d", calls)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	if calls != 2 {
+		t.Fatalf("expected a fresh cache per request, got %d generator calls", calls)
+	}
+}
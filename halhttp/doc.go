@@ -0,0 +1,5 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+// Package halhttp provides net/http handlers built on the hal package.
+package halhttp
@@ -0,0 +1,11 @@
+module github.com/Emin-ACIKGOZ/go-hal/halproto
+
+go 1.22.5
+
+require (
+	github.com/Emin-ACIKGOZ/go-hal v1.0.0
+	github.com/goccy/go-json v0.10.6
+	google.golang.org/protobuf v1.36.5
+)
+
+replace github.com/Emin-ACIKGOZ/go-hal => ../
@@ -0,0 +1,43 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+// Package halproto serializes protobuf messages wrapped in HAL envelopes with
+// protojson, so responses match grpc-gateway output (lowerCamelCase field
+// names, 64-bit integers as strings, well-known type mappings).
+//
+// It lives in its own module to keep the protobuf dependency out of go-hal.
+//
+//	inst := hal.New(halproto.WithProtoJSON(protojson.MarshalOptions{}))
+//	env := inst.Wrap(ctx, msg) // msg is a proto.Message
+package halproto
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
+	json "github.com/goccy/go-json"
+)
+
+// MarshalFunc returns a hal.MarshalFunc that encodes proto.Message values
+// with opts and falls back to the default JSON encoder for everything else.
+func MarshalFunc(opts protojson.MarshalOptions) hal.MarshalFunc {
+	return func(v any) ([]byte, error) {
+		if m, ok := v.(proto.Message); ok {
+			return opts.Marshal(m)
+		}
+		return json.Marshal(v)
+	}
+}
+
+// WithProtoJSON configures an Instance to serialize protobuf Data with opts.
+// Set opts.UseProtoNames to keep snake_case field names.
+//
+// # Example
+//
+//	inst := hal.New(halproto.WithProtoJSON(protojson.MarshalOptions{
+//	    EmitUnpopulated: true,
+//	}))
+func WithProtoJSON(opts protojson.MarshalOptions) hal.InstanceOption {
+	return hal.WithMarshalFunc(MarshalFunc(opts))
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package halproto
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
+)
+
+// option uses snake_case proto fields and 64-bit integers, both of which
+// encoding/json renders differently from protojson.
+func option() *descriptorpb.UninterpretedOption {
+	return &descriptorpb.UninterpretedOption{
+		IdentifierValue:  proto.String("retries"),
+		PositiveIntValue: proto.Uint64(1 << 60),
+	}
+}
+
+// decode unmarshals a JSON object; protojson output is deliberately unstable
+// in its whitespace, so documents are compared structurally.
+func decode(t *testing.T, b []byte) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+	return m
+}
+
+func TestWithProtoJSON_MatchesProtojson(t *testing.T) {
+	tests := []struct {
+		name string
+		opts protojson.MarshalOptions
+	}{
+		{"camel case", protojson.MarshalOptions{}},
+		{"proto names", protojson.MarshalOptions{UseProtoNames: true}},
+		{"emit unpopulated", protojson.MarshalOptions{EmitUnpopulated: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := option()
+			env := hal.New(WithProtoJSON(tt.opts)).Wrap(context.Background(), msg)
+			env.AddLink(hal.SelfLink("/options/retries"))
+
+			b, err := json.Marshal(env)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := decode(t, b)
+
+			ref, err := tt.opts.Marshal(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := decode(t, ref)
+			want["_links"] = map[string]any{"self": map[string]any{"href": "/options/retries"}}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestWithProtoJSON_GatewayFieldNames(t *testing.T) {
+	b, err := json.Marshal(hal.New(WithProtoJSON(protojson.MarshalOptions{})).Wrap(context.Background(), option()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := decode(t, b)
+
+	if got["identifierValue"] != "retries" {
+		t.Fatalf("expected lowerCamelCase field, got %v", got)
+	}
+	if got["positiveIntValue"] != "1152921504606846976" {
+		t.Fatalf("expected uint64 encoded as string, got %v", got)
+	}
+}
+
+func TestMarshalFunc_FallsBackForNonProto(t *testing.T) {
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	b, err := MarshalFunc(protojson.MarshalOptions{})(&user{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"id":1}` {
+		t.Fatalf("expected default encoding, got %s", b)
+	}
+}
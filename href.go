@@ -0,0 +1,60 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Href joins base and path segments into an href. Each segment is formatted
+// with fmt.Sprint and escaped with url.PathEscape, so IDs containing "/",
+// spaces or "%" stay a single segment. Segments made only of dots, such as
+// "..", are escaped as "%2E%2E" so they cannot move the href up. The base, including any scheme, host
+// and query, is used as is and never re-escaped; duplicate slashes at the
+// joins are removed.
+//
+// # Example
+//
+//	hal.Href("https://api.example.com/v1/", "users", u.ID) // https://api.example.com/v1/users/42
+//	hal.Href("/files", "a/b c")                            // /files/a%2Fb%20c
+func Href(base string, segments ...any) string {
+	escaped := make([]string, len(segments))
+	for i, s := range segments {
+		escaped[i] = escapeSegment(fmt.Sprint(s))
+	}
+	joined, err := url.JoinPath(base, escaped...)
+	if err != nil {
+		// base is not a parseable URL; fall back to plain joining.
+		return strings.TrimRight(base, "/") + "/" + strings.Join(escaped, "/")
+	}
+	return joined
+}
+
+// escapeSegment escapes s as a single path segment.
+func escapeSegment(s string) string {
+	if s != "" && strings.Trim(s, ".") == "" {
+		return strings.Repeat("%2E", len(s))
+	}
+	return url.PathEscape(s)
+}
+
+// HrefQ is like Href and appends the encoded query. Parameters are added to
+// any query already present in base.
+//
+// # Example
+//
+//	hal.HrefQ("/users", url.Values{"page": {"2"}}) // /users?page=2
+func HrefQ(base string, query url.Values, segments ...any) string {
+	href := Href(base, segments...)
+	if len(query) == 0 {
+		return href
+	}
+	sep := "?"
+	if strings.Contains(href, "?") {
+		sep = "&"
+	}
+	return href + sep + query.Encode()
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestHref(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		segments []any
+		want     string
+	}{
+		{"relative", "/users", []any{42}, "/users/42"},
+		{"trailing slash base", "/users/", []any{42}, "/users/42"},
+		{"absolute base", "https://api.example.com/v1/", []any{"users", 42}, "https://api.example.com/v1/users/42"},
+		{"host only", "https://api.example.com", []any{"users"}, "https://api.example.com/users"},
+		{"slash in id", "/files", []any{"a/b"}, "/files/a%2Fb"},
+		{"space in id", "/files", []any{"a b"}, "/files/a%20b"},
+		{"percent in id", "/files", []any{"100%"}, "/files/100%25"},
+		{"unicode id", "/users", []any{"ömer"}, "/users/%C3%B6mer"},
+		{"escaped base untouched", "/files/a%2Fb", []any{"c"}, "/files/a%2Fb/c"},
+		{"base query kept", "https://api.example.com/v1?k=1", []any{"users"}, "https://api.example.com/v1/users?k=1"},
+		{"no segments", "https://api.example.com/v1", nil, "https://api.example.com/v1"},
+		{"dot segment", "/users", []any{"."}, "/users/%2E"},
+		{"dot-dot segment", "/users", []any{".."}, "/users/%2E%2E"},
+		{"dot-dot before segment", "https://api.example.com/v1/users", []any{"..", "admin"}, "https://api.example.com/v1/users/%2E%2E/admin"},
+		{"dot-dot in id", "/files", []any{"../etc"}, "/files/..%2Fetc"},
+		{"dots in id", "/files", []any{"a..b"}, "/files/a..b"},
+		{"dot-dot fallback", "http://[::1/api/", []any{".."}, "http://[::1/api/%2E%2E"},
+		{"stringer segment", "/rels", []any{Rel("edit")}, "/rels/edit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Href(tt.base, tt.segments...); got != tt.want {
+				t.Fatalf("Href(%q, %v) = %q, want %q", tt.base, tt.segments, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHref_InvalidBaseFallsBack(t *testing.T) {
+	if got := Href("http://[::1/api/", "a b"); got != "http://[::1/api/a%20b" {
+		t.Fatalf("unexpected fallback href %q", got)
+	}
+}
+
+func TestHrefQ(t *testing.T) {
+	tests := []struct {
+		name  string
+		base  string
+		query url.Values
+		segs  []any
+		want  string
+	}{
+		{"query", "/users", url.Values{"page": {"2"}, "q": {"a&b c"}}, nil, "/users?page=2&q=a%26b+c"},
+		{"segments and query", "https://api.example.com", url.Values{"page": {"2"}}, []any{"users"}, "https://api.example.com/users?page=2"},
+		{"base query merged", "/users?sort=name", url.Values{"page": {"2"}}, nil, "/users?sort=name&page=2"},
+		{"empty query", "/users", nil, []any{1}, "/users/1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HrefQ(tt.base, tt.query, tt.segs...); got != tt.want {
+				t.Fatalf("HrefQ = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
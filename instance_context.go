@@ -0,0 +1,62 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import "context"
+
+type instanceKey struct{}
+
+// NewContext returns a copy of ctx carrying inst. The package-level Wrap,
+// WrapE and Collection functions use the instance found in their context in
+// place of DefaultInstance, so middleware can select a per-tenant registry
+// without threading it through every handler.
+//
+// Precedence, from highest:
+//
+//  1. Methods called on an Instance, such as inst.Wrap, always use that
+//     instance; the context is not consulted.
+//  2. The package-level functions use the instance stored with NewContext.
+//  3. Otherwise they use DefaultInstance.
+//
+// A nil inst leaves ctx unchanged. Registration functions such as Register
+// and RegisterCurie always target DefaultInstance.
+//
+// # Example
+//
+//	func tenantMiddleware(next http.Handler) http.Handler {
+//	    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	        inst := tenants[r.Header.Get("X-Tenant")]
+//	        next.ServeHTTP(w, r.WithContext(hal.NewContext(r.Context(), inst)))
+//	    })
+//	}
+func NewContext(ctx context.Context, inst *Instance) context.Context {
+	if inst == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, instanceKey{}, inst)
+}
+
+// FromContext returns the instance stored in ctx by NewContext, if any.
+func FromContext(ctx context.Context) (*Instance, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	inst, ok := ctx.Value(instanceKey{}).(*Instance)
+	return inst, ok
+}
+
+// instanceFor returns the instance the package-level functions use for ctx.
+func instanceFor(ctx context.Context) *Instance {
+	if inst, ok := FromContext(ctx); ok {
+		return inst
+	}
+	return DefaultInstance
+}
+
+// Instance returns the instance that built the envelope, or nil for an
+// envelope constructed directly. Nested resources of the envelope were
+// wrapped with the same instance.
+func (e *Envelope) Instance() *Instance {
+	return e.instance
+}
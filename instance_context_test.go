@@ -0,0 +1,66 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type tenantDoc struct {
+	ID int `json:"id"`
+}
+
+func TestNewContext_PackageFunctionsUseContextInstance(t *testing.T) {
+	tenant := New()
+	RegisterInstance(tenant, func(_ context.Context, d *tenantDoc) []Link {
+		return []Link{{Rel: "self", Href: "/tenant-a/docs/" + itoa(d.ID)}}
+	})
+	ctx := NewContext(context.Background(), tenant)
+
+	env := Wrap(ctx, &tenantDoc{ID: 1})
+	if env.Instance() != tenant {
+		t.Fatal("expected envelope to record the context instance")
+	}
+	if got := marshalString(t, env); !strings.Contains(got, "/tenant-a/docs/1") {
+		t.Fatalf("expected tenant links, got %s", got)
+	}
+	if _, err := WrapE(ctx, &tenantDoc{ID: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	page := Collection(ctx, []*tenantDoc{{ID: 1}, {ID: 2}}, 2, SelfLink("/tenant-a/docs"))
+	for _, item := range page.Embedded["items"].([]*Envelope) {
+		if item.Instance() != tenant {
+			t.Fatal("expected collection items to use the context instance")
+		}
+	}
+	if got := marshalString(t, page); !strings.Contains(got, "/tenant-a/docs/2") {
+		t.Fatalf("expected tenant item links, got %s", got)
+	}
+
+	if _, ok := DefaultInstance.lookupGenerator(reflect.TypeOf(&tenantDoc{})); ok {
+		t.Fatal("expected DefaultInstance to be untouched")
+	}
+	if env := Wrap(context.Background(), &tenantDoc{ID: 1}); env.Instance() != DefaultInstance || env.HasLink("self") {
+		t.Fatal("expected DefaultInstance without a context instance")
+	}
+}
+
+func TestNewContext_MethodsIgnoreContext(t *testing.T) {
+	tenant := New()
+	ctx := NewContext(context.Background(), tenant)
+	other := New()
+	if env := other.Wrap(ctx, &tenantDoc{}); env.Instance() != other {
+		t.Fatal("expected Instance.Wrap to use its receiver")
+	}
+	if NewContext(ctx, nil) != ctx {
+		t.Fatal("expected a nil instance to leave ctx unchanged")
+	}
+	if inst, ok := FromContext(ctx); !ok || inst != tenant {
+		t.Fatal("expected FromContext to return the stored instance")
+	}
+}
@@ -5,7 +5,10 @@ package hal
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -36,3 +39,81 @@ func TestRegisteredTypes(t *testing.T) {
 		t.Fatalf("expected 2 types, got %d", len(types))
 	}
 }
+
+func TestUnregister(t *testing.T) {
+	type A struct{}
+	type B struct{}
+
+	inst := New()
+	RegisterInstance(inst, func(context.Context, *A) []Link { return []Link{{Rel: "self", Href: "/a"}} })
+	RegisterInstance(inst, func(context.Context, *B) []Link { return []Link{{Rel: "self", Href: "/b"}} })
+	RegisterStatic(inst, &TestData{}, []Link{{Rel: "self", Href: "/data"}})
+
+	UnregisterInstance[A](inst)
+	inst.Unregister(reflect.TypeOf(&TestData{}))
+
+	if inst.Wrap(context.Background(), &A{}).HasLink("self") {
+		t.Fatal("expected A's generator to be removed")
+	}
+	if inst.Wrap(context.Background(), &TestData{}).HasLink("self") {
+		t.Fatal("expected static links to be removed")
+	}
+	if !inst.Wrap(context.Background(), &B{}).HasLink("self") {
+		t.Fatal("expected B's generator to be kept")
+	}
+	UnregisterInstance[A](inst) // no-op
+}
+
+func TestReset(t *testing.T) {
+	type A struct{}
+
+	inst := New(WithStrictMode())
+	RegisterInstance(inst, func(context.Context, *A) []Link { return []Link{{Rel: "acme:x", Href: "/a"}} })
+	inst.RegisterCurie("acme", "https://docs.example.com/{rel}")
+	inst.RegisterSample(&A{})
+
+	inst.Reset()
+
+	if len(inst.RegisteredTypes()) != 0 || len(inst.Curies()) != 0 || len(inst.Samples()) != 0 {
+		t.Fatalf("expected empty registry, got %v, %v, %v", inst.RegisteredTypes(), inst.Curies(), inst.Samples())
+	}
+	if _, err := inst.WrapE(context.Background(), &A{}); !errors.Is(err, ErrNoGenerator) {
+		t.Fatalf("expected strict mode to survive Reset, got %v", err)
+	}
+
+	RegisterInstance(inst, func(context.Context, *A) []Link { return []Link{{Rel: "self", Href: "/a"}} })
+	if !inst.Wrap(context.Background(), &A{}).HasLink("self") {
+		t.Fatal("expected registration after Reset to work")
+	}
+}
+
+func TestReset_ConcurrentWithWrap(t *testing.T) {
+	inst := New()
+	register := func() {
+		RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+			return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+		})
+		inst.RegisterCurie("acme", "https://docs.example.com/{rel}")
+	}
+	register()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 200; n++ {
+				if _, err := json.Marshal(inst.Wrap(context.Background(), &collectionUser{ID: n})); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	for n := 0; n < 50; n++ {
+		inst.Reset()
+		UnregisterInstance[collectionUser](inst)
+		register()
+	}
+	wg.Wait()
+}
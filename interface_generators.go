@@ -0,0 +1,104 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// interfaceGenerator is a generator registered for an interface type, used
+// for concrete types without a generator of their own.
+type interfaceGenerator struct {
+	iface reflect.Type
+	gen   Generator
+}
+
+// interfaceMatch is the cached result of matching a concrete type against
+// the registered interface generators.
+type interfaceMatch struct {
+	gen Generator
+	err error
+}
+
+// registerInterface adds gen for the interface type iface. An earlier
+// registration for the same interface is handled as by replaceGenerator and
+// keeps its position.
+func (i *Instance) registerInterface(iface reflect.Type, gen Generator, chain bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	// The slice is copied, as lookups may hold the previous one.
+	ifaces := slices.Clone(i.interfaces)
+	idx := slices.IndexFunc(ifaces, func(ig interfaceGenerator) bool { return ig.iface == iface })
+	if idx < 0 {
+		ifaces = append(ifaces, interfaceGenerator{iface: iface, gen: gen})
+	} else {
+		ifaces[idx].gen = i.replaceGenerator(reflect.PointerTo(iface), ifaces[idx].gen, gen, chain)
+	}
+	i.interfaces = ifaces
+	i.interfaceMatches = new(sync.Map)
+	i.registryChanged()
+}
+
+// removeInterface drops the generator for iface, reporting whether there was
+// one. The caller must hold i.mu for writing.
+func (i *Instance) removeInterface(iface reflect.Type) bool {
+	for idx, ig := range i.interfaces {
+		if ig.iface == iface {
+			i.interfaces = append(i.interfaces[:idx:idx], i.interfaces[idx+1:]...)
+			i.interfaceMatches = new(sync.Map)
+			i.registryChanged()
+			return true
+		}
+	}
+	return false
+}
+
+// resolveGenerator returns the generator for t: the one registered for t
+// itself, else one registered for an interface t implements. When several
+// interfaces match, the earliest registered wins; strict instances report
+// an error wrapping ErrAmbiguousGenerator instead. Interface matches are
+// cached per concrete type.
+func (i *Instance) resolveGenerator(t reflect.Type) (Generator, bool, error) {
+	var gen Generator
+	var ok bool
+	var ifaces []interfaceGenerator
+	var matches *sync.Map
+	if f := i.frozen.Load(); f != nil {
+		gen, ok = f.generators[t]
+		ifaces, matches = f.interfaces, f.interfaceMatches
+	} else {
+		i.mu.RLock()
+		gen, ok = i.generators[t]
+		ifaces, matches = i.interfaces, i.interfaceMatches
+		i.mu.RUnlock()
+	}
+	if ok || len(ifaces) == 0 || t == nil {
+		return gen, ok, nil
+	}
+
+	if cached, hit := matches.Load(t); hit {
+		m := cached.(interfaceMatch)
+		return m.gen, m.gen != nil, m.err
+	}
+	var m interfaceMatch
+	var first reflect.Type
+	for _, ig := range ifaces {
+		if !t.Implements(ig.iface) {
+			continue
+		}
+		if first == nil {
+			first, m.gen = ig.iface, ig.gen
+			continue
+		}
+		if i.strictMode {
+			m = interfaceMatch{err: fmt.Errorf("%w: %v implements both %v and %v", ErrAmbiguousGenerator, t, first, ig.iface)}
+		}
+		break
+	}
+	matches.Store(t, m)
+	return m.gen, m.gen != nil, m.err
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type identifiable interface{ ResourcePath() string }
+
+type archivable interface{ ArchivePath() string }
+
+type ifaceDoc struct {
+	ID int `json:"id"`
+}
+
+func (d *ifaceDoc) ResourcePath() string { return "/docs/" + itoa(d.ID) }
+func (d *ifaceDoc) ArchivePath() string  { return "/archive/docs/" + itoa(d.ID) }
+
+type ifaceNote struct {
+	ID int `json:"id"`
+}
+
+func (n *ifaceNote) ResourcePath() string { return "/notes/" + itoa(n.ID) }
+
+func identifiableLinks(_ context.Context, v *identifiable) []Link {
+	return []Link{{Rel: "self", Href: (*v).ResourcePath()}}
+}
+
+func archivableLinks(_ context.Context, v *archivable) []Link {
+	return []Link{{Rel: "self", Href: (*v).ArchivePath()}}
+}
+
+func TestInterfaceGenerator_Fallback(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, identifiableLinks)
+
+	for _, tt := range []struct {
+		data any
+		want string
+	}{
+		{&ifaceDoc{ID: 1}, `{"id":1,"_links":{"self":{"href":"/docs/1"}}}`},
+		{&ifaceNote{ID: 2}, `{"id":2,"_links":{"self":{"href":"/notes/2"}}}`},
+		{&ifaceNote{ID: 3}, `{"id":3,"_links":{"self":{"href":"/notes/3"}}}`}, // cached
+	} {
+		if got := marshalString(t, inst.Wrap(context.Background(), tt.data)); got != tt.want {
+			t.Errorf("expected %s, got %s", tt.want, got)
+		}
+	}
+}
+
+func TestInterfaceGenerator_ExactMatchWins(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, identifiableLinks)
+	RegisterInstance(inst, func(_ context.Context, d *ifaceDoc) []Link {
+		return []Link{{Rel: "self", Href: "/exact/" + itoa(d.ID)}}
+	})
+
+	if got := marshalString(t, inst.Wrap(context.Background(), &ifaceDoc{ID: 1})); got != `{"id":1,"_links":{"self":{"href":"/exact/1"}}}` {
+		t.Fatalf("expected the exact generator, got %s", got)
+	}
+}
+
+func TestInterfaceGenerator_Ambiguous(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, identifiableLinks)
+	RegisterInstance(inst, archivableLinks)
+	if got := marshalString(t, inst.Wrap(context.Background(), &ifaceDoc{ID: 1})); got != `{"id":1,"_links":{"self":{"href":"/docs/1"}}}` {
+		t.Fatalf("expected the first registered interface to win, got %s", got)
+	}
+
+	strict := New(WithStrictMode())
+	RegisterInstance(strict, identifiableLinks)
+	RegisterInstance(strict, archivableLinks)
+	if _, err := strict.WrapE(context.Background(), &ifaceDoc{ID: 1}); !errors.Is(err, ErrAmbiguousGenerator) {
+		t.Fatalf("expected ErrAmbiguousGenerator, got %v", err)
+	}
+	if _, err := strict.WrapE(context.Background(), &ifaceNote{ID: 1}); err != nil {
+		t.Fatalf("expected a single match to succeed, got %v", err)
+	}
+}
+
+func TestInterfaceGenerator_RegistrationInvalidatesCache(t *testing.T) {
+	inst := New()
+	if inst.Wrap(context.Background(), &ifaceDoc{ID: 1}).HasLink("self") {
+		t.Fatal("expected no links before registration")
+	}
+	RegisterInstance(inst, archivableLinks)
+	if !inst.Wrap(context.Background(), &ifaceDoc{ID: 1}).HasLink("self") {
+		t.Fatal("expected a cached miss to be invalidated")
+	}
+	UnregisterInstance[archivable](inst)
+	if inst.Wrap(context.Background(), &ifaceDoc{ID: 1}).HasLink("self") {
+		t.Fatal("expected the interface generator to be removed")
+	}
+}
+
+func BenchmarkLookupGenerator_Exact(b *testing.B) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, d *ifaceDoc) []Link { return nil })
+	benchmarkLookup(b, inst)
+}
+
+func BenchmarkLookupGenerator_InterfaceCached(b *testing.B) {
+	inst := New()
+	RegisterInstance(inst, identifiableLinks)
+	RegisterInstance(inst, archivableLinks)
+	benchmarkLookup(b, inst)
+}
+
+func benchmarkLookup(b *testing.B, inst *Instance) {
+	t := inst.linkType(&ifaceDoc{})
+	if _, ok := inst.lookupGenerator(t); !ok {
+		b.Fatal("no generator")
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		inst.lookupGenerator(t)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshal_RawMessageWithInsignificantBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"BOM prefixed", "\xef\xbb\xbf{\"id\":1}"},
+		{"space prefixed", "  \t{\"id\":1}"},
+		{"newline suffixed", "{\"id\":1}\n"},
+		{"BOM and whitespace", "\xef\xbb\xbf\r\n {\"id\":1} \r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := New().Wrap(context.Background(), json.RawMessage(tt.raw))
+			env.AddLink(Link{Rel: "self", Href: "/users/1"})
+
+			b, err := json.Marshal(env)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := `{"id":1,"_links":{"self":{"href":"/users/1"}}}`
+			if string(b) != want {
+				t.Fatalf("expected %s, got %s", want, b)
+			}
+		})
+	}
+}
+
+func TestMarshal_RawMessagePointer(t *testing.T) {
+	raw := json.RawMessage(" {\"id\":1}\n")
+
+	b, err := json.Marshal(New().Wrap(context.Background(), &raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != wantUserID1 {
+		t.Fatalf("expected %s, got %s", wantUserID1, b)
+	}
+}
+
+func TestMarshal_RawMessageNonObjectFails(t *testing.T) {
+	env := New().Wrap(context.Background(), json.RawMessage("\xef\xbb\xbf [1,2]"))
+
+	if _, err := json.Marshal(env); err == nil {
+		t.Fatal("expected error for non-object raw data")
+	}
+}
+
+func TestWrapPrecomputed_RawMessageWithBOM(t *testing.T) {
+	linksJSON := []byte(`{"_links":{"self":{"href":"/users/1"}}}`)
+	env := New().WrapPrecomputed(context.Background(), json.RawMessage("\xef\xbb\xbf{\"id\":1}\n"), linksJSON)
+
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"id":1,"_links":{"self":{"href":"/users/1"}}}`
+	if string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+}
+
+func TestTrimJSON(t *testing.T) {
+	tests := map[string]string{
+		"":                       "",
+		"   ":                    "",
+		"\xef\xbb\xbf":           "",
+		"\xef\xbb\xbf {}\n":      "{}",
+		"\t{\"a\":\" x \"}\r\n":  "{\"a\":\" x \"}",
+		"{\"a\":1}":              "{\"a\":1}",
+		" \xef\xbb\xbf{\"a\":1}": "\xef\xbb\xbf{\"a\":1}", // BOM only honored at the start
+	}
+
+	for in, want := range tests {
+		if got := string(trimJSON([]byte(in))); got != want {
+			t.Errorf("trimJSON(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCheckJSONStructure(t *testing.T) {
+	tests := []struct {
+		in            string
+		isNull, empty bool
+		err           bool
+	}{
+		{"", true, false, false},
+		{"null", true, false, false},
+		{"{}", false, true, false},
+		{"{ }", false, true, false},
+		{"{\n\t\r }", false, true, false},
+		{`{"a":1}`, false, false, false},
+		{"{ \"a\" : 1 \n}", false, false, false},
+		{"{", false, false, true},
+		{"}", false, false, true},
+		{`{"a":1`, false, false, true},
+		{"[]", false, false, true},
+		{`"{}"`, false, false, true},
+		{"nullx", false, false, true},
+		{"0", false, false, true},
+	}
+	for _, tt := range tests {
+		isNull, empty, err := checkJSONStructure([]byte(tt.in))
+		if isNull != tt.isNull || empty != tt.empty || (err != nil) != tt.err {
+			t.Errorf("checkJSONStructure(%q) = %v, %v, %v", tt.in, isNull, empty, err)
+		}
+	}
+}
+
+func TestMarshal_CustomMarshalerOutput(t *testing.T) {
+	tests := []struct {
+		name, out, want string
+	}{
+		{"padded object", "\n  {\"id\":1}\n", `{"id":1,"_links":{"self":{"href":"/x"}}}`},
+		{"pretty printed", "{\n  \"id\": 1\n}\n", "{\n  \"id\": 1\n,\"_links\":{\"self\":{\"href\":\"/x\"}}}"},
+		{"empty with whitespace", "{ \n }", `{"_links":{"self":{"href":"/x"}}}`},
+		{"null", "null", `{"_links":{"self":{"href":"/x"}}}`},
+		{"padded null", " null\n", `{"_links":{"self":{"href":"/x"}}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inst := New(WithMarshalFunc(func(any) ([]byte, error) { return []byte(tt.out), nil }))
+			env := inst.WrapRaw(&collectionUser{ID: 1})
+			env.AddLink(Link{Rel: "self", Href: "/x"})
+			pre := inst.WrapPrecomputed(context.Background(), &collectionUser{ID: 1}, []byte(`{"_links":{"self":{"href":"/x"}}}`))
+
+			for _, e := range []*Envelope{env, pre} {
+				got, err := e.MarshalJSON()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(got) != tt.want || !json.Valid(got) {
+					t.Fatalf("expected %s, got %s", tt.want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"unicode/utf8"
+
+	json "github.com/goccy/go-json"
+)
+
+// linkProperties are the serialized names of Link's standard fields, which
+// extensions may not use.
+var linkProperties = map[string]struct{}{
+	"href": {}, "templated": {}, "type": {}, "deprecation": {}, "name": {},
+	"profile": {}, "title": {}, "hreflang": {}, "method": {},
+}
+
+// linkFields has Link's fields without its methods, for default decoding.
+type linkFields Link
+
+// MarshalJSON implements json.Marshaler. Extensions are inlined after the
+// standard properties in sorted key order. A key colliding with a standard
+// property fails with ErrExtensionConflict.
+//
+// # Example
+//
+//	hal.Link{Rel: "edit", Href: "/orders/1", Extensions: map[string]any{"permissions": []string{"write"}}}
+//	// {"href":"/orders/1","permissions":["write"]}
+func (l Link) MarshalJSON() ([]byte, error) {
+	// HTML escaping is left to the calling encoder.
+	return appendLink(make([]byte, 0, estimateLinkSize(l)), l, false)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Properties other than the
+// standard ones are collected into Extensions. Rel is not part of the link
+// object and is left unchanged.
+func (l *Link) UnmarshalJSON(b []byte) error {
+	rel := l.Rel
+	var fields linkFields
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(b, &members); err != nil {
+		return err
+	}
+	for k, raw := range members {
+		if _, ok := linkProperties[k]; ok {
+			continue
+		}
+		var v any
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return err
+		}
+		if fields.Extensions == nil {
+			fields.Extensions = make(map[string]any, len(members))
+		}
+		fields.Extensions[k] = v
+	}
+	*l = Link(fields)
+	l.Rel = rel
+	return nil
+}
+
+// appendLinks appends the JSON encoding of a _links map to dst, writing Link
+// values directly rather than through the generic encoder. When ordered is
+// set, rels are written in compareRels order; otherwise in lexicographic order.
+func appendLinks(dst []byte, links map[string]any, ordered bool) ([]byte, error) {
+	rels := make([]string, 0, len(links))
+	for rel := range links {
+		rels = append(rels, rel)
+	}
+	if ordered {
+		slices.SortFunc(rels, compareRels)
+	} else {
+		slices.Sort(rels)
+	}
+
+	var err error
+	dst = append(dst, '{')
+	for idx, rel := range rels {
+		if idx > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendJSONString(dst, rel, true)
+		dst = append(dst, ':')
+		if dst, err = appendLinkValue(dst, links[rel]); err != nil {
+			return nil, err
+		}
+	}
+	return append(dst, '}'), nil
+}
+
+// appendLinkValue appends one _links member value: a Link, a slice of them,
+// or any other value stored with the links, such as a json.RawMessage.
+func appendLinkValue(dst []byte, v any) ([]byte, error) {
+	var err error
+	switch v := v.(type) {
+	case Link:
+		return appendLink(dst, v, true)
+	case []Link:
+		dst = append(dst, '[')
+		for idx, l := range v {
+			if idx > 0 {
+				dst = append(dst, ',')
+			}
+			if dst, err = appendLink(dst, l, true); err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, ']'), nil
+	case []any:
+		dst = append(dst, '[')
+		for idx, item := range v {
+			if idx > 0 {
+				dst = append(dst, ',')
+			}
+			if dst, err = appendLinkValue(dst, item); err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, ']'), nil
+	}
+	return appendJSON(dst, v)
+}
+
+// appendLink appends the link object for l to dst, with properties in Link
+// field order followed by the sorted extensions.
+func appendLink(dst []byte, l Link, escapeHTML bool) ([]byte, error) {
+	dst = append(dst, `{"href":`...)
+	dst = appendJSONString(dst, l.Href, escapeHTML)
+	if l.Templated {
+		dst = append(dst, `,"templated":true`...)
+	}
+	for _, f := range [...]struct {
+		member, value string
+	}{
+		{`,"type":`, l.Type},
+		{`,"deprecation":`, l.Deprecation},
+		{`,"name":`, l.Name},
+		{`,"profile":`, l.Profile},
+		{`,"title":`, l.Title},
+		{`,"hreflang":`, l.HrefLang},
+		{`,"method":`, l.Method},
+	} {
+		if f.value != "" {
+			dst = append(dst, f.member...)
+			dst = appendJSONString(dst, f.value, escapeHTML)
+		}
+	}
+	if len(l.Extensions) == 0 {
+		return append(dst, '}'), nil
+	}
+
+	keys := make([]string, 0, len(l.Extensions))
+	for k := range l.Extensions {
+		if _, ok := linkProperties[k]; ok {
+			return nil, fmt.Errorf("%w: %q in %q link", ErrExtensionConflict, k, l.Rel)
+		}
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		dst = append(dst, ',')
+		dst = appendJSONString(dst, k, escapeHTML)
+		dst = append(dst, ':')
+		var err error
+		if escapeHTML {
+			dst, err = appendJSON(dst, l.Extensions[k])
+		} else {
+			var val []byte
+			val, err = json.MarshalWithOption(l.Extensions[k], json.DisableHTMLEscape())
+			dst = append(dst, val...)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("hal: marshaling link extension %q: %w", k, err)
+		}
+	}
+	return append(dst, '}'), nil
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends s to dst as a JSON string, escaping it the way the
+// JSON encoder does: invalid UTF-8 becomes U+FFFD, U+2028 and U+2029 are
+// escaped, and so are <, > and & when escapeHTML is set.
+func appendJSONString(dst []byte, s string, escapeHTML bool) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && (!escapeHTML || (c != '<' && c != '>' && c != '&')) {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				dst = append(dst, '\\', c)
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, `\ufffd`...)
+		case r == '\u2028' || r == '\u2029':
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[r&0xf])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
+
+// extensionsEqual compares extension maps deeply, treating nil as empty.
+func extensionsEqual(a, b map[string]any) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, va := range a {
+		vb, ok := b[k]
+		if !ok || !reflect.DeepEqual(va, vb) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	goccy "github.com/goccy/go-json"
+)
+
+func TestLinkExtensions_Inlined(t *testing.T) {
+	e := New().Wrap(context.Background(), map[string]any{})
+	e.AddLink(Link{Rel: "edit", Href: "/orders/1", Title: "Edit", Extensions: map[string]any{
+		"rateLimit":   10,
+		"permissions": []string{"read", "write"},
+	}})
+	e.AddLink(Link{Rel: "self", Href: "/orders/1"})
+
+	want := `{"_links":{"edit":{"href":"/orders/1","title":"Edit","permissions":["read","write"],"rateLimit":10},"self":{"href":"/orders/1"}}}`
+	if got := marshalString(t, e); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestLinkExtensions_Conflict(t *testing.T) {
+	e := New().Wrap(context.Background(), map[string]any{})
+	e.AddLink(Link{Rel: "self", Href: "/a", Extensions: map[string]any{"href": "/b"}})
+
+	if _, err := json.Marshal(e); !errors.Is(err, ErrExtensionConflict) {
+		t.Fatalf("expected ErrExtensionConflict, got %v", err)
+	}
+}
+
+func TestLinkExtensions_Unmarshal(t *testing.T) {
+	l := Link{Rel: "keep", Extensions: map[string]any{"stale": true}}
+	if err := json.Unmarshal([]byte(`{"href":"/a","title":"A","permissions":["read"],"ttl":30}`), &l); err != nil {
+		t.Fatal(err)
+	}
+	want := Link{Rel: "keep", Href: "/a", Title: "A", Extensions: map[string]any{
+		"permissions": []any{"read"},
+		"ttl":         float64(30),
+	}}
+	if !l.Equal(want) {
+		t.Fatalf("expected %+v, got %+v", want, l)
+	}
+
+	var plain Link
+	if err := json.Unmarshal([]byte(`{"href":"/a"}`), &plain); err != nil {
+		t.Fatal(err)
+	}
+	if plain.Extensions != nil {
+		t.Fatalf("expected nil Extensions, got %v", plain.Extensions)
+	}
+}
+
+func TestLinkExtensions_StaticLinksDecoded(t *testing.T) {
+	inst := New()
+	RegisterStatic(inst, &TestData{}, []Link{{Rel: "self", Href: "/data", Extensions: map[string]any{"cache": "public"}}})
+
+	got := inst.Wrap(context.Background(), &TestData{}).Links()["self"]
+	want := Link{Rel: "self", Href: "/data", Extensions: map[string]any{"cache": "public"}}
+	if len(got) != 1 || !got[0].Equal(want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestLinkExtensions_Equal(t *testing.T) {
+	a := Link{Rel: "self", Href: "/a", Extensions: map[string]any{"tags": []string{"x"}}}
+
+	if !a.Equal(Link{Rel: "self", Href: "/a", Extensions: map[string]any{"tags": []string{"x"}}}) {
+		t.Fatal("expected deeply equal extensions to be equal")
+	}
+	if a.Equal(Link{Rel: "self", Href: "/a", Extensions: map[string]any{"tags": []string{"y"}}}) {
+		t.Fatal("expected differing extensions to differ")
+	}
+	if !(Link{Href: "/a"}).Equal(Link{Href: "/a", Extensions: map[string]any{}}) {
+		t.Fatal("expected nil and empty extensions to be equal")
+	}
+}
+
+func TestAppendJSONString_MatchesEncoder(t *testing.T) {
+	for _, s := range []string{
+		"", "/users/1", `quote " and \ backslash`, "<a href='x'>&amp;</a>",
+		"tab\tnewline\nreturn\rnul\x00bell\x07", "ünïcödé 日本", "line\u2028para\u2029",
+		"bad \xff utf8 \xc3", "/x?a=1&b=2",
+	} {
+		want, err := goccy.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := appendJSONString(nil, s, true); string(got) != string(want) {
+			t.Errorf("%q: expected %s, got %s", s, want, got)
+		}
+	}
+}
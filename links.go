@@ -0,0 +1,262 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// WithLinkDeduplication collapses identical links within a rel at marshal time.
+// When several sources contribute the same link, the rel would otherwise be
+// serialized as an array of equal entries. Links are compared with Link.Equal;
+// the first occurrence of each survives in its original position, and a rel
+// left with a single link is serialized as an object.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithLinkDeduplication())
+func WithLinkDeduplication() InstanceOption {
+	return func(i *Instance) {
+		i.dedupLinks = true
+	}
+}
+
+// WithSortedLinkArrays sorts the links of every multi-valued rel by Href, then
+// Name, at marshal time. Use it when byte-stable output matters more than the
+// default source ordering (see Envelope.AddLink).
+//
+// # Example
+//
+//	inst := hal.New(hal.WithSortedLinkArrays())
+func WithSortedLinkArrays() InstanceOption {
+	return func(i *Instance) {
+		i.sortLinkArrays = true
+	}
+}
+
+// LinkByName returns the link under rel whose Name equals name.
+// The HAL specification allows name to act as a secondary key when a rel has
+// multiple links, such as several "alternate" representations.
+//
+// # Example
+//
+//	pdf, ok := env.LinkByName("alternate", "pdf")
+func (e *Envelope) LinkByName(rel, name string) (Link, bool) {
+	switch v := e.links[rel].(type) {
+	case Link:
+		if v.Name == name {
+			return v, true
+		}
+	case []any:
+		for _, item := range v {
+			if l, ok := item.(Link); ok && l.Name == name {
+				return l, true
+			}
+		}
+	case []Link:
+		for _, l := range v {
+			if l.Name == name {
+				return l, true
+			}
+		}
+	}
+	return Link{}, false
+}
+
+// linksForMarshal returns the rel map to serialize. Marshal-time processing
+// works on a copy, so e.links is never mutated and an envelope can be
+// marshaled any number of times with the same result.
+func (e *Envelope) linksForMarshal() map[string]any {
+	links := e.links
+	if e.instance == nil {
+		return arrayLinks(links, nil)
+	}
+	curies := e.instance.curieSnapshot()
+	if e.instance.compactCuries {
+		links = compactRels(links, curies)
+	}
+	if e.instance.dedupLinks {
+		links = dedupLinks(links)
+	}
+	if used := e.usedCuries(links, curies); len(used) > 0 {
+		links = maps.Clone(links)
+		links[RelCuries] = mergeCuries(links[RelCuries], used)
+	}
+	if e.instance.sortLinkArrays {
+		links = sortLinkArrays(links)
+	}
+	links = e.instance.rewriteLinks(links, e.rewriter)
+	if e.baseURL != nil {
+		links = absoluteLinks(links, e.baseURL)
+	}
+	return arrayLinks(links, e.instance.arrayLinkRels)
+}
+
+// WithArrayRels forces the listed link rels to always serialize as arrays,
+// even when a rel holds a single link. "curies" is always an array, as the
+// HAL specification requires, and need not be listed.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithArrayRels("item", "alternate"))
+func WithArrayRels(rels ...string) InstanceOption {
+	return func(i *Instance) {
+		if i.arrayLinkRels == nil {
+			i.arrayLinkRels = make(map[string]struct{}, len(rels))
+		}
+		for _, rel := range rels {
+			i.arrayLinkRels[rel] = struct{}{}
+		}
+	}
+}
+
+// arrayLinks returns links with "curies" and the rels in arrayRels wrapped
+// in a slice when they hold a single value. The input map is not modified.
+func arrayLinks(links map[string]any, arrayRels map[string]struct{}) map[string]any {
+	var out map[string]any
+	force := func(rel string) {
+		v, ok := links[rel]
+		if !ok || isArrayValue(v) {
+			return
+		}
+		if out == nil {
+			out = maps.Clone(links)
+		}
+		out[rel] = []any{v}
+	}
+	force(RelCuries)
+	for rel := range arrayRels {
+		force(rel)
+	}
+	if out == nil {
+		return links
+	}
+	return out
+}
+
+// mergeCuries appends the resolved curie links to the curies already present
+// on the envelope, if any, as a flat slice.
+func mergeCuries(existing any, used []Link) any {
+	if existing == nil {
+		return used
+	}
+	out := relValues(existing)
+	for _, c := range used {
+		out = append(out, c)
+	}
+	return out
+}
+
+// sortLinkArrays returns links with every multi-valued rel sorted by
+// (Href, Name). Sorted slices are copies; the input map is not modified.
+func sortLinkArrays(links map[string]any) map[string]any {
+	var out map[string]any
+	for rel, v := range links {
+		var sorted any
+		switch vv := v.(type) {
+		case []any:
+			if len(vv) < 2 {
+				continue
+			}
+			s := slices.Clone(vv)
+			slices.SortStableFunc(s, func(a, b any) int {
+				la, _ := a.(Link)
+				lb, _ := b.(Link)
+				return compareLinks(la, lb)
+			})
+			sorted = s
+		case []Link:
+			if len(vv) < 2 {
+				continue
+			}
+			s := slices.Clone(vv)
+			slices.SortStableFunc(s, compareLinks)
+			sorted = s
+		default:
+			continue
+		}
+		if out == nil {
+			out = maps.Clone(links)
+		}
+		out[rel] = sorted
+	}
+	if out == nil {
+		return links
+	}
+	return out
+}
+
+func compareLinks(a, b Link) int {
+	if c := strings.Compare(a.Href, b.Href); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
+// dedupLinks returns links with duplicate entries removed from every
+// multi-valued rel. The input map is returned unchanged if it has no
+// duplicates; otherwise a modified copy is returned.
+func dedupLinks(links map[string]any) map[string]any {
+	var out map[string]any
+	for rel, v := range links {
+		vals, ok := v.([]any)
+		if !ok {
+			continue
+		}
+		uniq := uniqueLinks(vals)
+		if len(uniq) == len(vals) {
+			continue
+		}
+		if out == nil {
+			out = maps.Clone(links)
+		}
+		if len(uniq) == 1 {
+			out[rel] = uniq[0]
+		} else {
+			out[rel] = uniq
+		}
+	}
+	if out == nil {
+		return links
+	}
+	return out
+}
+
+// uniqueLinks drops Link values equal to an earlier one, preserving order.
+// Values that are not Links are always kept.
+func uniqueLinks(vals []any) []any {
+	out := make([]any, 0, len(vals))
+next:
+	for _, v := range vals {
+		if l, ok := v.(Link); ok {
+			for _, seen := range out {
+				if s, ok := seen.(Link); ok && s.Equal(l) {
+					continue next
+				}
+			}
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// RenameRel moves the links stored under from to the rel to, after any links
+// already there. It does nothing if from has no links. Transformers use it to
+// rename a rel during a migration window.
+func (e *Envelope) RenameRel(from, to string) {
+	v, ok := e.links[from]
+	if !ok || from == to {
+		return
+	}
+	delete(e.links, from)
+	for _, val := range relValues(v) {
+		if l, isLink := val.(Link); isLink {
+			l.Rel = to
+			val = l
+		}
+		appendRel(e.links, to, val)
+	}
+}
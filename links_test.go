@@ -0,0 +1,238 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestLinkEqual(t *testing.T) {
+	a := Link{Rel: "self", Href: "/a", Title: "A"}
+
+	if !a.Equal(Link{Rel: "self", Href: "/a", Title: "A"}) {
+		t.Fatal("expected identical links to be equal")
+	}
+	if a.Equal(Link{Rel: "self", Href: "/a", Title: "B"}) {
+		t.Fatal("expected links differing in Title to differ")
+	}
+	if a.Equal(Link{Rel: "edit", Href: "/a", Title: "A"}) {
+		t.Fatal("expected links differing in Rel to differ")
+	}
+}
+
+func TestDeduplication_ExactDuplicatesCollapse(t *testing.T) {
+	env := New(WithLinkDeduplication()).WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "self", Href: "/users/1"})
+	env.AddLink(Link{Rel: "self", Href: "/users/1"})
+
+	want := `{"id":1,"_links":{"self":{"href":"/users/1"}}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestDeduplication_NearDuplicatesKept(t *testing.T) {
+	env := New(WithLinkDeduplication()).WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "alternate", Href: "/users/1", Title: "JSON"})
+	env.AddLink(Link{Rel: "alternate", Href: "/users/1", Title: "XML"})
+
+	want := `{"id":1,"_links":{"alternate":[{"href":"/users/1","title":"JSON"},{"href":"/users/1","title":"XML"}]}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestDeduplication_PreservesFirstSeenOrder(t *testing.T) {
+	env := New(WithLinkDeduplication()).WrapRaw(&collectionUser{ID: 1})
+	for _, href := range []string{"/b", "/a", "/b", "/c", "/a"} {
+		env.AddLink(Link{Rel: "item", Href: href})
+	}
+
+	want := `{"id":1,"_links":{"item":[{"href":"/b"},{"href":"/a"},{"href":"/c"}]}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	// The envelope itself keeps every link; deduplication is marshal-time only.
+	if n := len(env.links["item"].([]any)); n != 5 {
+		t.Fatalf("expected stored links untouched, got %d", n)
+	}
+}
+
+func TestDeduplication_DisabledByDefault(t *testing.T) {
+	env := New().WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "self", Href: "/users/1"})
+	env.AddLink(Link{Rel: "self", Href: "/users/1"})
+
+	want := `{"id":1,"_links":{"self":[{"href":"/users/1"},{"href":"/users/1"}]}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestMarshal_RepeatedMarshalStable(t *testing.T) {
+	inst := New()
+	inst.RegisterCurie("acme", "https://docs.example.com/rels/{rel}")
+	RegisterInstance(inst, func(_ context.Context, _ *collectionUser) []Link {
+		return []Link{{Rel: "acme:widget", Href: "/x"}}
+	})
+
+	env := inst.Wrap(context.Background(), &collectionUser{ID: 1})
+	first := marshalString(t, env)
+	if second := marshalString(t, env); second != first {
+		t.Fatalf("expected identical output on re-marshal:\nfirst  %s\nsecond %s", first, second)
+	}
+}
+
+func TestLinkOrdering_DefaultFollowsSources(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, _ *collectionUser) []Link {
+		return []Link{{Rel: "item", Href: "/b"}, {Rel: "item", Href: "/a"}}
+	})
+
+	env := inst.Wrap(context.Background(), &collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "item", Href: "/0"})
+
+	want := `{"id":1,"_links":{"item":[{"href":"/b"},{"href":"/a"},{"href":"/0"}]}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestLinkOrdering_SortedArrays(t *testing.T) {
+	inst := New(WithSortedLinkArrays())
+	RegisterInstance(inst, func(_ context.Context, _ *collectionUser) []Link {
+		return []Link{
+			{Rel: "item", Href: "/b"},
+			{Rel: "item", Href: "/a", Name: "z"},
+			{Rel: "item", Href: "/a", Name: "y"},
+		}
+	})
+
+	env := inst.Wrap(context.Background(), &collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "item", Href: "/0"})
+
+	want := `{"id":1,"_links":{"item":[{"href":"/0"},{"href":"/a","name":"y"},{"href":"/a","name":"z"},{"href":"/b"}]}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestLinkOrdering_SortedCuries(t *testing.T) {
+	inst := New(WithSortedLinkArrays())
+	inst.RegisterCurie("b", "https://b.example.com/{rel}")
+	inst.RegisterCurie("a", "https://a.example.com/{rel}")
+
+	env := inst.WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "b:x", Href: "/x"})
+	env.AddLink(Link{Rel: "a:y", Href: "/y"})
+
+	want := `{"id":1,"_links":{"a:y":{"href":"/y"},"b:x":{"href":"/x"},"curies":[` +
+		`{"href":"https://a.example.com/{rel}","templated":true,"name":"a"},` +
+		`{"href":"https://b.example.com/{rel}","templated":true,"name":"b"}]}}`
+	for range 10 {
+		if got := marshalString(t, env); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	}
+}
+
+func TestLinkByName(t *testing.T) {
+	env := New().WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "alternate", Href: "/users/1.pdf", Name: "pdf"})
+	env.AddLink(Link{Rel: "alternate", Href: "/users/1.csv", Name: "csv"})
+	env.AddLink(Link{Rel: "self", Href: "/users/1", Name: "me"})
+
+	if l, ok := env.LinkByName("alternate", "csv"); !ok || l.Href != "/users/1.csv" {
+		t.Fatalf("expected csv alternate, got %+v (found=%v)", l, ok)
+	}
+	if l, ok := env.LinkByName("self", "me"); !ok || l.Href != "/users/1" {
+		t.Fatalf("expected single-valued rel lookup to match, got %+v", l)
+	}
+	if _, ok := env.LinkByName("alternate", "xml"); ok {
+		t.Fatal("expected lookup miss for unknown name")
+	}
+	if _, ok := env.LinkByName("missing", "pdf"); ok {
+		t.Fatal("expected lookup miss for unknown rel")
+	}
+}
+
+func TestLintHook_DuplicateNameWithinRel(t *testing.T) {
+	var warnings []string
+	inst := New(WithLintHook(func(msg string) { warnings = append(warnings, msg) }))
+
+	env := inst.WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "alternate", Href: "/a.pdf", Name: "pdf"})
+	env.AddLink(Link{Rel: "alternate", Href: "/b.csv", Name: "csv"})
+	env.AddLink(Link{Rel: "alternate", Href: "/c", Name: ""})
+	env.AddLink(Link{Rel: "alternate", Href: "/d"})
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings for distinct or empty names, got %v", warnings)
+	}
+
+	env.AddLink(Link{Rel: "alternate", Href: "/c.pdf", Name: "pdf"})
+	if len(warnings) != 1 || !containsString(warnings[0], `"pdf"`) {
+		t.Fatalf("expected one warning naming pdf, got %v", warnings)
+	}
+}
+
+func TestLink_MarshalAllProperties(t *testing.T) {
+	l := Link{
+		Rel:         "alternate",
+		Href:        "/docs/{id}",
+		Templated:   true,
+		Type:        "text/html",
+		Deprecation: "https://example.com/deprecations/docs",
+		Name:        "html",
+		Profile:     "https://example.com/profiles/doc",
+		Title:       "Documentation",
+		HrefLang:    "en-US",
+		Method:      "GET",
+	}
+	e := New().Wrap(context.Background(), map[string]any{})
+	e.AddLink(l)
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, prop := range []string{
+		`"href":"/docs/{id}"`, `"templated":true`, `"type":"text/html"`,
+		`"deprecation":"https://example.com/deprecations/docs"`, `"name":"html"`,
+		`"profile":"https://example.com/profiles/doc"`, `"title":"Documentation"`,
+		`"hreflang":"en-US"`, `"method":"GET"`,
+	} {
+		if !contains(b, prop) {
+			t.Errorf("expected %s in %s", prop, b)
+		}
+	}
+
+	var doc struct {
+		Links map[string]Link `json:"_links"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	got := doc.Links["alternate"]
+	got.Rel = l.Rel
+	if !got.Equal(l) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, l)
+	}
+}
+
+func TestWithArrayRels(t *testing.T) {
+	e := New(WithArrayRels("item")).Wrap(context.Background(), map[string]any{})
+	e.AddLink(Link{Rel: "item", Href: "/items/1"})
+	e.AddLink(Link{Rel: "self", Href: "/items"})
+
+	want := `{"_links":{"item":[{"href":"/items/1"}],"self":{"href":"/items"}}}`
+	if got := marshalString(t, e); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if _, ok := e.links["item"].([]any); ok {
+		t.Fatal("expected the envelope's links to be left unmodified")
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import "fmt"
+
+// WithLintHook installs a callback receiving warnings about questionable but
+// valid HAL output, such as two links under one rel sharing the same name or
+// a rel one typo away from a standard one ("slef").
+// Lint warnings never change the serialized document.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithLintHook(func(msg string) { log.Print(msg) }))
+func WithLintHook(fn func(msg string)) InstanceOption {
+	return func(i *Instance) {
+		i.lint = fn
+	}
+}
+
+// lintf reports a lint warning if a hook is installed.
+func (i *Instance) lintf(format string, args ...any) {
+	if i == nil || i.lint == nil {
+		return
+	}
+	i.lint(fmt.Sprintf(format, args...))
+}
+
+// lintLink checks l against the links already stored in e.
+func (e *Envelope) lintLink(l Link) {
+	if e.instance == nil || e.instance.lint == nil {
+		return
+	}
+	if std, ok := nearMissRel(l.Rel); ok {
+		e.instance.lintf("hal: rel %q looks like a misspelling of %q", l.Rel, std)
+	}
+	if l.Name != "" {
+		if _, dup := e.LinkByName(l.Rel, l.Name); dup {
+			e.instance.lintf("hal: rel %q has more than one link named %q", l.Rel, l.Name)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type projection map[string]any
+
+func projectionInstance(opts ...InstanceOption) *Instance {
+	inst := New(opts...)
+	RegisterInstance(inst, func(_ context.Context, p *projection) []Link {
+		return []Link{SelfLink(fmt.Sprint("/things/", (*p)["id"]))}
+	})
+	return inst
+}
+
+func TestWrap_NamedMap(t *testing.T) {
+	ctx := context.Background()
+	for _, inst := range []*Instance{projectionInstance(), projectionInstance(WithStrictMode())} {
+		want := `{"id":7,"_links":{"self":{"href":"/things/7"}}}`
+		if got := marshalString(t, inst.Wrap(ctx, projection{"id": 7})); got != want {
+			t.Fatalf("value: expected %s, got %s", want, got)
+		}
+		if got := marshalString(t, inst.Wrap(ctx, &projection{"id": 7})); got != want {
+			t.Fatalf("pointer: expected %s, got %s", want, got)
+		}
+	}
+}
+
+func TestWrap_NamedMapGeneratorSharesEntries(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, p *projection) []Link {
+		(*p)["seen"] = true
+		return nil
+	})
+	p := projection{"id": 1}
+	inst.Wrap(context.Background(), p)
+	if p["seen"] != true {
+		t.Fatalf("expected the generator to see the caller's map, got %v", p)
+	}
+}
+
+func TestWrap_PlainMap(t *testing.T) {
+	ctx := context.Background()
+	env := New().Wrap(ctx, map[string]any{"id": 3})
+	env.AddLink(SelfLink("/things/3"))
+	if got := marshalString(t, env); got != `{"id":3,"_links":{"self":{"href":"/things/3"}}}` {
+		t.Fatalf("unexpected output %s", got)
+	}
+
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, m *map[string]any) []Link {
+		return []Link{SelfLink(fmt.Sprint("/things/", (*m)["id"]))}
+	})
+	if got := marshalString(t, inst.Wrap(ctx, map[string]any{"id": 4})); got != `{"id":4,"_links":{"self":{"href":"/things/4"}}}` {
+		t.Fatalf("expected the map[string]any generator to run, got %s", got)
+	}
+}
+
+func TestWrap_MapWithLinksKey(t *testing.T) {
+	p := projection{"id": 7, "_links": map[string]any{"legacy": map[string]any{"href": "/old/7"}}}
+	got := marshalString(t, projectionInstance().Wrap(context.Background(), p))
+	want := `{"id":7,"_links":{"legacy":{"href":"/old/7"},"self":{"href":"/things/7"}}}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if n := strings.Count(got, `"_links"`); n != 1 {
+		t.Fatalf("expected a single _links member, got %d", n)
+	}
+}
+
+func TestCollection_NamedMaps(t *testing.T) {
+	page := projectionInstance().Collection(context.Background(), []projection{{"id": 1}, {"id": 2}}, 2, SelfLink("/things"))
+	got := marshalString(t, page)
+	if !strings.Contains(got, `"/things/1"`) || !strings.Contains(got, `"/things/2"`) {
+		t.Fatalf("expected item links, got %s", got)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"reflect"
+)
+
+// MarshalFunc serializes envelope Data to JSON. The result must be a JSON
+// object (or null) so that _links and _embedded can be spliced into it.
+type MarshalFunc func(v any) ([]byte, error)
+
+// WithMarshalFunc replaces the JSON encoder used for envelope Data.
+// json.RawMessage data is still passed through verbatim. Use it to plug in
+// encoders for types with their own JSON mapping, such as protobuf messages
+// (see the halproto module).
+//
+// # Example
+//
+//	inst := hal.New(hal.WithMarshalFunc(func(v any) ([]byte, error) {
+//	    return sonic.Marshal(v)
+//	}))
+func WithMarshalFunc(fn MarshalFunc) InstanceOption {
+	return func(i *Instance) {
+		i.marshal = fn
+	}
+}
+
+// RegisterMarshaler overrides how Data of type *T is serialized on the
+// Instance. It takes precedence over WithMarshalFunc; json.RawMessage data is
+// still passed through. The returned bytes must be a JSON object, like any
+// other Data, so that links can be spliced into it.
+//
+// # Example
+//
+//	hal.RegisterMarshaler(inst, func(m *Money) ([]byte, error) {
+//	    return []byte(`{"amount":"` + m.Amount.String() + `"}`), nil
+//	})
+func RegisterMarshaler[T any](i *Instance, fn func(*T) ([]byte, error)) {
+	targetType := reflect.TypeOf((*T)(nil))
+	adapter := func(v any) ([]byte, error) {
+		t := v.(*T)
+		if t == nil {
+			return []byte("null"), nil
+		}
+		return fn(t)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.marshalers[targetType] = adapter
+	i.refreeze()
+}
+
+// customMarshalFunc returns the encoder configured for data: a per-type
+// override if one is registered, else the instance-wide encoder, or nil if
+// the default encoder applies. A nil Instance uses the default.
+func (i *Instance) customMarshalFunc(data any) MarshalFunc {
+	if i == nil {
+		return nil
+	}
+	var fn MarshalFunc
+	var ok bool
+	if f := i.frozen.Load(); f != nil {
+		fn, ok = f.marshalers[reflect.TypeOf(data)]
+	} else {
+		i.mu.RLock()
+		fn, ok = i.marshalers[reflect.TypeOf(data)]
+		i.mu.RUnlock()
+	}
+	if ok {
+		return fn
+	}
+	return i.marshal
+}
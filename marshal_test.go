@@ -0,0 +1,119 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithMarshalFunc_UsedForData(t *testing.T) {
+	var called bool
+	inst := New(WithMarshalFunc(func(v any) ([]byte, error) {
+		called = true
+		return []byte(`{"user_id":1}`), nil
+	}))
+	env := inst.Wrap(context.Background(), &collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "self", Href: "/users/1"})
+
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Fatal("expected custom marshal func to be called")
+	}
+	want := `{"user_id":1,"_links":{"self":{"href":"/users/1"}}}`
+	if string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+}
+
+func TestWithMarshalFunc_SkippedForRawMessage(t *testing.T) {
+	inst := New(WithMarshalFunc(func(v any) ([]byte, error) {
+		return nil, errBoom
+	}))
+
+	b, err := json.Marshal(inst.Wrap(context.Background(), json.RawMessage(wantUserID1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != wantUserID1 {
+		t.Fatalf("expected %s, got %s", wantUserID1, b)
+	}
+}
+
+func TestWithMarshalFunc_ErrorWrapped(t *testing.T) {
+	inst := New(WithMarshalFunc(func(v any) ([]byte, error) {
+		return nil, errBoom
+	}))
+
+	_, err := json.Marshal(inst.Wrap(context.Background(), &collectionUser{ID: 1}))
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("expected wrapped errBoom, got %v", err)
+	}
+}
+
+type legacyUser struct {
+	UserID   int
+	FullName string
+}
+
+func renameLegacyUser(u *legacyUser) ([]byte, error) {
+	return json.Marshal(map[string]any{"id": u.UserID, "name": u.FullName})
+}
+
+func TestRegisterMarshaler_RenamesFields(t *testing.T) {
+	inst := New()
+	RegisterMarshaler(inst, renameLegacyUser)
+	env := inst.Wrap(context.Background(), &legacyUser{UserID: 1, FullName: "Ada"})
+	env.AddLink(Link{Rel: "self", Href: "/users/1"})
+
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":1,"name":"Ada","_links":{"self":{"href":"/users/1"}}}`
+	if string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+}
+
+func TestRegisterMarshaler_WinsOverMarshalFunc(t *testing.T) {
+	inst := New(WithMarshalFunc(func(v any) ([]byte, error) {
+		return nil, errBoom
+	}))
+	RegisterMarshaler(inst, renameLegacyUser)
+
+	b, err := json.Marshal(inst.Wrap(context.Background(), &legacyUser{UserID: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"id":1,"name":""}` {
+		t.Fatalf("expected per-type override output, got %s", b)
+	}
+
+	// Other types still use the instance-wide encoder.
+	if _, err := json.Marshal(inst.Wrap(context.Background(), &collectionUser{ID: 1})); !errors.Is(err, errBoom) {
+		t.Fatalf("expected instance-wide encoder for other types, got %v", err)
+	}
+}
+
+func TestRegisterMarshaler_NonObjectFails(t *testing.T) {
+	inst := New()
+	RegisterMarshaler(inst, func(u *legacyUser) ([]byte, error) {
+		return []byte(`"` + u.FullName + `"`), nil
+	})
+
+	_, err := json.Marshal(inst.Wrap(context.Background(), &legacyUser{FullName: "Ada"}))
+	if !errors.Is(err, ErrNonObjectData) {
+		t.Fatalf("expected ErrNonObjectData, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "*hal.legacyUser") {
+		t.Fatalf("expected type name in error, got %v", err)
+	}
+}
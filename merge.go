@@ -0,0 +1,256 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// registrations is a copy of the registry state of an Instance, as opposed
+// to the settings applied by options.
+type registrations struct {
+	generators  map[reflect.Type]Generator
+	interfaces  []interfaceGenerator
+	precomputed map[reflect.Type]*PrecomputedLinks
+	resources   map[reflect.Type]func(context.Context, any) ([]Link, map[string]any)
+	templates   map[reflect.Type]func(context.Context, any) map[string]Template
+	relInfo     map[reflect.Type][]RelInfo
+	cacheKeys   map[reflect.Type]func(any) any
+	marshalers  map[reflect.Type]MarshalFunc
+	curies      map[string]string
+	curieRefs   map[string]struct{}
+	routes      map[string]string
+	samples     []any
+}
+
+// snapshot copies the instance's registrations under the read lock.
+func (i *Instance) snapshot() registrations {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return registrations{
+		generators:  maps.Clone(i.generators),
+		interfaces:  slices.Clone(i.interfaces),
+		precomputed: maps.Clone(i.precomputed),
+		resources:   maps.Clone(i.resources),
+		templates:   maps.Clone(i.templates),
+		relInfo:     maps.Clone(i.relInfo),
+		cacheKeys:   maps.Clone(i.cacheKeys),
+		marshalers:  maps.Clone(i.marshalers),
+		curies:      maps.Clone(i.curies),
+		curieRefs:   maps.Clone(i.curieRefs),
+		routes:      maps.Clone(i.routes),
+		samples:     slices.Clone(i.samples),
+	}
+}
+
+// Clone returns an independent copy of the instance: its settings and all of
+// its registrations. Registering on the clone does not affect i, and later
+// registrations on i are not seen by the clone.
+//
+// # Example
+//
+//	base := hal.New(hal.WithStrictMode(), hal.WithCuries(orgCuries))
+//	hal.RegisterInstance(base, errorLinks)
+//
+//	users := base.Clone()
+//	hal.RegisterInstance(users, userLinks) // base is unchanged
+func (i *Instance) Clone() *Instance {
+	r := i.snapshot()
+	c := &Instance{
+		interfaceMatches: new(sync.Map),
+		generators:       r.generators,
+		interfaces:       r.interfaces,
+		precomputed:      r.precomputed,
+		resources:        r.resources,
+		templates:        r.templates,
+		relInfo:          r.relInfo,
+		cacheKeys:        r.cacheKeys,
+		marshalers:       r.marshalers,
+		curies:           r.curies,
+		curieRefs:        r.curieRefs,
+		routes:           r.routes,
+		samples:          r.samples,
+	}
+
+	// Settings are fixed once New returns, so they can be shared.
+	c.strictMode = i.strictMode
+	c.strictErrors = i.strictErrors
+	c.strictChecks = i.strictChecks
+	c.strictChecksSet = i.strictChecksSet
+	c.strictKinds = i.strictKinds
+	c.strictExempt = i.strictExempt
+	c.propagatePanics = i.propagatePanics
+	c.generatorRecovery = i.generatorRecovery
+	c.dedupLinks = i.dedupLinks
+	c.sortLinkArrays = i.sortLinkArrays
+	c.compactCuries = i.compactCuries
+	c.hoistCuries = i.hoistCuries
+	c.canonicalData = i.canonicalData
+	c.dataMetaWins = i.dataMetaWins
+	c.marshal = i.marshal
+	c.transformers = slices.Clone(i.transformers)
+	c.afterWrap = slices.Clone(i.afterWrap)
+	c.baseURLResolver = i.baseURLResolver
+	c.absoluteLinks = i.absoluteLinks
+	c.linkRewriters = slices.Clone(i.linkRewriters)
+	c.contextRewriter = i.contextRewriter
+	c.negotiate = i.negotiate
+	c.requireSelf = i.requireSelf
+	c.maxEmbedDepth = i.maxEmbedDepth
+	c.autoDeref = i.autoDeref
+	c.autoCollection = i.autoCollection
+	c.nilGenerators = i.nilGenerators
+	c.sortedOutput = i.sortedOutput
+	c.autoTemplated = i.autoTemplated
+	c.lint = i.lint
+	c.missingCurie = i.missingCurie
+	c.itemsRel = i.itemsRel
+	c.emitTotal = i.emitTotal
+	c.arrayEmbeddedRels = i.arrayEmbeddedRels
+	c.arrayLinkRels = i.arrayLinkRels
+	return c
+}
+
+// MergeOption configures Instance.Merge.
+type MergeOption func(*mergeConfig)
+
+type mergeConfig struct {
+	overwrite bool
+}
+
+// MergeOverwrite makes Merge replace conflicting registrations with those of
+// the merged instance instead of failing.
+func MergeOverwrite() MergeOption {
+	return func(c *mergeConfig) {
+		c.overwrite = true
+	}
+}
+
+// Merge copies other's registrations into i: generators, rel declarations,
+// static links, resource generators, templates, cache keys, marshalers,
+// routes, CURIE definitions and samples.
+// Settings of i are kept.
+//
+// A type registered on both instances, or a route name or CURIE prefix
+// defined on both with different values, is a conflict. By default Merge
+// then returns an error wrapping ErrRegistrationConflict that lists every
+// conflict, and i is left unchanged; with MergeOverwrite, other's
+// registrations win.
+//
+// # Example
+//
+//	svc := hal.New()
+//	if err := svc.Merge(orgBase); err != nil {
+//	    log.Fatal(err)
+//	}
+func (i *Instance) Merge(other *Instance, opts ...MergeOption) error {
+	if other == nil || other == i {
+		return nil
+	}
+	var cfg mergeConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	r := other.snapshot()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if !cfg.overwrite {
+		if err := i.mergeConflicts(r); err != nil {
+			return err
+		}
+	}
+	i.generators = mergeMap(i.generators, r.generators)
+	for _, ig := range r.interfaces {
+		i.removeInterface(ig.iface)
+		i.interfaces = append(i.interfaces, ig)
+	}
+	i.interfaceMatches = new(sync.Map)
+	i.precomputed = mergeMap(i.precomputed, r.precomputed)
+	i.resources = mergeMap(i.resources, r.resources)
+	i.templates = mergeMap(i.templates, r.templates)
+	i.relInfo = mergeMap(i.relInfo, r.relInfo)
+	i.cacheKeys = mergeMap(i.cacheKeys, r.cacheKeys)
+	i.marshalers = mergeMap(i.marshalers, r.marshalers)
+	// The curies map is replaced, never mutated (see curieSnapshot).
+	curies := maps.Clone(i.curies)
+	maps.Copy(curies, r.curies)
+	i.curies = curies
+	i.curieRefs = mergeMap(i.curieRefs, r.curieRefs)
+	i.routes = mergeMap(i.routes, r.routes)
+	i.samples = append(i.samples, r.samples...)
+	i.registryChanged()
+	return nil
+}
+
+// mergeConflicts describes the registrations of r that would replace ones
+// of i. The caller must hold i.mu.
+func (i *Instance) mergeConflicts(r registrations) error {
+	var conflicts []string
+	for t := range r.generators {
+		if _, ok := i.generators[t]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("generator for %v", t))
+		}
+	}
+	for _, ig := range r.interfaces {
+		for _, own := range i.interfaces {
+			if own.iface == ig.iface {
+				conflicts = append(conflicts, fmt.Sprintf("generator for %v", ig.iface))
+			}
+		}
+	}
+	for t := range r.precomputed {
+		if _, ok := i.precomputed[t]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("static links for %v", t))
+		}
+	}
+	for t := range r.templates {
+		if _, ok := i.templates[t]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("templates for %v", t))
+		}
+	}
+	for t := range r.cacheKeys {
+		if _, ok := i.cacheKeys[t]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("cache key for %v", t))
+		}
+	}
+	for t := range r.marshalers {
+		if _, ok := i.marshalers[t]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("marshaler for %v", t))
+		}
+	}
+	for name, pattern := range r.routes {
+		if existing, ok := i.routes[name]; ok && existing != pattern {
+			conflicts = append(conflicts, fmt.Sprintf("route %q (%s, %s)", name, existing, pattern))
+		}
+	}
+	for prefix, href := range r.curies {
+		if existing, ok := i.curies[prefix]; ok && existing != href {
+			conflicts = append(conflicts, fmt.Sprintf("curie %q (%s, %s)", prefix, existing, href))
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	slices.Sort(conflicts)
+	return fmt.Errorf("%w: %s", ErrRegistrationConflict, strings.Join(conflicts, "; "))
+}
+
+// mergeMap copies src into dst, allocating dst if needed.
+func mergeMap[K comparable, V any](dst, src map[K]V) map[K]V {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[K]V, len(src))
+	}
+	maps.Copy(dst, src)
+	return dst
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type mergeOrder struct {
+	ID int `json:"id"`
+}
+
+func orgBase() *Instance {
+	base := New(WithStrictMode(), WithCuries(map[string]string{"org": "https://docs.example.com/{rel}"}))
+	RegisterInstance(base, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	return base
+}
+
+func TestClone_Independent(t *testing.T) {
+	base := orgBase()
+	child := base.Clone()
+
+	RegisterInstance(child, func(_ context.Context, o *mergeOrder) []Link {
+		return []Link{{Rel: "self", Href: "/orders/" + itoa(o.ID)}}
+	})
+	child.RegisterCurie("svc", "https://svc.example.com/{rel}")
+
+	if _, err := child.WrapE(context.Background(), &mergeOrder{ID: 1}); err != nil {
+		t.Fatalf("expected child to wrap its own type, got %v", err)
+	}
+	if _, err := child.WrapE(context.Background(), &collectionUser{ID: 1}); err != nil {
+		t.Fatalf("expected child to inherit base generators, got %v", err)
+	}
+	if _, err := base.WrapE(context.Background(), &mergeOrder{ID: 1}); !errors.Is(err, ErrNoGenerator) {
+		t.Fatalf("expected base to stay unchanged and strict, got %v", err)
+	}
+	if _, ok := base.Curies()["svc"]; ok {
+		t.Fatal("expected child curie not to leak into base")
+	}
+	if child.Curies()["org"] == "" {
+		t.Fatal("expected child to inherit base curies")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	svc := New()
+	RegisterInstance(svc, func(_ context.Context, o *mergeOrder) []Link {
+		return []Link{{Rel: "self", Href: "/orders/" + itoa(o.ID)}}
+	})
+	if err := svc.Merge(orgBase()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := marshalString(t, svc.Wrap(context.Background(), &collectionUser{ID: 2})); got != `{"id":2,"_links":{"self":{"href":"/users/2"}}}` {
+		t.Fatalf("expected merged generator, got %s", got)
+	}
+	if svc.Curies()["org"] == "" {
+		t.Fatal("expected merged curies")
+	}
+	if svc.strictMode {
+		t.Fatal("expected Merge to keep the receiver's settings")
+	}
+}
+
+func TestMerge_Conflicts(t *testing.T) {
+	newSvc := func() *Instance {
+		svc := New(WithCuries(map[string]string{"org": "https://other.example.com/{rel}"}))
+		RegisterInstance(svc, func(_ context.Context, u *collectionUser) []Link {
+			return []Link{{Rel: "self", Href: "/members/" + itoa(u.ID)}}
+		})
+		return svc
+	}
+
+	svc := newSvc()
+	err := svc.Merge(orgBase())
+	if !errors.Is(err, ErrRegistrationConflict) {
+		t.Fatalf("expected ErrRegistrationConflict, got %v", err)
+	}
+	for _, want := range []string{"generator for *hal.collectionUser", `curie "org"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+	if got := marshalString(t, svc.Wrap(context.Background(), &collectionUser{ID: 1})); !strings.Contains(got, "/members/1") {
+		t.Fatalf("expected failed merge to leave the instance unchanged, got %s", got)
+	}
+
+	svc = newSvc()
+	if err := svc.Merge(orgBase(), MergeOverwrite()); err != nil {
+		t.Fatal(err)
+	}
+	if got := marshalString(t, svc.Wrap(context.Background(), &collectionUser{ID: 1})); !strings.Contains(got, "/users/1") {
+		t.Fatalf("expected merged generator to win, got %s", got)
+	}
+	if got := svc.Curies()["org"]; got != "https://docs.example.com/{rel}" {
+		t.Fatalf("expected merged curie to win, got %q", got)
+	}
+}
+
+func TestCloneMerge_Concurrent(t *testing.T) {
+	base := orgBase()
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				c := base.Clone()
+				RegisterInstance(c, func(context.Context, *tenantDoc) []Link { return []Link{{Rel: "self", Href: "/o"}} })
+				if _, err := json.Marshal(c.Wrap(context.Background(), &collectionUser{ID: n})); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				other := New()
+				RegisterInstance(other, func(context.Context, *mergeOrder) []Link { return []Link{{Rel: "self", Href: "/o"}} })
+				if err := base.Merge(other, MergeOverwrite()); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := json.Marshal(base.Wrap(context.Background(), &collectionUser{ID: n})); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"bytes"
+	"context"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	json "github.com/goccy/go-json"
+)
+
+// responseHint records the response type declared with SetResponseType.
+// Middleware stores a pointer in the request context so the handler can set
+// it on the request it receives.
+type responseHint struct {
+	t reflect.Type
+}
+
+type responseHintKey struct{}
+
+// SetResponseType declares that the handler serving r writes a JSON-encoded
+// T, so Middleware can decode and wrap it. It has no effect on requests not
+// passing through Middleware.
+//
+// # Example
+//
+//	func getUser(w http.ResponseWriter, r *http.Request) {
+//	    hal.SetResponseType[User](r)
+//	    json.NewEncoder(w).Encode(user)
+//	}
+func SetResponseType[T any](r *http.Request) {
+	if hint, ok := r.Context().Value(responseHintKey{}).(*responseHint); ok {
+		hint.t = reflect.TypeOf((*T)(nil)).Elem()
+	}
+}
+
+// Middleware returns net/http middleware that turns the JSON responses of
+// existing handlers into HAL documents. The response is buffered; if the
+// handler declared its type with SetResponseType, responded with a 2xx
+// status and an application/json Content-Type, the body is decoded into a
+// new *T, wrapped with inst and the request context, and written with
+// Content-Type MediaType.
+//
+// Other responses pass through untouched, as do responses whose body fails
+// to decode or wrap; those failures are reported to the lint hook.
+// A handler that flushes the response streams it unbuffered and unwrapped.
+//
+// # Example
+//
+//	mux.Handle("/users/", hal.Middleware(inst)(usersHandler))
+func Middleware(inst *Instance) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hint := &responseHint{}
+			r = r.WithContext(context.WithValue(r.Context(), responseHintKey{}, hint))
+			rec := &responseRecorder{w: w}
+			next.ServeHTTP(rec, r)
+			if rec.streaming {
+				return
+			}
+			rec.finish(inst, r, hint.t)
+		})
+	}
+}
+
+// responseRecorder buffers a response until the handler returns, unless
+// the handler flushes it.
+type responseRecorder struct {
+	w         http.ResponseWriter
+	status    int
+	body      bytes.Buffer
+	streaming bool
+}
+
+func (rec *responseRecorder) Header() http.Header { return rec.w.Header() }
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.streaming {
+		rec.w.WriteHeader(status)
+		return
+	}
+	if rec.status == 0 {
+		rec.status = status
+	}
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.streaming {
+		return rec.w.Write(b)
+	}
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	return rec.body.Write(b)
+}
+
+// Flush switches to streaming: the buffered response is written as-is and
+// later writes go straight to the client.
+func (rec *responseRecorder) Flush() {
+	if !rec.streaming {
+		rec.streaming = true
+		rec.writeBuffered()
+	}
+	if f, ok := rec.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (rec *responseRecorder) Unwrap() http.ResponseWriter { return rec.w }
+
+func (rec *responseRecorder) writeBuffered() {
+	if rec.status != 0 {
+		rec.w.WriteHeader(rec.status)
+	}
+	if rec.body.Len() > 0 {
+		_, _ = rec.w.Write(rec.body.Bytes())
+	}
+}
+
+// finish writes the buffered response, wrapped if it qualifies.
+func (rec *responseRecorder) finish(inst *Instance, r *http.Request, t reflect.Type) {
+	if t == nil || rec.status < 200 || rec.status > 299 || rec.body.Len() == 0 || !isJSONContentType(rec.Header().Get("Content-Type")) {
+		rec.writeBuffered()
+		return
+	}
+	body, err := inst.wrapJSONBody(r.Context(), t, rec.body.Bytes())
+	if err != nil {
+		inst.lintf("hal: middleware for %s %s: %v", r.Method, r.URL.Path, err)
+		rec.writeBuffered()
+		return
+	}
+	h := rec.Header()
+	h.Set("Content-Type", MediaType)
+	h.Set("Content-Length", strconv.Itoa(len(body)))
+	rec.w.WriteHeader(rec.status)
+	_, _ = rec.w.Write(body)
+}
+
+// isJSONContentType reports whether ct is application/json.
+func isJSONContentType(ct string) bool {
+	mt, _, err := mime.ParseMediaType(ct)
+	return err == nil && mt == "application/json"
+}
+
+// wrapJSONBody decodes body into a new *T for t and returns it as a HAL
+// document.
+func (i *Instance) wrapJSONBody(ctx context.Context, t reflect.Type, body []byte) ([]byte, error) {
+	v := reflect.New(t)
+	if err := json.Unmarshal(body, v.Interface()); err != nil {
+		return nil, err
+	}
+	env, err := i.WrapE(ctx, v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return env.MarshalJSON()
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func middlewareInstance() *Instance {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	return inst
+}
+
+func serveMiddleware(inst *Instance, h http.HandlerFunc) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	Middleware(inst)(h).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	return w
+}
+
+func TestMiddleware_WrapsDeclaredType(t *testing.T) {
+	w := serveMiddleware(middlewareInstance(), func(w http.ResponseWriter, r *http.Request) {
+		SetResponseType[collectionUser](r)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(collectionUser{ID: 1})
+	})
+
+	if w.Code != http.StatusCreated || w.Header().Get("Content-Type") != MediaType {
+		t.Fatalf("unexpected status %d or content type %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	if got, want := w.Body.String(), `{"id":1,"_links":{"self":{"href":"/users/1"}}}`; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if w.Header().Get("Content-Length") != itoa(w.Body.Len()) {
+		t.Fatalf("expected Content-Length to match the new body, got %q", w.Header().Get("Content-Length"))
+	}
+}
+
+func TestMiddleware_PassThrough(t *testing.T) {
+	tests := map[string]http.HandlerFunc{
+		"no type hint": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":1}`))
+		},
+		"error status": func(w http.ResponseWriter, r *http.Request) {
+			SetResponseType[collectionUser](r)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"id":1}`))
+		},
+		"not JSON": func(w http.ResponseWriter, r *http.Request) {
+			SetResponseType[collectionUser](r)
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(`{"id":1}`))
+		},
+		"undecodable": func(w http.ResponseWriter, r *http.Request) {
+			SetResponseType[collectionUser](r)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[1,2]`))
+		},
+	}
+	for name, h := range tests {
+		t.Run(name, func(t *testing.T) {
+			want := httptest.NewRecorder()
+			h(want, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+			got := serveMiddleware(middlewareInstance(), h)
+			if got.Code != want.Code || got.Body.String() != want.Body.String() || got.Header().Get("Content-Type") != want.Header().Get("Content-Type") {
+				t.Fatalf("expected %d %s, got %d %s", want.Code, want.Body, got.Code, got.Body)
+			}
+		})
+	}
+}
+
+func TestMiddleware_Flush(t *testing.T) {
+	w := serveMiddleware(middlewareInstance(), func(w http.ResponseWriter, r *http.Request) {
+		SetResponseType[collectionUser](r)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":`))
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte(`1}`))
+	})
+	if !w.Flushed || w.Body.String() != `{"id":1}` || strings.Contains(w.Header().Get("Content-Type"), "hal") {
+		t.Fatalf("expected the flushed response to stream unwrapped, got %s", w.Body)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import "fmt"
+
+// WithMissingCurieHandler installs a callback receiving, at marshal time,
+// each curied rel whose prefix has no CURIE definition on the instance, so
+// clients would be unable to resolve it. Strict instances fail the marshal
+// with ErrUnregisteredCurie instead and do not call the handler.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithMissingCurieHandler(func(rel string) {
+//	    log.Printf("hal: no curie defined for rel %q", rel)
+//	}))
+func WithMissingCurieHandler(fn func(rel string)) InstanceOption {
+	return func(i *Instance) {
+		i.missingCurie = fn
+	}
+}
+
+// checkCuries reports the rels in links whose CURIE prefix is not defined,
+// returning an error for the first one in strict mode.
+func (e *Envelope) checkCuries(links map[string]any) error {
+	i := e.instance
+	if i == nil || (!i.strictMode && i.missingCurie == nil) {
+		return nil
+	}
+	curies := i.curieSnapshot()
+	for rel := range links {
+		prefix, ok := curiePrefix(rel)
+		if !ok {
+			continue
+		}
+		if _, defined := curies[prefix]; defined {
+			continue
+		}
+		if i.strictMode {
+			return fmt.Errorf("%w: rel %q, prefix %q", ErrUnregisteredCurie, rel, prefix)
+		}
+		i.missingCurie(rel)
+	}
+	return nil
+}
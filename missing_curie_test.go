@@ -0,0 +1,54 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func curiedEnvelope(inst *Instance) *Envelope {
+	e := inst.Wrap(context.Background(), map[string]any{})
+	e.AddLink(Link{Rel: "acme:orders", Href: "/orders"})
+	e.AddLink(Link{Rel: "https://rels.example.com/x", Href: "/x"})
+	return e
+}
+
+func TestMissingCurie_Strict(t *testing.T) {
+	inst := New(WithStrictMode())
+	_, err := json.Marshal(curiedEnvelope(inst))
+	if !errors.Is(err, ErrUnregisteredCurie) || !strings.Contains(err.Error(), `"acme:orders"`) {
+		t.Fatalf("expected ErrUnregisteredCurie naming the rel, got %v", err)
+	}
+
+	inst.RegisterCurie("acme", "https://docs.example.com/{rel}")
+	if _, err := json.Marshal(curiedEnvelope(inst)); err != nil {
+		t.Fatalf("expected registered prefix to marshal, got %v", err)
+	}
+}
+
+func TestMissingCurie_Handler(t *testing.T) {
+	var rels []string
+	inst := New(WithMissingCurieHandler(func(rel string) { rels = append(rels, rel) }))
+
+	if _, err := json.Marshal(curiedEnvelope(inst)); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(rels, []string{"acme:orders"}) {
+		t.Fatalf("expected handler called for acme:orders only, got %q", rels)
+	}
+
+	rels = nil
+	inst.RegisterCurie("acme", "https://docs.example.com/{rel}")
+	if _, err := json.Marshal(curiedEnvelope(inst)); err != nil {
+		t.Fatal(err)
+	}
+	if len(rels) != 0 {
+		t.Fatalf("expected no calls for a registered prefix, got %q", rels)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Module is a self-contained bundle of registrations, typically one per
+// bounded context, installed with Instance.Install.
+//
+// A Module may also implement interface{ Name() string }; the name is used
+// to attribute errors. Otherwise the module's type is used.
+type Module interface {
+	RegisterHAL(i *Instance) error
+}
+
+// ModuleFunc adapts a function to the Module interface.
+//
+// # Example
+//
+//	billing := hal.ModuleFunc(func(i *hal.Instance) error {
+//	    hal.RegisterInstance(i, invoiceLinks)
+//	    return i.RegisterCurieE("bil", "https://docs.example.com/billing/{rel}")
+//	})
+type ModuleFunc func(i *Instance) error
+
+// RegisterHAL calls f(i).
+func (f ModuleFunc) RegisterHAL(i *Instance) error {
+	return f(i)
+}
+
+// Install applies modules to the instance in order. A failing module does
+// not stop the others; all errors are returned joined, each naming its
+// module and position.
+//
+// A module redefining a CURIE prefix defined before it with a different
+// href, or removing it, as SetCuries does, fails with an error wrapping
+// ErrCurieConflict, and the earlier definition is restored.
+//
+// # Example
+//
+//	if err := inst.Install(users.HAL{}, billing.HAL{}, orders.HAL{}); err != nil {
+//	    log.Fatal(err)
+//	}
+func (i *Instance) Install(modules ...Module) error {
+	var errs []error
+	for idx, m := range modules {
+		before := i.Curies()
+		err := m.RegisterHAL(i)
+		if conflict := i.restoreCuries(before); conflict != nil {
+			err = errors.Join(err, conflict)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("hal: module %d (%s): %w", idx, moduleName(m), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// restoreCuries restores the definitions of before that were changed or
+// removed since, and reports them.
+func (i *Instance) restoreCuries(before map[string]string) error {
+	after := i.Curies()
+	prefixes := make([]string, 0, len(before))
+	for prefix := range before {
+		prefixes = append(prefixes, prefix)
+	}
+	slices.Sort(prefixes)
+
+	var errs []error
+	for _, prefix := range prefixes {
+		href, ok := after[prefix]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("%w: %q removed, keeping %s", ErrCurieConflict, prefix, before[prefix]))
+		case href != before[prefix]:
+			errs = append(errs, fmt.Errorf("%w: %q redefined as %s, keeping %s", ErrCurieConflict, prefix, href, before[prefix]))
+		default:
+			continue
+		}
+		i.setCurie(prefix, before[prefix])
+	}
+	return errors.Join(errs...)
+}
+
+func moduleName(m Module) string {
+	if n, ok := m.(interface{ Name() string }); ok {
+		return n.Name()
+	}
+	return fmt.Sprintf("%T", m)
+}
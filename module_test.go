@@ -0,0 +1,114 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"strings"
+	"testing"
+)
+
+// usersModule registers user links and the shared "acme" curie.
+type usersModule struct{}
+
+func (usersModule) Name() string { return "users" }
+
+func (usersModule) RegisterHAL(i *Instance) error {
+	RegisterInstance(i, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	i.RegisterCurie("acme", "https://docs.example.com/users/{rel}")
+	return nil
+}
+
+// curieModule registers a curie.
+func curieModule(prefix, href string) ModuleFunc {
+	return func(i *Instance) error {
+		return i.RegisterCurieE(prefix, href)
+	}
+}
+
+func TestInstall_AppliesModules(t *testing.T) {
+	inst := New()
+	if err := inst.Install(usersModule{}, curieModule("bil", "https://docs.example.com/billing/{rel}"), curieModule("acme", "https://docs.example.com/users/{rel}")); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(inst.Wrap(context.Background(), &collectionUser{ID: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"id":1,"_links":{"self":{"href":"/users/1"}}}` {
+		t.Fatalf("expected module generator to be registered, got %s", b)
+	}
+	if len(inst.Curies()) != 2 {
+		t.Fatalf("expected curies from both modules, got %v", inst.Curies())
+	}
+}
+
+func TestInstall_AggregatesErrorsWithAttribution(t *testing.T) {
+	errCustom := errors.New("custom failure")
+	inst := New()
+
+	err := inst.Install(
+		usersModule{},
+		curieModule("acme", "https://docs.example.com/orders/{rel}"),
+		ModuleFunc(func(*Instance) error { return errCustom }),
+		ModuleFunc(func(i *Instance) error { return i.SetCuries(map[string]string{"x": "no placeholder"}) }),
+	)
+
+	if !errors.Is(err, errCustom) || !errors.Is(err, ErrInvalidCurie) || !errors.Is(err, ErrCurieConflict) {
+		t.Fatalf("expected all module errors to be joined, got %v", err)
+	}
+	msg := err.Error()
+	for _, want := range []string{
+		`module 1 (hal.ModuleFunc): hal: conflicting curie: "acme" redefined as https://docs.example.com/orders/{rel}`,
+		"module 2 (hal.ModuleFunc): custom failure",
+		"module 3 (hal.ModuleFunc)",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected %q in error, got %q", want, msg)
+		}
+	}
+	if strings.Contains(msg, "module 0") {
+		t.Errorf("expected no error for the successful module, got %q", msg)
+	}
+}
+
+func TestInstall_CurieConflictKeepsEarlierDefinition(t *testing.T) {
+	inst := New()
+	err := inst.Install(
+		usersModule{},
+		curieModule("bil", "https://docs.example.com/billing/{rel}"),
+		ModuleFunc(func(i *Instance) error {
+			return i.SetCuries(map[string]string{"ord": "https://docs.example.com/orders/{rel}"})
+		}),
+	)
+	if !errors.Is(err, ErrCurieConflict) || !strings.Contains(err.Error(), `module 2 (hal.ModuleFunc): hal: conflicting curie: "acme" removed`) {
+		t.Fatalf("expected the removed curies to be reported, got %v", err)
+	}
+	want := map[string]string{
+		"acme": "https://docs.example.com/users/{rel}",
+		"bil":  "https://docs.example.com/billing/{rel}",
+		"ord":  "https://docs.example.com/orders/{rel}",
+	}
+	if got := inst.Curies(); !maps.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestInstall_UsesModuleName(t *testing.T) {
+	err := New().Install(failingModule{})
+	if err == nil || !strings.Contains(err.Error(), "module 0 (billing)") {
+		t.Fatalf("expected module name in error, got %v", err)
+	}
+}
+
+type failingModule struct{}
+
+func (failingModule) Name() string                { return "billing" }
+func (failingModule) RegisterHAL(*Instance) error { return errBoom }
@@ -0,0 +1,138 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// OutputMode is the representation chosen by Negotiate.
+type OutputMode int
+
+const (
+	// OutputHAL serves the full HAL document as application/hal+json.
+	OutputHAL OutputMode = iota
+	// OutputJSON serves the bare data, without _links or _embedded, as
+	// application/json.
+	OutputJSON
+	// OutputNotAcceptable means the client accepts neither representation.
+	OutputNotAcceptable
+)
+
+// String returns the mode's name.
+func (m OutputMode) String() string {
+	switch m {
+	case OutputHAL:
+		return "hal"
+	case OutputJSON:
+		return "json"
+	case OutputNotAcceptable:
+		return "not acceptable"
+	}
+	return "OutputMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// JSONMediaType is the Content-Type written by Respond for OutputJSON.
+const JSONMediaType = "application/json; charset=utf-8"
+
+// Negotiate picks the representation for r from its Accept header, honoring
+// q-values. Each media type is weighted by the most specific range matching
+// it: "application/hal+json" is matched by itself, "application/*" and
+// "*/*", while "application/json" is matched by itself, "application/*" and
+// "*/*". HAL is chosen when its weight is at least that of plain JSON, so a
+// missing Accept header or a bare "*/*" yields OutputHAL, and
+// "application/json" alone yields OutputJSON. When both weights are zero the
+// result is OutputNotAcceptable. An explicit "application/prs.hal-forms+json"
+// range counts as HAL.
+//
+// # Example
+//
+//	// Accept: application/json;q=0.9, application/hal+json
+//	hal.Negotiate(r) // OutputHAL
+func Negotiate(r *http.Request) OutputMode {
+	accept := r.Header.Values("Accept")
+	if len(accept) == 0 {
+		return OutputHAL
+	}
+	halQ := max(acceptQuality(accept, "application", "hal+json"), formsQuality(accept))
+	jsonQ := acceptQuality(accept, "application", "json")
+	switch {
+	case halQ > 0 && halQ >= jsonQ:
+		return OutputHAL
+	case jsonQ > 0:
+		return OutputJSON
+	}
+	return OutputNotAcceptable
+}
+
+// acceptQuality returns the q-value the Accept header values give the media
+// type typ/sub, taken from the most specific matching range, or 0 if none
+// matches.
+func acceptQuality(accept []string, typ, sub string) float64 {
+	q, specificity := 0.0, -1
+	for _, header := range accept {
+		for _, rng := range strings.Split(header, ",") {
+			mediaRange, params, _ := strings.Cut(rng, ";")
+			rt, rs, ok := strings.Cut(strings.TrimSpace(mediaRange), "/")
+			if !ok {
+				continue
+			}
+			var s int
+			switch {
+			case strings.EqualFold(rt, typ) && strings.EqualFold(rs, sub):
+				s = 2
+			case strings.EqualFold(rt, typ) && rs == "*":
+				s = 1
+			case rt == "*" && rs == "*":
+				s = 0
+			default:
+				continue
+			}
+			if s > specificity {
+				q, specificity = rangeQuality(params), s
+			}
+		}
+	}
+	return q
+}
+
+// acceptsForms reports whether r's Accept header names HAL-FORMS with a
+// non-zero q-value.
+func acceptsForms(r *http.Request) bool {
+	return formsQuality(r.Header.Values("Accept")) > 0
+}
+
+// formsQuality returns the q-value the Accept header values give HAL-FORMS,
+// or 0 if no range names it. Wildcard ranges do not count: clients must ask
+// for the media type explicitly.
+func formsQuality(accept []string) float64 {
+	for _, header := range accept {
+		for _, rng := range strings.Split(header, ",") {
+			mediaRange, params, _ := strings.Cut(rng, ";")
+			if strings.EqualFold(strings.TrimSpace(mediaRange), "application/prs.hal-forms+json") {
+				return rangeQuality(params)
+			}
+		}
+	}
+	return 0
+}
+
+// rangeQuality returns the q parameter among the media range params, 1 if
+// absent or malformed.
+func rangeQuality(params string) float64 {
+	for _, p := range strings.Split(params, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(p), "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(name), "q") {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || q < 0 || q > 1 {
+			return 1
+		}
+		return q
+	}
+	return 1
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   OutputMode
+	}{
+		{"", OutputHAL},
+		{"*/*", OutputHAL},
+		{"application/hal+json", OutputHAL},
+		{"application/json", OutputJSON},
+		{"application/json;q=0.9, application/hal+json", OutputHAL},
+		{"application/json, application/hal+json;q=0.5", OutputJSON},
+		{"application/json;q=0.5, */*;q=0.5", OutputHAL},
+		{"application/*", OutputHAL},
+		{"application/hal+json;q=0, application/json", OutputJSON},
+		{"application/hal+json;q=0, */*", OutputJSON},
+		{"text/html, application/xml;q=0.9", OutputNotAcceptable},
+		{"application/json;q=0", OutputNotAcceptable},
+		{"APPLICATION/HAL+JSON; profile=\"x\"; Q=0.8, application/json;q=0.7", OutputHAL},
+		{"application/prs.hal-forms+json", OutputHAL},
+		{"application/prs.hal-forms+json;q=0.5, application/json", OutputJSON},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.accept != "" {
+			r.Header.Set("Accept", tt.accept)
+		}
+		if got := Negotiate(r); got != tt.want {
+			t.Errorf("Accept %q: expected %v, got %v", tt.accept, tt.want, got)
+		}
+	}
+}
+
+func TestRespond_Negotiation(t *testing.T) {
+	inst := New(WithContentNegotiation())
+	respond := func(accept string, data any) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/users", nil)
+		r.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		inst.Respond(w, r, http.StatusOK, data)
+		return w
+	}
+	env := inst.WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(SelfLink("/users/1"))
+
+	w := respond("application/json;q=0.9, application/hal+json", env)
+	if ct := w.Header().Get("Content-Type"); ct != MediaType || w.Body.String() != `{"id":1,"_links":{"self":{"href":"/users/1"}}}` {
+		t.Fatalf("expected HAL, got %q %s", ct, w.Body)
+	}
+	if w.Header().Get("Vary") != "Accept" {
+		t.Fatalf("expected Vary: Accept, got %v", w.Header())
+	}
+
+	w = respond("application/json", env)
+	if ct := w.Header().Get("Content-Type"); ct != JSONMediaType || w.Body.String() != `{"id":1}` {
+		t.Fatalf("expected bare data, got %q %s", ct, w.Body)
+	}
+	if !env.HasLink("self") {
+		t.Fatal("expected the envelope to stay unchanged")
+	}
+
+	w = respond("application/json", []*collectionUser{{ID: 1}, {ID: 2}})
+	if w.Body.String() != `[{"id":1},{"id":2}]` {
+		t.Fatalf("expected a plain array, got %s", w.Body)
+	}
+	page := inst.Collection(context.Background(), []*collectionUser{{ID: 3}}, 1, SelfLink("/users"))
+	if w = respond("application/json", page); w.Body.String() != `[{"id":3}]` {
+		t.Fatalf("expected the page items, got %s", w.Body)
+	}
+
+	w = respond("text/html", env)
+	if w.Code != http.StatusNotAcceptable || w.Body.String() != notAcceptableBody {
+		t.Fatalf("expected 406, got %d %s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import "reflect"
+
+// WithNilGenerators makes Wrap run generators for typed nil pointers, such
+// as (*User)(nil), including ones held in an interface value. Use it when
+// generators deliberately handle nil, for example to link to a "create"
+// form when a resource does not exist yet.
+//
+// By default a typed nil pointer is wrapped without running its generator,
+// static links, resource generator or templates, so the envelope marshals to
+// {}, or to the links added with AddLink. Strict mode does not report the
+// skipped generator as missing. A nil interface value never has links
+// computed.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithNilGenerators())
+//	hal.RegisterInstance(inst, func(ctx context.Context, u *User) []hal.Link {
+//	    if u == nil {
+//	        return []hal.Link{{Rel: "create", Href: "/users"}}
+//	    }
+//	    return []hal.Link{{Rel: "self", Href: "/users/" + u.ID}}
+//	})
+func WithNilGenerators() InstanceOption {
+	return func(i *Instance) {
+		i.nilGenerators = true
+	}
+}
+
+// isNilPointer reports whether data is a typed nil pointer.
+func isNilPointer(data any) bool {
+	v := reflect.ValueOf(data)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func nilAwareInstance(calls *int, opts ...InstanceOption) *Instance {
+	inst := New(opts...)
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		*calls++
+		if u == nil {
+			return []Link{{Rel: "create", Href: "/users"}}
+		}
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	RegisterTemplates(inst, func(_ context.Context, _ *collectionUser) map[string]Template {
+		return map[string]Template{"default": {Method: http.MethodPost}}
+	})
+	return inst
+}
+
+func TestWrap_TypedNilSkipsGenerator(t *testing.T) {
+	var calls int
+	ctx := context.Background()
+	for _, inst := range []*Instance{nilAwareInstance(&calls), nilAwareInstance(&calls, WithStrictMode())} {
+		env, err := inst.WrapE(ctx, (*collectionUser)(nil))
+		if err != nil {
+			t.Fatalf("expected no strict error, got %v", err)
+		}
+		if got := marshalString(t, env); got != `{}` {
+			t.Fatalf("expected an empty document, got %s", got)
+		}
+		env.AddLink(Link{Rel: "search", Href: "/users{?q}", Templated: true})
+		if got := marshalString(t, env); got != `{"_links":{"search":{"href":"/users{?q}","templated":true}}}` {
+			t.Fatalf("expected a links-only document, got %s", got)
+		}
+	}
+	if calls != 0 {
+		t.Fatalf("expected the generator not to run, ran %d times", calls)
+	}
+}
+
+func TestWrap_TypedNilStaticLinks(t *testing.T) {
+	inst := New()
+	RegisterStatic(inst, &collectionUser{}, []Link{SelfLink("/users")})
+	if got := marshalString(t, inst.Wrap(context.Background(), (*collectionUser)(nil))); got != `{}` {
+		t.Fatalf("expected static links to be skipped, got %s", got)
+	}
+}
+
+func TestWithNilGenerators(t *testing.T) {
+	var calls int
+	inst := nilAwareInstance(&calls, WithNilGenerators())
+	want := `{"_links":{"create":{"href":"/users"}},"_templates":{"default":{"method":"POST"}}}`
+	if got := marshalString(t, inst.Clone().Wrap(context.Background(), (*collectionUser)(nil))); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the generator to run once, ran %d times", calls)
+	}
+}
+
+func TestWrap_NilInterfaceVsTypedNil(t *testing.T) {
+	ctx := context.Background()
+	var user *collectionUser
+	var boxed any = user // A typed nil inside an interface is still a *collectionUser.
+
+	tests := []struct {
+		name  string
+		data  any
+		nilOK bool
+		want  string
+		calls int
+	}{
+		{"nil interface", nil, false, `{}`, 0},
+		{"nil interface with nil generators", nil, true, `{}`, 0},
+		{"boxed typed nil", boxed, false, `{}`, 0},
+		{"boxed typed nil with nil generators", boxed, true, `{"_links":{"create":{"href":"/users"}},"_templates":{"default":{"method":"POST"}}}`, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			var opts []InstanceOption
+			if tt.nilOK {
+				opts = append(opts, WithNilGenerators())
+			}
+			got := marshalString(t, nilAwareInstance(&calls, opts...).Wrap(ctx, tt.data))
+			if got != tt.want || calls != tt.calls {
+				t.Fatalf("expected %s with %d calls, got %s with %d", tt.want, tt.calls, got, calls)
+			}
+		})
+	}
+}
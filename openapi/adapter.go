@@ -4,7 +4,11 @@
 package openapi
 
 import (
+	"reflect"
+
 	"github.com/getkin/kin-openapi/openapi3"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
 )
 
 // LinkSchemaName is the key used in the Components.Schemas map for the HAL Link object.
@@ -12,14 +16,67 @@ const (
 	LinkSchemaName = "Link"
 )
 
+// defaultItemsRel matches the hal package's default collection items rel.
+const defaultItemsRel = "items"
+
 // Adapter helps augment an OpenAPI 3.0 document with HAL semantics.
 type Adapter struct {
-	doc *openapi3.T
+	doc            *openapi3.T
+	inst           *hal.Instance
+	itemsRel       string
+	linkExtensions bool
+}
+
+// Option configures an Adapter.
+type Option func(*Adapter)
+
+// FromInstance aligns the adapter's defaults with a hal.Instance, such as the
+// embedded rel used for collection items, and lets MakeResourceFor read the
+// rels declared with hal.RegisterInstanceWithRels.
+//
+//	a := openapi.New(doc, openapi.FromInstance(inst))
+func FromInstance(inst *hal.Instance) Option {
+	return func(a *Adapter) {
+		a.inst = inst
+		a.itemsRel = inst.DefaultItemsRel()
+	}
+}
+
+// WithLinkExtensions documents the Link object with additionalProperties
+// set, for services attaching hal.Link.Extensions to their links.
+//
+//	a := openapi.New(doc, openapi.WithLinkExtensions())
+func WithLinkExtensions() Option {
+	return func(a *Adapter) {
+		a.linkExtensions = true
+	}
+}
+
+// CollectionOption configures a single MakeCollection call.
+type CollectionOption func(*collectionConfig)
+
+type collectionConfig struct {
+	itemsRel string
+}
+
+// WithItemsRel sets the embedded rel documented for the items of one
+// collection, taking precedence over the adapter default. Use it alongside
+// hal.WithItemsRel so the schema key agrees with the runtime key.
+//
+//	users := a.MakeCollection(userRef, openapi.WithItemsRel("users"))
+func WithItemsRel(rel string) CollectionOption {
+	return func(c *collectionConfig) {
+		c.itemsRel = rel
+	}
 }
 
 // New creates a new HAL OpenAPI adapter.
-func New(doc *openapi3.T) *Adapter {
-	return &Adapter{doc: doc}
+func New(doc *openapi3.T, opts ...Option) *Adapter {
+	a := &Adapter{doc: doc, itemsRel: defaultItemsRel}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 // InjectLinkSchema adds the standard HAL Link object definition to Components.Schemas.
@@ -39,9 +96,15 @@ func (a *Adapter) InjectLinkSchema() {
 		WithProperty("href", openapi3.NewStringSchema()).
 		WithProperty("templated", openapi3.NewBoolSchema()).
 		WithProperty("type", openapi3.NewStringSchema()).
-		WithProperty("title", openapi3.NewStringSchema()).
+		WithProperty("deprecation", openapi3.NewStringSchema().WithFormat("uri")).
 		WithProperty("name", openapi3.NewStringSchema()).
+		WithProperty("profile", openapi3.NewStringSchema().WithFormat("uri")).
+		WithProperty("title", openapi3.NewStringSchema()).
+		WithProperty("hreflang", openapi3.NewStringSchema()).
 		WithProperty("method", openapi3.NewStringSchema()) // Hint
+	if a.linkExtensions {
+		linkSchema.WithAnyAdditionalProperties()
+	}
 
 	a.doc.Components.Schemas[LinkSchemaName] =
 		openapi3.NewSchemaRef("", linkSchema)
@@ -56,23 +119,11 @@ func (a *Adapter) MakeResource(schema *openapi3.Schema) {
 	}
 
 	// 1. Add _links
-	oneOfSchema := &openapi3.Schema{
-		OneOf: []*openapi3.SchemaRef{
-			openapi3.NewSchemaRef("#/components/schemas/"+LinkSchemaName, nil),
-			{
-				Value: &openapi3.Schema{
-					Type:  &openapi3.Types{openapi3.TypeArray},
-					Items: openapi3.NewSchemaRef("#/components/schemas/"+LinkSchemaName, nil),
-				},
-			},
-		},
-	}
-
 	linksSchema := openapi3.NewObjectSchema()
 	linksSchema.ReadOnly = true
 	linksSchema.AdditionalProperties = openapi3.AdditionalProperties{
 		Schema: &openapi3.SchemaRef{
-			Value: oneOfSchema,
+			Value: linkValueSchema(),
 		},
 	}
 	schema.Properties["_links"] = openapi3.NewSchemaRef("", linksSchema)
@@ -84,6 +135,52 @@ func (a *Adapter) MakeResource(schema *openapi3.Schema) {
 	schema.Properties["_embedded"] = openapi3.NewSchemaRef("", embeddedSchema)
 }
 
+// MakeResourceFor is like MakeResource, but also documents the rels declared
+// for t with hal.RegisterInstanceWithRels as named properties of _links.
+// Undeclared rels remain allowed through additionalProperties. Templated rels
+// are marked with the "x-hal-templated" extension. The rels are read from the
+// instance passed with FromInstance; without one, MakeResourceFor behaves
+// like MakeResource.
+//
+//	a := openapi.New(doc, openapi.FromInstance(inst))
+//	a.MakeResourceFor(userSchema, reflect.TypeOf(&User{}))
+func (a *Adapter) MakeResourceFor(schema *openapi3.Schema, t reflect.Type) {
+	a.MakeResource(schema)
+	if a.inst == nil {
+		return
+	}
+	rels := a.inst.RelsFor(t)
+	if len(rels) == 0 {
+		return
+	}
+	linksSchema := schema.Properties["_links"].Value
+	linksSchema.Properties = make(openapi3.Schemas, len(rels))
+	for _, rel := range rels {
+		relSchema := linkValueSchema()
+		relSchema.Description = rel.Description
+		if rel.Templated {
+			relSchema.Extensions = map[string]any{"x-hal-templated": true}
+		}
+		linksSchema.Properties[rel.Rel] = openapi3.NewSchemaRef("", relSchema)
+	}
+}
+
+// linkValueSchema returns the schema of a _links member: a single Link or
+// an array of them.
+func linkValueSchema() *openapi3.Schema {
+	return &openapi3.Schema{
+		OneOf: []*openapi3.SchemaRef{
+			openapi3.NewSchemaRef("#/components/schemas/"+LinkSchemaName, nil),
+			{
+				Value: &openapi3.Schema{
+					Type:  &openapi3.Types{openapi3.TypeArray},
+					Items: openapi3.NewSchemaRef("#/components/schemas/"+LinkSchemaName, nil),
+				},
+			},
+		},
+	}
+}
+
 // MakeCollection creates a new HAL Collection Schema wrapping the provided item schema.
 // It returns a standard structure:
 //
@@ -93,7 +190,14 @@ func (a *Adapter) MakeResource(schema *openapi3.Schema) {
 //	  count: int,
 //	  total: int
 //	}
-func (a *Adapter) MakeCollection(itemSchemaRef *openapi3.SchemaRef) *openapi3.Schema {
+//
+// The items key follows the instance passed with FromInstance, if any, and
+// can be set per collection with WithItemsRel to match hal.WithItemsRel.
+func (a *Adapter) MakeCollection(itemSchemaRef *openapi3.SchemaRef, opts ...CollectionOption) *openapi3.Schema {
+	cfg := collectionConfig{itemsRel: a.itemsRel}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 	collection := openapi3.NewObjectSchema()
 
 	// Reuse MakeResource to inject _links and basic _embedded structure
@@ -105,7 +209,7 @@ func (a *Adapter) MakeCollection(itemSchemaRef *openapi3.SchemaRef) *openapi3.Sc
 		Type:  &openapi3.Types{openapi3.TypeArray},
 		Items: itemSchemaRef,
 	}
-	embeddedItems.WithProperty("items", itemsArray)
+	embeddedItems.WithProperty(cfg.itemsRel, itemsArray)
 
 	collection.Properties["_embedded"] = openapi3.NewSchemaRef("", embeddedItems)
 	collection.WithProperty("count", openapi3.NewIntegerSchema())
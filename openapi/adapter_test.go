@@ -4,9 +4,14 @@
 package openapi
 
 import (
+	"context"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
+
+	hal "github.com/Emin-ACIKGOZ/go-hal"
 )
 
 func TestInjectLinkSchema(t *testing.T) {
@@ -25,6 +30,42 @@ func TestInjectLinkSchema(t *testing.T) {
 	}
 }
 
+func TestInjectLinkSchema_MatchesLinkFields(t *testing.T) {
+	doc := &openapi3.T{}
+	New(doc).InjectLinkSchema()
+	props := doc.Components.Schemas[LinkSchemaName].Value.Properties
+
+	linkType := reflect.TypeOf(hal.Link{})
+	serialized := 0
+	for idx := 0; idx < linkType.NumField(); idx++ {
+		name, _, _ := strings.Cut(linkType.Field(idx).Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		serialized++
+		if _, ok := props[name]; !ok {
+			t.Errorf("Link schema is missing property %q", name)
+		}
+	}
+	if len(props) != serialized {
+		t.Errorf("expected %d properties, got %d", serialized, len(props))
+	}
+}
+
+func TestInjectLinkSchema_Extensions(t *testing.T) {
+	doc := &openapi3.T{}
+	New(doc).InjectLinkSchema()
+	if has := doc.Components.Schemas[LinkSchemaName].Value.AdditionalProperties.Has; has != nil && *has {
+		t.Fatal("expected additional properties to be unset by default")
+	}
+
+	doc = &openapi3.T{}
+	New(doc, WithLinkExtensions()).InjectLinkSchema()
+	if has := doc.Components.Schemas[LinkSchemaName].Value.AdditionalProperties.Has; has == nil || !*has {
+		t.Fatal("expected additionalProperties: true with WithLinkExtensions")
+	}
+}
+
 func TestMakeResource_AddsHALFields(t *testing.T) {
 	doc := &openapi3.T{
 		Components: &openapi3.Components{
@@ -73,3 +114,72 @@ func TestMakeCollection(t *testing.T) {
 		t.Fatal("_embedded.items missing in collection schema")
 	}
 }
+
+func TestMakeCollection_FromInstanceItemsRel(t *testing.T) {
+	doc := &openapi3.T{}
+	inst := hal.New(hal.WithDefaultItemsRel("results"))
+	a := New(doc, FromInstance(inst))
+	a.InjectLinkSchema()
+
+	c := a.MakeCollection(openapi3.NewSchemaRef("", openapi3.NewObjectSchema()))
+	embedded := c.Properties["_embedded"].Value
+
+	if _, ok := embedded.Properties["results"]; !ok {
+		t.Fatal("_embedded.results missing in collection schema")
+	}
+	if _, ok := embedded.Properties["items"]; ok {
+		t.Fatal("unexpected _embedded.items in collection schema")
+	}
+}
+
+func TestMakeCollection_PerCallItemsRel(t *testing.T) {
+	inst := hal.New(hal.WithDefaultItemsRel("results"))
+	a := New(&openapi3.T{}, FromInstance(inst))
+	a.InjectLinkSchema()
+
+	c := a.MakeCollection(openapi3.NewSchemaRef("", openapi3.NewObjectSchema()), WithItemsRel("users"))
+	embedded := c.Properties["_embedded"].Value
+	if _, ok := embedded.Properties["users"]; !ok {
+		t.Fatal("_embedded.users missing in collection schema")
+	}
+	if _, ok := embedded.Properties["results"]; ok {
+		t.Fatal("expected the adapter default to be overridden")
+	}
+}
+
+type relUser struct{ ID int }
+
+func TestMakeResourceFor_DeclaredRels(t *testing.T) {
+	inst := hal.New()
+	hal.RegisterInstanceWithRels(inst, func(context.Context, *relUser) []hal.Link { return nil },
+		hal.RelInfo{Rel: "self"},
+		hal.RelInfo{Rel: "orders", Templated: true, Description: "Orders placed by the user"},
+	)
+	doc := &openapi3.T{}
+	a := New(doc, FromInstance(inst))
+	a.InjectLinkSchema()
+
+	schema := openapi3.NewObjectSchema()
+	a.MakeResourceFor(schema, reflect.TypeOf(&relUser{}))
+
+	links := schema.Properties["_links"].Value
+	if len(links.Properties) != 2 {
+		t.Fatalf("expected 2 named rels, got %d", len(links.Properties))
+	}
+	orders := links.Properties["orders"].Value
+	if orders.Description != "Orders placed by the user" || orders.Extensions["x-hal-templated"] != true {
+		t.Errorf("unexpected orders schema: %+v", orders)
+	}
+	if len(orders.OneOf) != 2 {
+		t.Errorf("expected orders to be a Link or an array of Link")
+	}
+	if links.AdditionalProperties.Schema == nil {
+		t.Error("expected undeclared rels to stay allowed")
+	}
+
+	plain := openapi3.NewObjectSchema()
+	New(doc).MakeResourceFor(plain, reflect.TypeOf(&relUser{}))
+	if len(plain.Properties["_links"].Value.Properties) != 0 {
+		t.Error("expected no named rels without FromInstance")
+	}
+}
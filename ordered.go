@@ -0,0 +1,79 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"slices"
+	"strings"
+)
+
+// WithSortedOutput writes the rels of _links and _embedded with "self" first
+// and "curies" second, followed by the remaining rels in lexicographic order.
+//
+// Output is deterministic without this option too: rels are always written
+// in lexicographic order, so the same envelope marshals to the same bytes.
+// The option only moves the rels readers look for first to the front.
+// Links registered with RegisterStatic after New are ordered the same way;
+// JSON passed to WrapPrecomputed is written as given.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithSortedOutput())
+func WithSortedOutput() InstanceOption {
+	return func(i *Instance) {
+		i.sortedOutput = true
+	}
+}
+
+// sortedOutput reports whether e's instance uses WithSortedOutput.
+func (e *Envelope) sortedOutput() bool {
+	return e.instance != nil && e.instance.sortedOutput
+}
+
+// compareRels orders "self", then "curies", then other rels lexicographically.
+func compareRels(a, b string) int {
+	if pa, pb := relPriority(a), relPriority(b); pa != pb {
+		return pa - pb
+	}
+	return strings.Compare(a, b)
+}
+
+func relPriority(rel string) int {
+	switch rel {
+	case "self":
+		return 0
+	case "curies":
+		return 1
+	}
+	return 2
+}
+
+// appendObject appends the JSON encoding of m to dst. When ordered is set,
+// keys are written in compareRels order; otherwise in lexicographic order.
+func appendObject(dst []byte, m map[string]any, ordered bool) ([]byte, error) {
+	if !ordered {
+		return appendJSON(dst, m)
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, compareRels)
+
+	var err error
+	dst = append(dst, '{')
+	for idx, k := range keys {
+		if idx > 0 {
+			dst = append(dst, ',')
+		}
+		if dst, err = appendJSON(dst, k); err != nil {
+			return nil, err
+		}
+		dst = append(dst, ':')
+		if dst, err = appendJSON(dst, m[k]); err != nil {
+			return nil, err
+		}
+	}
+	return append(dst, '}'), nil
+}
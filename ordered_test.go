@@ -0,0 +1,57 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func orderedEnvelope(inst *Instance) *Envelope {
+	ctx := context.Background()
+	inst.RegisterCurie("acme", "https://docs.example.com/{rel}")
+	env := inst.Wrap(ctx, &collectionUser{ID: 1})
+	for _, rel := range []string{"zeta", "acme:orders", "alpha", "self", "beta", "next"} {
+		env.AddLink(Link{Rel: rel, Href: "/" + rel})
+	}
+	env.AddEmbedded(ctx, "b", json.RawMessage(`{"id":2}`))
+	env.AddEmbedded(ctx, "a", json.RawMessage(`{"id":3}`))
+	env.AddEmbedded(ctx, "self", json.RawMessage(`{"id":4}`))
+	return env
+}
+
+func TestMarshal_OutputIsDeterministic(t *testing.T) {
+	for name, inst := range map[string]*Instance{"default": New(), "sorted": New(WithSortedOutput())} {
+		env := orderedEnvelope(inst)
+		first := marshalString(t, env)
+		for range 100 {
+			if got := marshalString(t, env); got != first {
+				t.Fatalf("%s: output changed between runs:\n%s\n%s", name, first, got)
+			}
+		}
+	}
+}
+
+func TestWithSortedOutput_SelfAndCuriesFirst(t *testing.T) {
+	got := marshalString(t, orderedEnvelope(New(WithSortedOutput())))
+
+	want := `{"id":1,"_embedded":{"self":{"id":4},"a":{"id":3},"b":{"id":2}},"_links":{` +
+		`"self":{"href":"/self"},"curies":[{"href":"https://docs.example.com/{rel}","templated":true,"name":"acme"}],` +
+		`"acme:orders":{"href":"/acme:orders"},"alpha":{"href":"/alpha"},"beta":{"href":"/beta"},` +
+		`"next":{"href":"/next"},"zeta":{"href":"/zeta"}}}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestWithSortedOutput_StaticLinks(t *testing.T) {
+	inst := New(WithSortedOutput())
+	RegisterStatic(inst, &collectionUser{}, []Link{{Rel: "author", Href: "/a"}, {Rel: "self", Href: "/s"}})
+
+	got := marshalString(t, inst.Wrap(context.Background(), &collectionUser{ID: 1}))
+	if want := `{"id":1,"_links":{"self":{"href":"/s"},"author":{"href":"/a"}}}`; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Query parameters set by PaginationLinks and CursorLinks.
+const (
+	PageParam    = "page"
+	PerPageParam = "per_page"
+	CursorParam  = "cursor"
+)
+
+// PaginationLinks returns the self, first, prev, next and last links of
+// page (1-based) in a listing of total items, perPage at a time. Each href
+// is base with the page and per_page query parameters set; other parameters
+// of base, such as filters, are kept.
+//
+// prev is omitted on the first page and next on the last. A page beyond the
+// last still gets its self link, and prev points to the last page. Pages
+// below 1 are treated as page 1, and an empty listing has a single page.
+// If perPage is below 1, only the self link is returned, with base as is.
+// A nil base is treated as an empty relative URL.
+//
+// # Example
+//
+//	base, _ := url.Parse("/users?status=active")
+//	links := hal.PaginationLinks(base, 2, 20, 45)
+//	// self  /users?page=2&per_page=20&status=active
+//	// first /users?page=1&per_page=20&status=active
+//	// prev  /users?page=1&per_page=20&status=active
+//	// next  /users?page=3&per_page=20&status=active
+//	// last  /users?page=3&per_page=20&status=active
+func PaginationLinks(base *url.URL, page, perPage, total int) []Link {
+	if base == nil {
+		base = &url.URL{}
+	}
+	if perPage < 1 {
+		return []Link{SelfLink(base.String())}
+	}
+	page = max(page, 1)
+	last := max((total+perPage-1)/perPage, 1)
+
+	query := base.Query()
+	href := func(p int) string {
+		query.Set(PageParam, strconv.Itoa(p))
+		query.Set(PerPageParam, strconv.Itoa(perPage))
+		u := *base
+		u.RawQuery = query.Encode()
+		return u.String()
+	}
+
+	links := make([]Link, 0, 5)
+	links = append(links, SelfLink(href(page)), FirstLink(href(1)))
+	if page > 1 {
+		links = append(links, PrevLink(href(min(page-1, last))))
+	}
+	if page < last {
+		links = append(links, NextLink(href(page+1)))
+	}
+	return append(links, LastLink(href(last)))
+}
+
+// PaginatedCollection builds a CollectionPage like Collection, with the
+// links returned by PaginationLinks for page, perPage and total instead of
+// a single self link.
+//
+// # Example
+//
+//	users, total := repo.List(ctx, page, perPage)
+//	p := inst.PaginatedCollection(ctx, users, page, perPage, total, r.URL)
+func (i *Instance) PaginatedCollection(ctx context.Context, items any, page, perPage, total int, base *url.URL, opts ...CollectionOption) *CollectionPage {
+	links := PaginationLinks(base, page, perPage, total)
+	opts = append(opts[:len(opts):len(opts)], WithPageLinks(links[1:]...))
+	return i.Collection(ctx, items, total, links[0], opts...)
+}
+
+// CursorLinks returns the links of a cursor-paginated listing: self, which
+// is base as is, and next and prev with the cursor query parameter set to
+// the respective cursor. next and prev are omitted when their cursor is
+// empty. Other parameters of base are kept. A nil base is treated as an
+// empty relative URL.
+//
+// # Example
+//
+//	base, _ := url.Parse("/events?type=login&cursor=abc")
+//	links := hal.CursorLinks(base, "def", "")
+//	// self /events?type=login&cursor=abc
+//	// next /events?cursor=def&type=login
+func CursorLinks(base *url.URL, nextCursor, prevCursor string) []Link {
+	if base == nil {
+		base = &url.URL{}
+	}
+	query := base.Query()
+	href := func(cursor string) string {
+		query.Set(CursorParam, cursor)
+		u := *base
+		u.RawQuery = query.Encode()
+		return u.String()
+	}
+
+	links := []Link{SelfLink(base.String())}
+	if nextCursor != "" {
+		links = append(links, NextLink(href(nextCursor)))
+	}
+	if prevCursor != "" {
+		links = append(links, PrevLink(href(prevCursor)))
+	}
+	return links
+}
+
+// CursorCollection builds a CollectionPage like Collection, with the links
+// returned by CursorLinks. The total is unknown with cursors, so it is left
+// at zero and omitted from the output; count is the number of items.
+//
+// # Example
+//
+//	events, next, prev := repo.Page(ctx, r.URL.Query().Get("cursor"))
+//	p := inst.CursorCollection(ctx, events, next, prev, r.URL)
+func (i *Instance) CursorCollection(ctx context.Context, items any, nextCursor, prevCursor string, base *url.URL, opts ...CollectionOption) *CollectionPage {
+	links := CursorLinks(base, nextCursor, prevCursor)
+	opts = append(opts[:len(opts):len(opts)], WithPageLinks(links[1:]...))
+	page := i.Collection(ctx, items, 0, links[0], opts...)
+	page.TotalKnown = false
+	return page
+}
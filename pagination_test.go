@@ -0,0 +1,124 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestPaginationLinks(t *testing.T) {
+	base, _ := url.Parse("/users?status=active")
+	q := func(page int) string { return "/users?page=" + itoa(page) + "&per_page=20&status=active" }
+
+	tests := []struct {
+		name        string
+		page, total int
+		want        map[string]string
+	}{
+		{"first page", 1, 45, map[string]string{"self": q(1), "first": q(1), "next": q(2), "last": q(3)}},
+		{"middle page", 2, 45, map[string]string{"self": q(2), "first": q(1), "prev": q(1), "next": q(3), "last": q(3)}},
+		{"last page", 3, 45, map[string]string{"self": q(3), "first": q(1), "prev": q(2), "last": q(3)}},
+		{"exact multiple", 2, 40, map[string]string{"self": q(2), "first": q(1), "prev": q(1), "last": q(2)}},
+		{"beyond last", 7, 45, map[string]string{"self": q(7), "first": q(1), "prev": q(3), "last": q(3)}},
+		{"below first", 0, 45, map[string]string{"self": q(1), "first": q(1), "next": q(2), "last": q(3)}},
+		{"empty listing", 1, 0, map[string]string{"self": q(1), "first": q(1), "last": q(1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := PaginationLinks(base, tt.page, 20, tt.total)
+			if links[0].Rel != "self" {
+				t.Fatalf("expected self first, got %q", links[0].Rel)
+			}
+			got := make(map[string]string, len(links))
+			for _, l := range links {
+				got[l.Rel] = l.Href
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for rel, href := range tt.want {
+				if got[rel] != href {
+					t.Errorf("%s: expected %s, got %s", rel, href, got[rel])
+				}
+			}
+		})
+	}
+
+	if links := PaginationLinks(base, 2, 0, 45); len(links) != 1 || links[0].Href != "/users?status=active" {
+		t.Fatalf("expected only the base self link without perPage, got %v", links)
+	}
+	if links := PaginationLinks(nil, 1, 10, 5); links[0].Href != "?page=1&per_page=10" {
+		t.Fatalf("expected a query-only href for a nil base, got %s", links[0].Href)
+	}
+}
+
+func TestPaginatedCollection(t *testing.T) {
+	inst := New()
+	base, _ := url.Parse("https://api.example.com/users?status=active")
+	page := inst.PaginatedCollection(context.Background(), []collectionUser{{ID: 3}, {ID: 4}}, 2, 2, 5, base, WithItemsRel("users"))
+
+	prefix := "https://api.example.com/users?page="
+	for rel, want := range map[string]string{
+		"self":  prefix + "2&per_page=2&status=active",
+		"first": prefix + "1&per_page=2&status=active",
+		"prev":  prefix + "1&per_page=2&status=active",
+		"next":  prefix + "3&per_page=2&status=active",
+		"last":  prefix + "3&per_page=2&status=active",
+	} {
+		if l, _ := page.Links[rel].(Link); l.Href != want {
+			t.Errorf("%s: expected %s, got %v", rel, want, page.Links[rel])
+		}
+	}
+	if got := marshalString(t, page); !strings.Contains(got, `"_embedded":{"users":[{"id":3},{"id":4}]},"count":2,"total":5}`) {
+		t.Errorf("expected the items and counts, got %s", got)
+	}
+}
+
+func TestCursorLinks(t *testing.T) {
+	base, _ := url.Parse("/events?type=login&cursor=abc")
+	tests := []struct {
+		name, next, prev string
+		want             map[string]string
+	}{
+		{"both", "def", "zyx", map[string]string{
+			"self": "/events?type=login&cursor=abc",
+			"next": "/events?cursor=def&type=login",
+			"prev": "/events?cursor=zyx&type=login",
+		}},
+		{"last page", "", "zyx", map[string]string{
+			"self": "/events?type=login&cursor=abc",
+			"prev": "/events?cursor=zyx&type=login",
+		}},
+		{"single page", "", "", map[string]string{"self": "/events?type=login&cursor=abc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links := CursorLinks(base, tt.next, tt.prev)
+			if len(links) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, links)
+			}
+			for _, l := range links {
+				if tt.want[l.Rel] != l.Href {
+					t.Errorf("%s: expected %s, got %s", l.Rel, tt.want[l.Rel], l.Href)
+				}
+			}
+		})
+	}
+}
+
+func TestCursorCollection(t *testing.T) {
+	inst := New()
+	base, _ := url.Parse("/users")
+	page := inst.CursorCollection(context.Background(), []collectionUser{{ID: 1}, {ID: 2}}, "opaque/2", "", base)
+
+	got := marshalString(t, page)
+	want := `{"_links":{"next":{"href":"/users?cursor=opaque%2F2"},"self":{"href":"/users"}},` +
+		`"_embedded":{"items":[{"id":1},{"id":2}]},"count":2}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
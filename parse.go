@@ -0,0 +1,202 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+
+	json "github.com/goccy/go-json"
+)
+
+// Resource is a decoded HAL document, as received by a client. It is the
+// reading counterpart of Envelope: _links and _embedded are split from the
+// resource's own properties.
+type Resource struct {
+	// Links holds the document's links keyed by rel. Every rel maps to a
+	// slice, whether the document holds a single link object or an array,
+	// and each Link has its Rel set. Curies are under the "curies" rel.
+	Links map[string][]Link
+
+	// Embedded holds the raw JSON of each embedded rel: an object, or an
+	// array of objects. Decode it with Parse or json.Unmarshal.
+	Embedded map[string]json.RawMessage
+
+	// Properties holds the remaining members of the document.
+	Properties map[string]json.RawMessage
+
+	// base is the URL relative hrefs resolve against; set by Client.
+	base *url.URL
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It fails if the document is not
+// a JSON object or if _links or _embedded is malformed.
+func (r *Resource) UnmarshalJSON(b []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(b, &members); err != nil {
+		return err
+	}
+	res := Resource{Properties: make(map[string]json.RawMessage, len(members))}
+	for k, raw := range members {
+		switch k {
+		case "_links":
+			links, err := parseLinks(raw)
+			if err != nil {
+				return fmt.Errorf("hal: parsing _links: %w", err)
+			}
+			res.Links = links
+		case "_embedded":
+			if err := json.Unmarshal(raw, &res.Embedded); err != nil {
+				return fmt.Errorf("hal: parsing _embedded: %w", err)
+			}
+		default:
+			res.Properties[k] = raw
+		}
+	}
+	*r = res
+	return nil
+}
+
+// parseLinks decodes a _links object, accepting a link object or an array of
+// them for each rel.
+func parseLinks(raw json.RawMessage) (map[string][]Link, error) {
+	var rels map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &rels); err != nil {
+		return nil, err
+	}
+	out := make(map[string][]Link, len(rels))
+	for rel, v := range rels {
+		var links []Link
+		if v = bytes.TrimLeft(v, " \t\r\n"); len(v) > 0 && v[0] == '[' {
+			if err := json.Unmarshal(v, &links); err != nil {
+				return nil, fmt.Errorf("rel %q: %w", rel, err)
+			}
+		} else {
+			var l Link
+			if err := json.Unmarshal(v, &l); err != nil {
+				return nil, fmt.Errorf("rel %q: %w", rel, err)
+			}
+			links = []Link{l}
+		}
+		for idx := range links {
+			links[idx].Rel = rel
+		}
+		out[rel] = links
+	}
+	return out, nil
+}
+
+// Parse decodes a HAL document, returning its non-HAL members decoded into
+// a T along with the Resource holding its links and embedded resources.
+// Members named _links or _embedded are never passed to T.
+//
+// # Example
+//
+//	user, res, err := hal.Parse[User](body)
+//	if err != nil {
+//	    return err
+//	}
+//	orders, _ := res.ResolveRel("acme:orders")
+func Parse[T any](data []byte) (T, *Resource, error) {
+	var v T
+	res := new(Resource)
+	if err := json.Unmarshal(data, res); err != nil {
+		return v, nil, err
+	}
+	props, err := json.Marshal(res.Properties)
+	if err != nil {
+		return v, nil, err
+	}
+	if err := json.Unmarshal(props, &v); err != nil {
+		return v, nil, fmt.Errorf("hal: decoding %T: %w", v, err)
+	}
+	return v, res, nil
+}
+
+// ResolveRel expands a curied rel, such as "acme:orders", into the URI its
+// prefix stands for, using the document's own curies. It reports false if
+// rel has no prefix or the document defines no curie for it.
+//
+// # Example
+//
+//	// "curies": [{"name": "acme", "href": "https://docs.acme.com/rels/{rel}", "templated": true}]
+//	uri, ok := res.ResolveRel("acme:orders")
+//	// uri == "https://docs.acme.com/rels/orders"
+func (r *Resource) ResolveRel(rel string) (string, bool) {
+	prefix, reference, ok := strings.Cut(rel, ":")
+	if !ok || prefix == "" {
+		return "", false
+	}
+	for _, c := range r.Links["curies"] {
+		if c.Name == prefix && strings.Contains(c.Href, curieRelPlaceholder) {
+			return strings.ReplaceAll(c.Href, curieRelPlaceholder, reference), true
+		}
+	}
+	return "", false
+}
+
+// EmbeddedAs decodes the resources embedded in r under rel into a slice of
+// T, whether the document holds a single object or an array. T may be
+// Resource itself, to read the links and embedded resources of each item;
+// their relative hrefs resolve like those of r (see Client.Follow).
+// It returns an error wrapping ErrEmbeddedNotFound if r has no such rel, and
+// one wrapping ErrEmbeddedShape if the value is not an object or an array
+// of objects, or an item does not decode into T.
+//
+// # Example
+//
+//	_, res, err := hal.Parse[struct{ Total int }](body)
+//	users, err := hal.EmbeddedAs[User](res, "items")
+//	items, err := hal.EmbeddedAs[hal.Resource](res, "items")
+//	self := items[0].Links["self"]
+func EmbeddedAs[T any](r *Resource, rel string) ([]T, error) {
+	raw, ok := r.Embedded[rel]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrEmbeddedNotFound, rel)
+	}
+	var items []json.RawMessage
+	if raw = bytes.TrimLeft(raw, " \t\r\n"); len(raw) > 0 && raw[0] == '[' {
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, fmt.Errorf("%w under %q: %w", ErrEmbeddedShape, rel, err)
+		}
+	} else {
+		items = []json.RawMessage{raw}
+	}
+	out := make([]T, len(items))
+	for idx, item := range items {
+		if item = bytes.TrimLeft(item, " \t\r\n"); len(item) == 0 || item[0] != '{' {
+			return nil, fmt.Errorf("%w under %q: item %d is not an object", ErrEmbeddedShape, rel, idx)
+		}
+		if err := json.Unmarshal(item, &out[idx]); err != nil {
+			return nil, fmt.Errorf("%w under %q: item %d: %w", ErrEmbeddedShape, rel, idx, err)
+		}
+		if res, ok := any(&out[idx]).(*Resource); ok && r.base != nil {
+			res.setBase(r.base)
+		}
+	}
+	return out, nil
+}
+
+// LinkByName returns the link under rel whose Name equals name.
+func (r *Resource) LinkByName(rel, name string) (Link, bool) {
+	for _, l := range r.Links[rel] {
+		if l.Name == name {
+			return l, true
+		}
+	}
+	return Link{}, false
+}
+
+// setBase sets the URL relative hrefs resolve against: the resource's self
+// link, if it has exactly one, resolved against u, or u itself.
+func (r *Resource) setBase(u *url.URL) {
+	r.base = u
+	if self := r.Links["self"]; len(self) == 1 && !self[0].Templated {
+		if ref, err := url.Parse(self[0].Href); err == nil {
+			r.base = u.ResolveReference(ref)
+		}
+	}
+}
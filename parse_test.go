@@ -0,0 +1,238 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type parseOrder struct {
+	ID     int      `json:"id"`
+	Status string   `json:"status"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+func parsingInstance() *Instance {
+	inst := embeddingInstance(WithCuries(map[string]string{"acme": "https://docs.acme.com/rels/{rel}"}))
+	RegisterInstance(inst, func(_ context.Context, o *parseOrder) []Link {
+		return []Link{
+			{Rel: "self", Href: "/orders/" + itoa(o.ID)},
+			{Rel: "acme:items", Href: "/orders/" + itoa(o.ID) + "/items"},
+			{Rel: "item", Href: "/items/1", Title: "First"},
+			{Rel: "item", Href: "/items/2", Extensions: map[string]any{"quantity": 2.0}},
+			{Rel: "find", Href: "/orders{?q}", Templated: true},
+		}
+	})
+	return inst
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	order := parseOrder{ID: 7, Status: "shipped", Tags: []string{"gift"}}
+	env := parsingInstance().Wrap(ctx, &order)
+	env.AddEmbedded(ctx, "comments", &embeddedComment{Text: "a"})
+	env.AddEmbedded(ctx, "comments", &embeddedComment{Text: "b"})
+	data, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, res, err := Parse[parseOrder](data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if !reflect.DeepEqual(got, order) {
+		t.Fatalf("expected %+v, got %+v", order, got)
+	}
+	for rel, want := range env.Links() {
+		if !reflect.DeepEqual(res.Links[rel], want) {
+			t.Errorf("%s: expected %+v, got %+v", rel, want, res.Links[rel])
+		}
+	}
+	if curies := res.Links["curies"]; len(curies) != 1 || curies[0].Name != "acme" || curies[0].Rel != "curies" {
+		t.Errorf("expected the acme curie, got %+v", curies)
+	}
+	if len(res.Properties) != 3 {
+		t.Errorf("expected only the order's members in Properties, got %v", res.Properties)
+	}
+
+	var comments []json.RawMessage
+	if err := json.Unmarshal(res.Embedded["comments"], &comments); err != nil || len(comments) != 2 {
+		t.Fatalf("expected two embedded comments, got %s (%v)", res.Embedded["comments"], err)
+	}
+	comment, commentRes, err := Parse[embeddedComment](comments[1])
+	if err != nil || comment.Text != "b" {
+		t.Fatalf("expected comment b, got %+v (%v)", comment, err)
+	}
+	if self := commentRes.Links["self"]; len(self) != 1 || self[0].Href != "/comments/b" {
+		t.Errorf("expected the comment's self link, got %+v", self)
+	}
+}
+
+func TestParse_CollectionRoundTrip(t *testing.T) {
+	inst := parsingInstance()
+	page := inst.Collection(context.Background(), []parseOrder{{ID: 1, Status: "new"}, {ID: 2, Status: "paid"}}, 10, SelfLink("/orders"), WithItemsRel("acme:orders"))
+	data, err := json.Marshal(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counts, res, err := Parse[struct{ Count, Total int }](data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if counts.Count != 2 || counts.Total != 10 {
+		t.Errorf("expected count 2 and total 10, got %+v", counts)
+	}
+	var orders []parseOrder
+	if err := json.Unmarshal(res.Embedded["acme:orders"], &orders); err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 2 || orders[1].Status != "paid" {
+		t.Errorf("expected both orders, got %+v", orders)
+	}
+	if uri, ok := res.ResolveRel("acme:orders"); !ok || uri != "https://docs.acme.com/rels/orders" {
+		t.Errorf("expected the items rel to resolve, got %q, %v", uri, ok)
+	}
+}
+
+func TestResource_UnmarshalJSON_NormalizesLinks(t *testing.T) {
+	doc := `{
+		"_links": {
+			"self": {"href": "/a"},
+			"item": [ {"href": "/b"}, {"href": "/c", "name": "c"} ],
+			"empty": []
+		},
+		"_embedded": {"owner": {"name": "x"}},
+		"id": 1
+	}`
+	var res Resource
+	if err := json.Unmarshal([]byte(doc), &res); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]Link{
+		"self":  {{Rel: "self", Href: "/a"}},
+		"item":  {{Rel: "item", Href: "/b"}, {Rel: "item", Href: "/c", Name: "c"}},
+		"empty": {},
+	}
+	if !reflect.DeepEqual(res.Links, want) {
+		t.Fatalf("expected %+v, got %+v", want, res.Links)
+	}
+	if string(res.Embedded["owner"]) != `{"name": "x"}` {
+		t.Errorf("expected the raw owner, got %s", res.Embedded["owner"])
+	}
+	if string(res.Properties["id"]) != "1" || len(res.Properties) != 1 {
+		t.Errorf("expected only id in Properties, got %v", res.Properties)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for name, doc := range map[string]string{
+		"not an object":   `[1, 2]`,
+		"links array":     `{"_links": []}`,
+		"bad link":        `{"_links": {"self": "/a"}}`,
+		"bad embedded":    `{"_embedded": "x"}`,
+		"mismatched type": `{"id": "seven"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, res, err := Parse[parseOrder]([]byte(doc)); err == nil {
+				t.Fatalf("expected an error, got %+v", res)
+			}
+		})
+	}
+}
+
+func TestResource_ResolveRel(t *testing.T) {
+	res := &Resource{Links: map[string][]Link{"curies": {
+		{Rel: "curies", Name: "acme", Href: "https://docs.acme.com/rels/{rel}", Templated: true},
+		{Rel: "curies", Name: "bad", Href: "https://docs.acme.com/rels"},
+	}}}
+	tests := []struct {
+		rel, want string
+		ok        bool
+	}{
+		{"acme:orders", "https://docs.acme.com/rels/orders", true},
+		{"other:orders", "", false},
+		{"bad:orders", "", false},
+		{"orders", "", false},
+		{":orders", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := res.ResolveRel(tt.rel); got != tt.want || ok != tt.ok {
+			t.Errorf("%s: expected %q, %v, got %q, %v", tt.rel, tt.want, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestEmbeddedAs_CollectionRoundTrip(t *testing.T) {
+	inst := parsingInstance()
+	want := []parseOrder{{ID: 1, Status: "new"}, {ID: 2, Status: "paid", Tags: []string{"x"}}}
+	data, err := json.Marshal(inst.Collection(context.Background(), want, 2, SelfLink("/orders")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, res, err := Parse[struct{}](data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orders, err := EmbeddedAs[parseOrder](res, "items")
+	if err != nil {
+		t.Fatalf("EmbeddedAs: %v", err)
+	}
+	if !reflect.DeepEqual(orders, want) {
+		t.Fatalf("expected %+v, got %+v", want, orders)
+	}
+	// The items' curies are hoisted to the page.
+	if uri, ok := res.ResolveRel("acme:items"); !ok || uri != "https://docs.acme.com/rels/items" {
+		t.Errorf("expected the page to define the items' curie, got %q", uri)
+	}
+	items, err := EmbeddedAs[Resource](res, "items")
+	if err != nil {
+		t.Fatalf("EmbeddedAs: %v", err)
+	}
+	for idx, item := range items {
+		if self := item.Links["self"]; len(self) != 1 || self[0].Href != "/orders/"+itoa(want[idx].ID) {
+			t.Errorf("item %d: expected its self link, got %+v", idx, self)
+		}
+		if len(item.Links["acme:items"]) != 1 {
+			t.Errorf("item %d: expected its acme:items link, got %+v", idx, item.Links)
+		}
+	}
+}
+
+func TestEmbeddedAs_SingleObject(t *testing.T) {
+	var res Resource
+	if err := json.Unmarshal([]byte(`{"_embedded": {"owner": {"id": 3, "_embedded": {"manager": [{"id": 4}]}}}}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	owners, err := EmbeddedAs[Resource](&res, "owner")
+	if err != nil || len(owners) != 1 {
+		t.Fatalf("expected one owner, got %v (%v)", owners, err)
+	}
+	managers, err := EmbeddedAs[collectionUser](&owners[0], "manager")
+	if err != nil || len(managers) != 1 || managers[0].ID != 4 {
+		t.Fatalf("expected the nested manager, got %v (%v)", managers, err)
+	}
+}
+
+func TestEmbeddedAs_Errors(t *testing.T) {
+	var res Resource
+	doc := `{"_embedded": {"scalar": 1, "mixed": [{"id": 1}, "x"], "wrong": {"id": "one"}, "null": null}}`
+	if err := json.Unmarshal([]byte(doc), &res); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := EmbeddedAs[collectionUser](&res, "missing"); !errors.Is(err, ErrEmbeddedNotFound) {
+		t.Errorf("missing: expected ErrEmbeddedNotFound, got %v", err)
+	}
+	for _, rel := range []string{"scalar", "mixed", "wrong", "null"} {
+		if _, err := EmbeddedAs[collectionUser](&res, rel); !errors.Is(err, ErrEmbeddedShape) || errors.Is(err, ErrEmbeddedNotFound) {
+			t.Errorf("%s: expected ErrEmbeddedShape, got %v", rel, err)
+		}
+	}
+}
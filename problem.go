@@ -0,0 +1,374 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	json "github.com/goccy/go-json"
+)
+
+// ProblemMediaType is the Content-Type written by RespondProblem.
+const ProblemMediaType = "application/problem+json"
+
+// aboutBlank is the problem type assumed when none is given (RFC 7807,
+// section 4.2).
+const aboutBlank = "about:blank"
+
+// internalProblemBody is written by RespondProblem when a problem cannot be
+// rendered.
+const internalProblemBody = `{"type":"about:blank","title":"Internal Server Error","status":500}`
+
+// Problem is an error rendered as an RFC 7807 problem details document,
+// with links under _links as an extension member. It implements error, so
+// handlers can return it, wrapped or not, and leave the response to
+// RespondProblem.
+//
+// A Problem built with NewProblem carries the links of the generator
+// registered for *Problem, if any. Links can also be added with AddLink,
+// including to a Problem literal.
+//
+// # Example
+//
+//	hal.RegisterInstance(inst, func(ctx context.Context, p *hal.Problem) []hal.Link {
+//	    return []hal.Link{{Rel: "help", Href: "/docs/errors"}}
+//	})
+type Problem struct {
+	Type     string // URI reference identifying the problem type; "about:blank" if empty
+	Title    string // Short summary of the problem type
+	Status   int    // HTTP status code
+	Detail   string // Explanation specific to this occurrence
+	Instance string // URI reference identifying this occurrence
+
+	// Extensions are additional members, written after the standard ones.
+	// Keys must not start with "_" or name a standard member.
+	Extensions map[string]any
+
+	cause error
+	env   *Envelope
+}
+
+// problemData is the Data of a Problem's envelope. It has its own
+// MarshalJSON, so the envelope does not call back into Problem.MarshalJSON.
+type problemData Problem
+
+// ProblemOption configures NewProblem.
+type ProblemOption func(*Problem)
+
+// ProblemType sets the type member, a URI reference identifying the problem
+// type.
+func ProblemType(uri string) ProblemOption {
+	return func(p *Problem) {
+		p.Type = uri
+	}
+}
+
+// ProblemTitle sets the title member. It defaults to the status text.
+func ProblemTitle(title string) ProblemOption {
+	return func(p *Problem) {
+		p.Title = title
+	}
+}
+
+// ProblemInstance sets the instance member, a URI reference identifying this
+// occurrence of the problem.
+func ProblemInstance(uri string) ProblemOption {
+	return func(p *Problem) {
+		p.Instance = uri
+	}
+}
+
+// ProblemExtension sets the extension member key to value.
+func ProblemExtension(key string, value any) ProblemOption {
+	return func(p *Problem) {
+		if p.Extensions == nil {
+			p.Extensions = make(map[string]any, 1)
+		}
+		p.Extensions[key] = value
+	}
+}
+
+// ProblemLinks adds links, such as help or describes, as if by AddLink.
+func ProblemLinks(links ...Link) ProblemOption {
+	return func(p *Problem) {
+		for _, l := range links {
+			p.AddLink(l)
+		}
+	}
+}
+
+// ProblemCause records the error that caused this one, returned by Unwrap.
+// The cause is not part of the document.
+func ProblemCause(err error) ProblemOption {
+	return func(p *Problem) {
+		p.cause = err
+	}
+}
+
+// NewProblem builds a problem details document for the given HTTP status.
+// Links are computed through the instance stored in ctx by NewContext, or
+// the DefaultInstance, so its CURIEs and link rewriting apply.
+//
+// # Example
+//
+//	return hal.NewProblem(ctx, http.StatusConflict, "order 42 has already shipped",
+//	    hal.ProblemType("https://api.example.com/problems/shipped"),
+//	    hal.ProblemLinks(hal.Link{Rel: "order", Href: "/orders/42"}),
+//	)
+func NewProblem(ctx context.Context, status int, detail string, opts ...ProblemOption) *Problem {
+	return instanceFor(ctx).NewProblem(ctx, status, detail, opts...)
+}
+
+// NewProblem builds a problem like the package-level NewProblem, with links
+// computed through i. The generator registered for *Problem, if any, runs
+// after the options are applied, so it sees the final members; links added
+// by ProblemLinks follow its links.
+func (i *Instance) NewProblem(ctx context.Context, status int, detail string, opts ...ProblemOption) *Problem {
+	p := &Problem{Status: status, Title: http.StatusText(status), Detail: detail}
+	p.env = i.WrapWith(ctx, (*problemData)(p), SkipGenerators())
+	for _, opt := range opts {
+		opt(p)
+	}
+	t := reflect.TypeOf(p)
+	r := i.resolve(t)
+	if !r.found() {
+		return p
+	}
+	env := i.wrapResolved(ctx, p, t, r)
+	i.failStrict(env)
+	if len(p.env.links) > 0 {
+		env.materializePrecomputed()
+		for rel, v := range p.env.links {
+			for _, val := range relValues(v) {
+				env.addLinkRaw(rel, val)
+			}
+		}
+	}
+	env.Data = (*problemData)(p)
+	p.env = env
+	return p
+}
+
+// AddLink adds a link to the problem's _links member.
+func (p *Problem) AddLink(l Link) {
+	if p.env == nil {
+		p.env = &Envelope{}
+	}
+	p.env.AddLink(l)
+}
+
+// Links returns the problem's links, keyed by rel.
+func (p *Problem) Links() map[string][]Link {
+	if p.env == nil {
+		return map[string][]Link{}
+	}
+	return p.env.Links()
+}
+
+// Error returns the title, followed by the detail and the cause if set.
+func (p *Problem) Error() string {
+	msg := p.Title
+	if msg == "" {
+		msg = http.StatusText(p.Status)
+	}
+	if p.Detail != "" {
+		msg += ": " + p.Detail
+	}
+	if p.cause != nil {
+		msg += ": " + p.cause.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the cause set with ProblemCause.
+func (p *Problem) Unwrap() error {
+	return p.cause
+}
+
+// StatusCode returns the Status member.
+func (p *Problem) StatusCode() int {
+	return p.Status
+}
+
+// MarshalJSON implements json.Marshaler, writing the problem details
+// document. The type member is always present, as "about:blank" when Type is
+// empty, and _links follows the extension members.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	var env Envelope
+	if p.env != nil {
+		env = *p.env
+	}
+	env.Data = (*problemData)(p)
+	return env.MarshalJSON()
+}
+
+// MarshalJSON writes the problem's members without _links.
+func (d *problemData) MarshalJSON() ([]byte, error) {
+	members := struct {
+		Type     string `json:"type"`
+		Title    string `json:"title,omitempty"`
+		Status   int    `json:"status,omitempty"`
+		Detail   string `json:"detail,omitempty"`
+		Instance string `json:"instance,omitempty"`
+	}{d.Type, d.Title, d.Status, d.Detail, d.Instance}
+	if members.Type == "" {
+		members.Type = aboutBlank
+	}
+	b, err := json.Marshal(members)
+	if err != nil || len(d.Extensions) == 0 {
+		return b, err
+	}
+	if err := checkProblemExtensions(d.Extensions); err != nil {
+		return nil, err
+	}
+	ext, err := json.Marshal(d.Extensions)
+	if err != nil {
+		return nil, fmt.Errorf("hal: marshaling problem extensions: %w", err)
+	}
+	out := make([]byte, 0, len(b)+len(ext)-1)
+	out = append(out, b[:len(b)-1]...)
+	out = append(out, ',')
+	return append(out, ext[1:]...), nil
+}
+
+// checkProblemExtensions rejects extension keys that would shadow standard
+// members or HAL reserved properties.
+func checkProblemExtensions(ext map[string]any) error {
+	var reserved []string
+	for key := range ext {
+		switch {
+		case strings.HasPrefix(key, "_"),
+			key == "type", key == "title", key == "status", key == "detail", key == "instance":
+			reserved = append(reserved, key)
+		}
+	}
+	if len(reserved) == 0 {
+		return nil
+	}
+	slices.Sort(reserved)
+	return fmt.Errorf("%w: %q", ErrReservedProblemMember, reserved)
+}
+
+// Problem converts e to a problem details document with the same status
+// and links. The message becomes the detail, the logref and path become
+// extension members, and nested errors are converted under the "errors"
+// extension member.
+//
+// # Example
+//
+//	// Serve RFC 7807 clients from code that builds vnd.error documents.
+//	if strings.Contains(r.Header.Get("Accept"), "application/problem+json") {
+//	    hal.RespondProblem(w, r, verr.Problem())
+//	}
+func (e *ErrorEnvelope) Problem() *Problem {
+	p := &Problem{
+		Status: e.status,
+		Title:  http.StatusText(e.status),
+		Detail: e.data.Message,
+		cause:  e.cause,
+	}
+	if e.data.Logref != "" {
+		ProblemExtension("logref", e.data.Logref)(p)
+	}
+	if e.data.Path != "" {
+		ProblemExtension("path", e.data.Path)(p)
+	}
+	var nested []*Problem
+	for _, n := range e.nested {
+		if n != nil {
+			nested = append(nested, n.Problem())
+		}
+	}
+	if len(nested) > 0 {
+		ProblemExtension("errors", nested)(p)
+	}
+	p.env = e.env.Clone()
+	p.env.Data = (*problemData)(p)
+	p.env.embedded = nil
+	p.env.arrayRels = nil
+	return p
+}
+
+// ErrorEnvelope converts p to a vnd.error document with the same status and
+// links. The detail, or the title if there is no detail, becomes the
+// message, and string "logref" and "path" extension members are carried
+// over. Other members are dropped.
+func (p *Problem) ErrorEnvelope() *ErrorEnvelope {
+	msg := p.Detail
+	if msg == "" {
+		msg = p.Title
+	}
+	e := &ErrorEnvelope{status: p.Status, data: &vndError{Message: msg}, cause: p.cause}
+	e.data.Logref, _ = p.Extensions["logref"].(string)
+	e.data.Path, _ = p.Extensions["path"].(string)
+	if p.env != nil {
+		e.env = p.env.Clone()
+	} else {
+		e.env = &Envelope{}
+	}
+	e.env.Data = e.data
+	e.env.arrayRels = map[string]struct{}{"errors": {}}
+	return e
+}
+
+// RespondProblem writes err as a problem details document, using the
+// instance stored in the request context by NewContext, or the
+// DefaultInstance if there is none. See Instance.RespondProblem.
+//
+// # Example
+//
+//	if err := svc.Ship(ctx, order); err != nil {
+//	    hal.RespondProblem(w, r, err)
+//	    return
+//	}
+func RespondProblem(w http.ResponseWriter, r *http.Request, err error) {
+	instanceFor(r.Context()).RespondProblem(w, r, err)
+}
+
+// RespondProblem writes err as a problem details document with Content-Type
+// ProblemMediaType. If err is or wraps a *Problem, found with errors.As,
+// that document is written; otherwise an *ErrorEnvelope is converted with
+// its Problem method. The response status is the problem's Status, or 500
+// if that is not an error status. Any other error is reported to the lint
+// hook and answered with a 500 document that does not expose it. A nil err
+// writes nothing. Responses to HEAD requests carry the headers only.
+func (i *Instance) RespondProblem(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		return
+	}
+	var p *Problem
+	if !errors.As(err, &p) {
+		var e *ErrorEnvelope
+		if errors.As(err, &e) {
+			p = e.Problem()
+		} else {
+			i.lintf("hal: responding to %s %s: %v", r.Method, r.URL.Path, err)
+			p = i.NewProblem(r.Context(), http.StatusInternalServerError, "")
+		}
+	}
+	status := p.Status
+	if status < 400 || status > 599 {
+		status = http.StatusInternalServerError
+	}
+	body, err := p.MarshalJSON()
+	if err != nil {
+		i.lintf("hal: responding to %s %s: %v", r.Method, r.URL.Path, err)
+		status, body = http.StatusInternalServerError, []byte(internalProblemBody)
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", ProblemMediaType)
+	h.Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(body)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// checkProblemDocument validates body against the members RFC 7807 defines:
+// type is a string (written even when it is about:blank), title and detail
+// are strings, status is a number matching the response status, and
+// instance is a string.
+func checkProblemDocument(t *testing.T, body []byte, status int) map[string]any {
+	t.Helper()
+	var doc map[string]any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("expected a JSON object, got %s (%v)", body, err)
+	}
+	if _, ok := doc["type"].(string); !ok {
+		t.Errorf("expected a string type member, got %v", doc["type"])
+	}
+	for _, member := range []string{"title", "detail", "instance"} {
+		if v, ok := doc[member]; ok {
+			if _, isString := v.(string); !isString {
+				t.Errorf("expected %s to be a string, got %v", member, v)
+			}
+		}
+	}
+	if got, ok := doc["status"].(float64); !ok || int(got) != status {
+		t.Errorf("expected status %d, got %v", status, doc["status"])
+	}
+	return doc
+}
+
+func TestNewProblem(t *testing.T) {
+	inst := New(WithCuries(map[string]string{"acme": "https://docs.acme.com/problems/{rel}"}))
+	ctx := NewContext(context.Background(), inst)
+
+	p := NewProblem(ctx, http.StatusConflict, "order 42 has already shipped",
+		ProblemType("https://api.acme.com/problems/shipped"),
+		ProblemInstance("/orders/42/cancel"),
+		ProblemExtension("order", 42),
+		ProblemLinks(Link{Rel: "acme:order", Href: "/orders/42"}),
+	)
+
+	want := `{"type":"https://api.acme.com/problems/shipped","title":"Conflict","status":409,` +
+		`"detail":"order 42 has already shipped","instance":"/orders/42/cancel","order":42,` +
+		`"_links":{"acme:order":{"href":"/orders/42"},` +
+		`"curies":[{"href":"https://docs.acme.com/problems/{rel}","templated":true,"name":"acme"}]}}`
+	got := marshalString(t, p)
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	checkProblemDocument(t, []byte(got), http.StatusConflict)
+	if p.Error() != "Conflict: order 42 has already shipped" {
+		t.Errorf("unexpected message %q", p.Error())
+	}
+}
+
+func TestNewProblem_Generator(t *testing.T) {
+	inst := New(WithStrictMode())
+	RegisterInstance(inst, func(_ context.Context, p *Problem) []Link {
+		return []Link{{Rel: "help", Href: "/docs/problems/" + itoa(p.Status)}}
+	})
+
+	p := inst.NewProblem(context.Background(), http.StatusNotFound, "", ProblemLinks(Link{Rel: "search", Href: "/users"}))
+	want := `{"type":"about:blank","title":"Not Found","status":404,` +
+		`"_links":{"help":{"href":"/docs/problems/404"},"search":{"href":"/users"}}}`
+	if got := marshalString(t, p); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestNewProblem_StrictWithoutGenerator(t *testing.T) {
+	p := New(WithStrictMode()).NewProblem(context.Background(), http.StatusGone, "")
+	if got := marshalString(t, p); got != `{"type":"about:blank","title":"Gone","status":410}` {
+		t.Fatalf("expected a problem without links, got %s", got)
+	}
+}
+
+func TestProblem_Literal(t *testing.T) {
+	p := &Problem{Title: "Out of credit", Status: http.StatusForbidden}
+	if got := marshalString(t, p); got != `{"type":"about:blank","title":"Out of credit","status":403}` {
+		t.Fatalf("expected the standard members only, got %s", got)
+	}
+
+	p.AddLink(Link{Rel: "account", Href: "/accounts/12"})
+	p.Detail = "balance is 30, but that costs 50"
+	want := `{"type":"about:blank","title":"Out of credit","status":403,"detail":"balance is 30, but that costs 50",` +
+		`"_links":{"account":{"href":"/accounts/12"}}}`
+	if got := marshalString(t, p); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if links := p.Links(); len(links["account"]) != 1 {
+		t.Errorf("expected the account link, got %+v", links)
+	}
+}
+
+func TestProblem_ReservedExtension(t *testing.T) {
+	for _, key := range []string{"status", "_links", "_embedded", "type"} {
+		p := &Problem{Status: http.StatusBadRequest, Extensions: map[string]any{key: 1}}
+		if _, err := p.MarshalJSON(); !errors.Is(err, ErrReservedProblemMember) {
+			t.Errorf("%s: expected ErrReservedProblemMember, got %v", key, err)
+		}
+	}
+}
+
+func TestProblem_Cause(t *testing.T) {
+	cause := errors.New("no rows")
+	p := NewProblem(context.Background(), http.StatusNotFound, "user 7", ProblemCause(cause))
+	if !errors.Is(p, cause) || p.Error() != "Not Found: user 7: no rows" {
+		t.Fatalf("expected the cause to be wrapped, got %v", p)
+	}
+	if got := marshalString(t, p); strings.Contains(got, "no rows") {
+		t.Fatalf("expected the cause to stay out of the document, got %s", got)
+	}
+}
+
+func TestErrorEnvelope_Problem(t *testing.T) {
+	ctx := context.Background()
+	field := NewError(ctx, http.StatusBadRequest, "email is invalid", ErrorPath("/email"))
+	e := NewError(ctx, http.StatusBadRequest, "validation failed",
+		ErrorLogref("42"),
+		ErrorLinks(Link{Rel: "help", Href: "/docs/validation"}),
+		NestedErrors(field),
+	)
+
+	p := e.Problem()
+	want := `{"type":"about:blank","title":"Bad Request","status":400,"detail":"validation failed",` +
+		`"errors":[{"type":"about:blank","title":"Bad Request","status":400,"detail":"email is invalid","path":"/email"}],` +
+		`"logref":"42","_links":{"help":{"href":"/docs/validation"}}}`
+	got := marshalString(t, p)
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	checkProblemDocument(t, []byte(got), http.StatusBadRequest)
+
+	back := p.ErrorEnvelope()
+	if got := marshalString(t, back); got != `{"message":"validation failed","logref":"42","_links":{"help":{"href":"/docs/validation"}}}` {
+		t.Fatalf("expected the vnd.error document back, got %s", got)
+	}
+	if back.StatusCode() != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", back.StatusCode())
+	}
+
+	p.AddLink(Link{Rel: "about", Href: "/about"})
+	if got := marshalString(t, e); strings.Contains(got, "about") {
+		t.Errorf("expected the conversion not to share links, got %s", got)
+	}
+}
+
+func TestProblem_ErrorEnvelope_TitleOnly(t *testing.T) {
+	e := (&Problem{Title: "Too many requests", Status: http.StatusTooManyRequests}).ErrorEnvelope()
+	if got := marshalString(t, e); got != `{"message":"Too many requests"}` {
+		t.Fatalf("expected the title as message, got %s", got)
+	}
+}
+
+func TestRespondProblem(t *testing.T) {
+	var lints []string
+	inst := New(WithLintHook(func(msg string) { lints = append(lints, msg) }))
+	ctx := context.Background()
+	notFound := inst.NewProblem(ctx, http.StatusNotFound, "user 7")
+	notFoundBody := `{"type":"about:blank","title":"Not Found","status":404,"detail":"user 7"}`
+
+	tests := []struct {
+		name   string
+		method string
+		err    error
+		status int
+		body   string
+	}{
+		{"problem", http.MethodGet, notFound, http.StatusNotFound, notFoundBody},
+		{"wrapped", http.MethodGet, fmt.Errorf("loading user: %w", notFound), http.StatusNotFound, notFoundBody},
+		{"vnd.error", http.MethodGet, inst.NewError(ctx, http.StatusNotFound, "user 7"), http.StatusNotFound, notFoundBody},
+		{"plain error", http.MethodGet, errors.New("db password is hunter2"), http.StatusInternalServerError,
+			`{"type":"about:blank","title":"Internal Server Error","status":500}`},
+		{"bad extension", http.MethodGet, &Problem{Status: http.StatusConflict, Extensions: map[string]any{"_links": 1}},
+			http.StatusInternalServerError, internalProblemBody},
+		{"head", http.MethodHead, notFound, http.StatusNotFound, ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			inst.RespondProblem(w, httptest.NewRequest(tt.method, "/users/7", nil), tt.err)
+
+			if w.Code != tt.status {
+				t.Errorf("expected %d, got %d", tt.status, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != ProblemMediaType {
+				t.Errorf("expected %q, got %q", ProblemMediaType, ct)
+			}
+			if got := w.Body.String(); got != tt.body {
+				t.Errorf("expected %s, got %s", tt.body, got)
+			}
+			if tt.body != "" {
+				checkProblemDocument(t, w.Body.Bytes(), tt.status)
+			}
+		})
+	}
+	if len(lints) != 2 || !strings.Contains(lints[0], "hunter2") {
+		t.Errorf("expected the plain error and the marshal failure to be linted, got %q", lints)
+	}
+}
+
+func TestRespondProblem_NilError(t *testing.T) {
+	w := httptest.NewRecorder()
+	RespondProblem(w, httptest.NewRequest(http.MethodGet, "/", nil), nil)
+	if w.Body.Len() != 0 || w.Header().Get("Content-Type") != "" {
+		t.Fatalf("expected nothing to be written, got %d %q", w.Code, w.Body.String())
+	}
+}
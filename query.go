@@ -0,0 +1,169 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// QueryOf encodes the exported fields of a struct (or pointer to struct) as
+// query parameters, typically to carry a request's filter into "self" and
+// "search" links.
+//
+// Fields are named by their `query` tag, or the Go field name if untagged;
+// a tag of "-" skips the field. With the omitempty option zero values are
+// skipped. Supported field types are strings, numbers, bools, time.Time
+// (formatted as RFC 3339) and pointers to them; slices and arrays of these
+// produce repeated parameters. Nil pointers are always skipped and untagged
+// embedded structs are flattened. Any other type yields an error wrapping
+// ErrUnsupportedQueryType.
+//
+// # Example
+//
+//	type UserFilter struct {
+//	    Status []string  `query:"status,omitempty"`
+//	    Since  time.Time `query:"since,omitempty"`
+//	    Page   int       `query:"page"`
+//	}
+//
+//	q, err := hal.QueryOf(UserFilter{Status: []string{"active"}, Page: 2})
+//	// q.Encode() == "page=2&status=active"
+func QueryOf(v any) (url.Values, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return url.Values{}, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T is not a struct", ErrUnsupportedQueryType, v)
+	}
+
+	q := url.Values{}
+	if err := encodeQueryStruct(q, rv); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// LinkWithQuery builds a link whose href is base with the query parameters of
+// filter appended (see QueryOf and HrefQ).
+//
+// # Example
+//
+//	self, err := hal.LinkWithQuery(hal.RelSelf, "/users", filter)
+func LinkWithQuery(rel, base string, filter any) (Link, error) {
+	q, err := QueryOf(filter)
+	if err != nil {
+		return Link{}, err
+	}
+	return Link{Rel: rel, Href: HrefQ(base, q)}, nil
+}
+
+func encodeQueryStruct(q url.Values, rv reflect.Value) error {
+	rt := rv.Type()
+	for idx := 0; idx < rt.NumField(); idx++ {
+		f := rt.Field(idx)
+		tag, hasTag := f.Tag.Lookup("query")
+		if tag == "-" {
+			continue
+		}
+		fv := rv.Field(idx)
+
+		if f.Anonymous && !hasTag && baseType(f.Type).Kind() == reflect.Struct && baseType(f.Type) != timeType {
+			for fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := encodeQueryStruct(q, fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		omitEmpty := opts == "omitempty"
+
+		if omitEmpty && fv.IsZero() {
+			continue
+		}
+		if err := encodeQueryValue(q, name, fv); err != nil {
+			return fmt.Errorf("hal: query field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func encodeQueryValue(q url.Values, name string, fv reflect.Value) error {
+	switch fv.Kind() {
+	case reflect.Pointer:
+		if fv.IsNil() {
+			return nil
+		}
+		return encodeQueryValue(q, name, fv.Elem())
+	case reflect.Slice, reflect.Array:
+		if fv.Type().Elem().Kind() == reflect.Uint8 {
+			break // []byte has no obvious query form
+		}
+		for idx := 0; idx < fv.Len(); idx++ {
+			elem := fv.Index(idx)
+			if elem.Kind() == reflect.Pointer {
+				if elem.IsNil() {
+					continue
+				}
+				elem = elem.Elem()
+			}
+			s, err := queryScalar(elem)
+			if err != nil {
+				return err
+			}
+			q.Add(name, s)
+		}
+		return nil
+	}
+	s, err := queryScalar(fv)
+	if err != nil {
+		return err
+	}
+	q.Add(name, s)
+	return nil
+}
+
+// queryScalar formats a single parameter value.
+func queryScalar(v reflect.Value) (string, error) {
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(time.RFC3339), nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("%w %s", ErrUnsupportedQueryType, v.Type())
+}
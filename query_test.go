@@ -0,0 +1,135 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type pageFilter struct {
+	Page int `query:"page,omitempty"`
+	Size int `query:"size,omitempty"`
+}
+
+type userFilter struct {
+	pageFilter
+	Name     string     `query:"name,omitempty"`
+	Status   []string   `query:"status,omitempty"`
+	Active   bool       `query:"active"`
+	MinScore float64    `query:"min_score,omitempty"`
+	MaxAge   *uint8     `query:"max_age,omitempty"`
+	Since    time.Time  `query:"since,omitempty"`
+	Until    *time.Time `query:"until"`
+	IDs      [2]int64   `query:"id,omitempty"`
+	Internal string     `query:"-"`
+	Plain    string
+	hidden   string
+}
+
+func TestQueryOf_Kinds(t *testing.T) {
+	age := uint8(30)
+	until := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	f := &userFilter{
+		pageFilter: pageFilter{Page: 2},
+		Name:       "a&b c",
+		Status:     []string{"active", "pending"},
+		Active:     true,
+		MinScore:   0.5,
+		MaxAge:     &age,
+		Since:      time.Date(2025, 12, 31, 23, 0, 0, 0, time.FixedZone("", 3600)),
+		Until:      &until,
+		IDs:        [2]int64{7, -8},
+		Internal:   "secret",
+		Plain:      "x",
+		hidden:     "y",
+	}
+
+	q, err := QueryOf(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Plain=x&active=true&id=7&id=-8&max_age=30&min_score=0.5&name=a%26b+c&page=2" +
+		"&since=2025-12-31T23%3A00%3A00%2B01%3A00&status=active&status=pending&until=2026-01-02T03%3A04%3A05Z"
+	if got := q.Encode(); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestQueryOf_OmitEmpty(t *testing.T) {
+	q, err := QueryOf(userFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only fields without omitempty remain; the nil pointer is always skipped.
+	if got := q.Encode(); got != "Plain=&active=false" {
+		t.Fatalf("expected zero values to be omitted, got %s", got)
+	}
+}
+
+func TestQueryOf_NilAndNonStruct(t *testing.T) {
+	q, err := QueryOf((*userFilter)(nil))
+	if err != nil || len(q) != 0 {
+		t.Fatalf("expected empty values for nil pointer, got %v, %v", q, err)
+	}
+
+	if _, err := QueryOf(42); !errors.Is(err, ErrUnsupportedQueryType) {
+		t.Fatalf("expected ErrUnsupportedQueryType, got %v", err)
+	}
+}
+
+func TestQueryOf_UnsupportedKinds(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"map", struct {
+			M map[string]int `query:"m"`
+		}{M: map[string]int{"a": 1}}},
+		{"struct", struct {
+			S struct{ A int } `query:"s"`
+		}{}},
+		{"bytes", struct {
+			B []byte `query:"b"`
+		}{B: []byte("x")}},
+		{"nested slice", struct {
+			S [][]string `query:"s"`
+		}{S: [][]string{{"a"}}}},
+		{"complex", struct {
+			C complex64 `query:"c"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := QueryOf(tt.value)
+			if !errors.Is(err, ErrUnsupportedQueryType) {
+				t.Fatalf("expected ErrUnsupportedQueryType, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "query field") {
+				t.Fatalf("expected field name in error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLinkWithQuery(t *testing.T) {
+	l, err := LinkWithQuery(RelSelf, "https://api.example.com/users?sort=name", pageFilter{Page: 3, Size: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Link{Rel: "self", Href: "https://api.example.com/users?sort=name&page=3&size=20"}
+	if !l.Equal(want) {
+		t.Fatalf("expected %+v, got %+v", want, l)
+	}
+
+	if _, err := LinkWithQuery(RelSelf, "/users", "not a struct"); err == nil {
+		t.Fatal("expected error for non-struct filter")
+	}
+}
@@ -5,11 +5,14 @@ package hal
 
 import (
 	"context"
+	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
-
-	json "github.com/goccy/go-json"
+	"sync/atomic"
+	"unicode"
 )
 
 // defaultLinksCapacity is the default capacity for the links map.
@@ -37,11 +40,59 @@ type Generator func(ctx context.Context, v any) []Link
 //	// With strict mode
 //	inst := hal.New(hal.WithStrictMode())
 type Instance struct {
-	mu          sync.RWMutex
-	generators  map[reflect.Type]Generator
-	precomputed map[reflect.Type]*PrecomputedLinks // OPTIMIZATION: static pre-computed
-	curies      map[string]string
-	strictMode  bool
+	mu                sync.RWMutex
+	generators        map[reflect.Type]Generator
+	interfaces        []interfaceGenerator
+	interfaceMatches  *sync.Map                          // reflect.Type -> interfaceMatch
+	typed             sync.Map                           // typeKey[T] -> *typedResolution (see WrapInstanceT)
+	registryVersion   atomic.Uint64                      // Bumped by registryChanged
+	frozen            atomic.Pointer[frozenRegistry]     // Published by Freeze
+	precomputed       map[reflect.Type]*PrecomputedLinks // OPTIMIZATION: static pre-computed
+	curies            map[string]string
+	strictMode        bool
+	strictErrors      bool
+	strictChecks      StrictCheck
+	strictChecksSet   bool
+	strictKinds       map[reflect.Kind]struct{}
+	strictExempt      map[reflect.Type]struct{}
+	propagatePanics   bool
+	generatorRecovery func(recovered any, t reflect.Type)
+	dedupLinks        bool
+	sortLinkArrays    bool
+	compactCuries     bool
+	hoistCuries       bool
+	canonicalData     bool
+	dataMetaWins      bool
+	marshal           MarshalFunc
+	marshalers        map[reflect.Type]MarshalFunc
+	transformers      []Transformer
+	afterWrap         []func(ctx context.Context, e *Envelope)
+	baseURLResolver   func(ctx context.Context) string
+	absoluteLinks     bool
+	linkRewriters     []func(Link) Link
+	contextRewriter   func(ctx context.Context) func(Link) Link
+	negotiate         bool
+	requireSelf       bool
+	samples           []any
+	curieRefs         map[string]struct{}
+	cacheKeys         map[reflect.Type]func(any) any
+	resources         map[reflect.Type]func(context.Context, any) ([]Link, map[string]any)
+	templates         map[reflect.Type]func(context.Context, any) map[string]Template
+	relInfo           map[reflect.Type][]RelInfo
+	routes            map[string]string
+	maxEmbedDepth     int
+	autoDeref         bool
+	autoCollection    bool
+	nilGenerators     bool
+	sortedOutput      bool
+	autoTemplated     bool
+	lint              func(msg string)
+	missingCurie      func(rel string)
+	itemsRel          string
+	emitTotal         bool
+
+	arrayEmbeddedRels map[string]struct{}
+	arrayLinkRels     map[string]struct{}
 }
 
 // New creates a new HAL Instance.
@@ -55,10 +106,23 @@ func New(opts ...InstanceOption) *Instance {
 		generators:  make(map[reflect.Type]Generator),
 		precomputed: make(map[reflect.Type]*PrecomputedLinks),
 		curies:      make(map[string]string),
+		marshalers:  make(map[reflect.Type]MarshalFunc),
+		cacheKeys:   make(map[reflect.Type]func(any) any),
 	}
 	for _, opt := range opts {
 		opt(i)
 	}
+	if i.strictMode && !i.strictChecksSet {
+		i.strictChecks = CheckAll
+	}
+	for prefix, href := range i.curies {
+		if err := validateCurie(prefix, href); err != nil {
+			if i.strictMode {
+				panic(err)
+			}
+			i.lintf("%v", err)
+		}
+	}
 	return i
 }
 
@@ -96,7 +160,14 @@ func RegisterCurie(prefix, href string) {
 	DefaultInstance.RegisterCurie(prefix, href)
 }
 
-// Wrap wraps data in a HAL Envelope using the DefaultInstance.
+// RegisterCurieE registers a CURIE prefix on the DefaultInstance, returning
+// an error for invalid definitions. See Instance.RegisterCurieE.
+func RegisterCurieE(prefix, href string) error {
+	return DefaultInstance.RegisterCurieE(prefix, href)
+}
+
+// Wrap wraps data in a HAL Envelope using the instance stored in ctx by
+// NewContext, or the DefaultInstance if there is none.
 // The envelope will inject _links during JSON serialization based on registered generators.
 //
 // # Example
@@ -105,17 +176,137 @@ func RegisterCurie(prefix, href string) {
 //	env := hal.Wrap(context.Background(), user)
 //	json.Marshal(env)
 func Wrap(ctx context.Context, data any) *Envelope {
-	return DefaultInstance.Wrap(ctx, data)
+	return instanceFor(ctx).Wrap(ctx, data)
+}
+
+// WrapE wraps data like Wrap, returning an error instead of panicking in
+// strict mode. See Instance.WrapE.
+func WrapE(ctx context.Context, data any) (*Envelope, error) {
+	return instanceFor(ctx).WrapE(ctx, data)
 }
 
 // --- Instance Methods ---
 
 // RegisterCurie adds a CURIE (Compact URI) mapping to the instance.
 // These are used to shorten link relations in the output JSON.
+//
+// Invalid definitions (see RegisterCurieE) panic in strict mode. Otherwise
+// they are registered as given and reported to the lint hook.
 func (i *Instance) RegisterCurie(prefix, href string) {
+	if err := validateCurie(prefix, href); err != nil {
+		if i.strictMode {
+			panic(err)
+		}
+		i.lintf("%v", err)
+	}
+	i.setCurie(prefix, href)
+}
+
+// RegisterCurieE adds a CURIE mapping to the instance after validating it.
+// The prefix must follow the CURIE prefix grammar (a letter or underscore,
+// then letters, digits, '.', '-' or '_'), and the href must be a valid URI
+// template containing the {rel} placeholder. An invalid definition is not
+// registered and an error wrapping ErrInvalidCurie is returned.
+//
+// # Example
+//
+//	if err := inst.RegisterCurieE("acme", cfg.DocsURL+"/rels/{rel}"); err != nil {
+//	    log.Fatal(err)
+//	}
+func (i *Instance) RegisterCurieE(prefix, href string) error {
+	if err := validateCurie(prefix, href); err != nil {
+		return err
+	}
+	i.setCurie(prefix, href)
+	return nil
+}
+
+func (i *Instance) setCurie(prefix, href string) {
 	i.mu.Lock()
 	defer i.mu.Unlock()
-	i.curies[prefix] = href
+	// The map is replaced rather than mutated, so snapshots taken by
+	// concurrent marshals stay consistent (see curieSnapshot).
+	curies := maps.Clone(i.curies)
+	curies[prefix] = href
+	i.curies = curies
+	i.refreeze()
+}
+
+// SetCuries replaces all CURIE definitions of the instance at once, for
+// example when the documentation host comes from runtime configuration.
+// Concurrent marshals see either the previous or the new set, never a mix.
+//
+// Every entry must be valid as described for RegisterCurieE. If any entry is
+// invalid, an error wrapping ErrInvalidCurie is returned and the current
+// definitions are kept.
+//
+// # Example
+//
+//	err := inst.SetCuries(map[string]string{
+//	    "acme": cfg.DocsURL + "/rels/{rel}",
+//	})
+func (i *Instance) SetCuries(curies map[string]string) error {
+	for prefix, href := range curies {
+		if err := validateCurie(prefix, href); err != nil {
+			return err
+		}
+	}
+	next := make(map[string]string, len(curies))
+	maps.Copy(next, curies)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.curies = next
+	i.refreeze()
+	return nil
+}
+
+// WithCuries registers CURIE definitions at construction. Definitions are
+// validated like RegisterCurie, once all options have been applied, so
+// WithStrictMode may appear in any position. Later RegisterCurie calls for
+// the same prefix replace these.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithStrictMode(), hal.WithCuries(map[string]string{
+//	    "acme": "https://docs.example.com/rels/{rel}",
+//	}))
+func WithCuries(curies map[string]string) InstanceOption {
+	return func(i *Instance) {
+		maps.Copy(i.curies, curies)
+	}
+}
+
+// Curies returns a copy of the instance's CURIE definitions, keyed by prefix.
+func (i *Instance) Curies() map[string]string {
+	return maps.Clone(i.curieSnapshot())
+}
+
+func validateCurie(prefix, href string) error {
+	if !isCuriePrefix(prefix) {
+		return fmt.Errorf("%w: prefix %q must be a letter or underscore followed by letters, digits, '.', '-' or '_'", ErrInvalidCurie, prefix)
+	}
+	if !strings.Contains(href, curieRelPlaceholder) {
+		return fmt.Errorf("%w: href %q for prefix %q must contain %s", ErrInvalidCurie, href, prefix, curieRelPlaceholder)
+	}
+	if _, err := Expand(href, map[string]any{"rel": "x"}); err != nil {
+		return fmt.Errorf("%w: href %q for prefix %q: %w", ErrInvalidCurie, href, prefix, err)
+	}
+	return nil
+}
+
+// isCuriePrefix reports whether prefix matches the CURIE prefix grammar,
+// an XML NCName.
+func isCuriePrefix(prefix string) bool {
+	for idx, r := range prefix {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+		case idx > 0 && (unicode.IsDigit(r) || r == '.' || r == '-'):
+		default:
+			return false
+		}
+	}
+	return prefix != ""
 }
 
 // RegisterInstance registers a generator using reflection.
@@ -126,15 +317,34 @@ func (i *Instance) RegisterInstance(gen any) {
 
 // RegisterInstance binds a strongly-typed generator function to the provided Instance.
 // The generator will be invoked whenever Wrap is called with a value of type *T.
+//
+// # Interfaces
+//
+// When T is an interface type, the generator serves every type implementing
+// T that has no generator of its own; it receives a pointer to the value as
+// a T. Matches are cached per concrete type. If a type implements several
+// registered interfaces, the earliest registration wins, and strict instances
+// fail with ErrAmbiguousGenerator.
+//
+//	hal.RegisterInstance(inst, func(ctx context.Context, v *Identifiable) []hal.Link {
+//	    return []hal.Link{{Rel: "self", Href: (*v).ResourcePath()}}
+//	})
+//
+// # Maps
+//
+// When T is a map type, such as a named type for dynamic projections, Wrap
+// also runs the generator for map values of type T, passing a pointer to a
+// copy of the map header; the entries are shared. A _links or _embedded key
+// in the map is merged with the computed members (see Envelope.MarshalJSON).
+//
+//	type Projection map[string]any
+//
+//	hal.RegisterInstance(inst, func(ctx context.Context, p *Projection) []hal.Link {
+//	    return []hal.Link{{Rel: "self", Href: fmt.Sprint("/things/", (*p)["id"])}}
+//	})
+//	env := inst.Wrap(ctx, Projection{"id": 7})
 func RegisterInstance[T any](i *Instance, gen func(context.Context, *T) []Link) {
-	targetType := reflect.TypeOf((*T)(nil))
-	adapter := func(ctx context.Context, v any) []Link {
-		return gen(ctx, v.(*T))
-	}
-
-	i.mu.Lock()
-	defer i.mu.Unlock()
-	i.generators[targetType] = adapter
+	registerGenerator(i, gen, false)
 }
 
 // RegisterStatic registers pre-computed links for a type.
@@ -161,9 +371,9 @@ func RegisterStatic(i *Instance, target any, links []Link) {
 	// Pre-serialize links JSON once
 	linksMap := make(map[string]any, len(links))
 	for _, l := range links {
-		linksMap[l.Rel] = l
+		linksMap[l.Rel] = i.autoTemplate(l)
 	}
-	linksJSON, _ := json.Marshal(linksMap)
+	linksJSON, _ := appendLinks(nil, arrayLinks(linksMap, i.arrayLinkRels), i.sortedOutput)
 
 	// Wrap in _links object for easy splice
 	fullJSON := make([]byte, 0, len(linksJSON)+precomputedLinksWrapperLen+precomputedLinksPrefixLen)
@@ -176,6 +386,7 @@ func RegisterStatic(i *Instance, target any, links []Link) {
 	defer i.mu.Unlock()
 	// Store as precomputed for this type
 	i.precomputed[targetType] = &PrecomputedLinks{JSON: fullJSON}
+	i.registryChanged()
 }
 
 // PrecomputedLinks stores pre-computed links JSON
@@ -197,6 +408,8 @@ func (i *Instance) registerReflect(gen any) {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 	i.generators[targetType] = adapter
+	i.chainResource(targetType, adapter, false)
+	i.registryChanged()
 }
 
 // Wrap creates a HAL Envelope with computed links.
@@ -207,30 +420,83 @@ func (i *Instance) registerReflect(gen any) {
 //
 // If RegisterStatic was called for the data's type, links are pre-computed and this method
 // automatically uses them, providing ~60% better performance.
+//
+// # Strict Mode
+//
+// In strict mode, Wrap panics with the error WrapE would return, such as one
+// wrapping ErrNoGenerator. Use WrapE in handlers that should not panic, or
+// WithStrictErrors to have MarshalJSON return the error instead.
 func (i *Instance) Wrap(ctx context.Context, data any) *Envelope {
-	t := reflect.TypeOf(data)
+	e := i.wrap(ctx, data)
+	i.failStrict(e)
+	return e
+}
+
+// failStrict panics with the strict mode failure recorded on e, if any,
+// unless the instance reports such failures as errors (see WithStrictErrors).
+func (i *Instance) failStrict(e *Envelope) {
+	if e.strictErr != nil && !i.strictErrors {
+		panic(e.strictErr)
+	}
+}
+
+// wrap implements Wrap and WrapE. Strict mode failures are recorded in
+// e.strictErr, and the envelope is left unfinished.
+func (i *Instance) wrap(ctx context.Context, data any) *Envelope {
+	t := i.linkType(data)
+	return i.wrapResolved(ctx, data, t, i.resolve(t))
+}
 
+// wrapResolved is wrap for data whose link type t was resolved to r.
+func (i *Instance) wrapResolved(ctx context.Context, data any, t reflect.Type, r resolution) *Envelope {
+	if !i.nilGenerators && isNilPointer(data) {
+		// There is no resource to link; see WithNilGenerators.
+		r = resolution{}
+	}
+	if i.autoCollection && !r.found() {
+		if items, ok := i.collectionItems(data); ok {
+			return i.wrapCollection(ctx, items)
+		}
+	}
 	// OPTIMIZATION: Check for precomputed first
-	i.mu.RLock()
-	pre, hasPre := i.precomputed[t]
-	i.mu.RUnlock()
-	if hasPre && pre != nil {
-		return &Envelope{
+	if r.pre != nil {
+		e := &Envelope{
 			Data:            data,
 			instance:        i,
-			precomputedJSON: pre.JSON,
+			precomputedJSON: r.pre.JSON,
 		}
+		e.computeTemplates(ctx, t)
+		i.finishWrap(ctx, e)
+		return e
 	}
 
 	e := &Envelope{
 		Data:     data,
 		instance: i,
 		links:    make(map[string]any, defaultLinksCapacity),
+		baseURL:  i.baseURLFor(ctx),
+		rewriter: i.rewriterFor(ctx),
+	}
+	e.computeLinks(ctx, t, r)
+	if e.strictErr != nil {
+		return e
 	}
-	e.computeLinks(ctx)
+	e.computeTemplates(ctx, t)
+	i.finishWrap(ctx, e)
 	return e
 }
 
+// WrapOnce wraps data like Wrap, unless data is already an *Envelope created by
+// this instance, in which case it is returned unchanged. Use it where a value
+// may have been wrapped upstream, so generators with side effects (metrics,
+// permission lookups) run exactly once per resource.
+func (i *Instance) WrapOnce(ctx context.Context, data any) *Envelope {
+	if env, ok := data.(*Envelope); ok && env != nil && env.instance == i {
+		return env
+	}
+	return i.Wrap(ctx, data)
+}
+
 // WrapPrecomputed wraps data with pre-serialized links JSON.
 // This is the absolute fastest option - bypasses all generator calls.
 //
@@ -270,12 +536,66 @@ func (i *Instance) WrapRaw(data any) *Envelope {
 }
 
 func (i *Instance) lookupGenerator(t reflect.Type) (Generator, bool) {
-	i.mu.RLock()
-	defer i.mu.RUnlock()
-	gen, ok := i.generators[t]
+	gen, ok, _ := i.resolveGenerator(t)
 	return gen, ok
 }
 
+// UnregisterInstance removes the generator registered for *T on i, along with
+// static links, resource generators, templates and cache keys for that type.
+// It does nothing if no generator is registered.
+//
+// # Example
+//
+//	hal.RegisterInstance(inst, userLinks)
+//	defer hal.UnregisterInstance[User](inst)
+func UnregisterInstance[T any](i *Instance) {
+	i.Unregister(reflect.TypeOf((*T)(nil)))
+}
+
+// Unregister removes everything registered for type t: its generator, rel
+// declarations, static links, resource generator, templates and cache key.
+// Envelopes already wrapped keep their links. Unregister is safe to call
+// concurrently with Wrap.
+func (i *Instance) Unregister(t reflect.Type) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.relInfo, t)
+	if t != nil && t.Kind() == reflect.Pointer && i.removeInterface(t.Elem()) {
+		return
+	}
+	delete(i.generators, t)
+	delete(i.precomputed, t)
+	delete(i.resources, t)
+	delete(i.templates, t)
+	i.registryChanged()
+	delete(i.cacheKeys, t)
+}
+
+// Reset clears the instance's registrations: generators, rel declarations,
+// static links, resource generators, templates, cache keys, marshalers,
+// samples, routes and CURIE definitions.
+// Settings applied by options, such as strict mode and transformers, are kept.
+// Reset is meant for tests sharing an instance between cases and is safe to
+// call concurrently with Wrap.
+func (i *Instance) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.generators = make(map[reflect.Type]Generator)
+	i.interfaces = nil
+	i.interfaceMatches = nil
+	i.precomputed = make(map[reflect.Type]*PrecomputedLinks)
+	i.resources = nil
+	i.templates = nil
+	i.relInfo = nil
+	i.routes = nil
+	i.cacheKeys = make(map[reflect.Type]func(any) any)
+	i.marshalers = make(map[reflect.Type]MarshalFunc)
+	i.samples = nil
+	i.curies = make(map[string]string)
+	i.curieRefs = nil
+	i.registryChanged()
+}
+
 // RegisteredTypes returns a list of all Go types that have a generator registered.
 // This is a Read-Only introspection hook useful for adapters (like OpenAPI).
 func (i *Instance) RegisteredTypes() []reflect.Type {
@@ -288,11 +608,25 @@ func (i *Instance) RegisteredTypes() []reflect.Type {
 	return types
 }
 
-func (i *Instance) resolveCuries(links map[string]any) []Link {
+// curieSnapshot returns the current curie definitions. The map is never
+// modified after it is published, so it can be read without holding i.mu,
+// and one marshal sees a single consistent set.
+// A nil Instance has no curies, which lets Envelope literals marshal safely.
+func (i *Instance) curieSnapshot() map[string]string {
+	if i == nil {
+		return nil
+	}
+	if f := i.frozen.Load(); f != nil {
+		return f.curies
+	}
 	i.mu.RLock()
 	defer i.mu.RUnlock()
+	return i.curies
+}
 
-	if len(i.curies) == 0 || len(links) == 0 {
+// resolveCuries returns the curie links from curies used by rels in links.
+func resolveCuries(links map[string]any, curies map[string]string) []Link {
+	if len(curies) == 0 || len(links) == 0 {
 		return nil
 	}
 
@@ -300,7 +634,7 @@ func (i *Instance) resolveCuries(links map[string]any) []Link {
 	for rel := range links {
 		if idx := strings.IndexByte(rel, ':'); idx > 0 {
 			prefix := rel[:idx]
-			if href, ok := i.curies[prefix]; ok {
+			if href, ok := curies[prefix]; ok && !hasCurie(used, prefix) {
 				used = append(used, Link{
 					Rel:       "curies",
 					Name:      prefix,
@@ -310,5 +644,18 @@ func (i *Instance) resolveCuries(links map[string]any) []Link {
 			}
 		}
 	}
+	// Map iteration order is random; sort for stable output.
+	slices.SortFunc(used, func(a, b Link) int { return strings.Compare(a.Name, b.Name) })
 	return used
 }
+
+// hasCurie reports whether curies already defines prefix. Envelopes use a
+// handful of prefixes, so a linear scan beats allocating a set.
+func hasCurie(curies []Link, prefix string) bool {
+	for _, c := range curies {
+		if c.Name == prefix {
+			return true
+		}
+	}
+	return false
+}
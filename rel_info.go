@@ -0,0 +1,67 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"reflect"
+	"slices"
+)
+
+// RelInfo describes a rel a generator emits. It is documentation only: the
+// links a generator actually returns are not checked against it.
+type RelInfo struct {
+	// Rel is the link relation, such as "self" or "acme:orders".
+	Rel string
+	// Templated reports whether the link's href is a URI Template.
+	Templated bool
+	// Description optionally explains the relation to API consumers.
+	Description string
+}
+
+// RegisterWithRels registers gen for *T on the DefaultInstance together with
+// a declaration of the rels it emits. See RegisterInstanceWithRels.
+//
+// # Example
+//
+//	hal.RegisterWithRels(userLinks,
+//	    hal.RelInfo{Rel: "self"},
+//	    hal.RelInfo{Rel: "orders", Templated: true, Description: "Orders placed by the user"},
+//	)
+func RegisterWithRels[T any](gen func(context.Context, *T) []Link, rels ...RelInfo) {
+	RegisterInstanceWithRels(DefaultInstance, gen, rels...)
+}
+
+// RegisterInstanceWithRels registers gen for *T on i like RegisterInstance and
+// records rels as the relations it emits. The declaration replaces any
+// previous one for the type and can be read back with RelsFor, for example by
+// the openapi adapter to document the _links object.
+func RegisterInstanceWithRels[T any](i *Instance, gen func(context.Context, *T) []Link, rels ...RelInfo) {
+	RegisterInstance(i, gen)
+
+	t := reflect.TypeOf((*T)(nil))
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.relInfo == nil {
+		i.relInfo = make(map[reflect.Type][]RelInfo)
+	}
+	i.relInfo[t] = slices.Clone(rels)
+}
+
+// RelsFor returns the rels declared for t with RegisterInstanceWithRels, in
+// declaration order, or nil if none were declared. t is the registered
+// pointer type, such as reflect.TypeOf(&User{}); the pointee type is accepted
+// as well.
+func (i *Instance) RelsFor(t reflect.Type) []RelInfo {
+	if t == nil {
+		return nil
+	}
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	rels, ok := i.relInfo[t]
+	if !ok && t.Kind() != reflect.Pointer {
+		rels = i.relInfo[reflect.PointerTo(t)]
+	}
+	return slices.Clone(rels)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestRegisterInstanceWithRels(t *testing.T) {
+	inst := New()
+	rels := []RelInfo{
+		{Rel: "self"},
+		{Rel: "orders", Templated: true, Description: "Orders placed by the user"},
+	}
+	RegisterInstanceWithRels(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	}, rels...)
+
+	if got := marshalString(t, inst.Wrap(context.Background(), &collectionUser{ID: 3})); got != `{"id":3,"_links":{"self":{"href":"/users/3"}}}` {
+		t.Fatalf("expected the generator to be registered, got %s", got)
+	}
+	ptr := reflect.TypeOf(&collectionUser{})
+	if got := inst.RelsFor(ptr); !slices.Equal(got, rels) {
+		t.Fatalf("expected %v, got %v", rels, got)
+	}
+	if got := inst.RelsFor(ptr.Elem()); !slices.Equal(got, rels) {
+		t.Fatalf("expected the pointee type to be accepted, got %v", got)
+	}
+
+	inst.RelsFor(ptr)[0].Rel = "mutated"
+	if inst.RelsFor(ptr)[0].Rel != "self" {
+		t.Fatal("expected RelsFor to return a copy")
+	}
+
+	inst.Unregister(ptr)
+	if got := inst.RelsFor(ptr); got != nil {
+		t.Fatalf("expected Unregister to drop the declaration, got %v", got)
+	}
+}
+
+func TestRelsFor_PlainRegister(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(context.Context, *collectionUser) []Link { return nil })
+	if got := inst.RelsFor(reflect.TypeOf(&collectionUser{})); got != nil {
+		t.Fatalf("expected no declaration, got %v", got)
+	}
+	if got := inst.RelsFor(nil); got != nil {
+		t.Fatalf("expected nil for a nil type, got %v", got)
+	}
+}
+
+func TestRelsFor_CloneAndReset(t *testing.T) {
+	inst := New()
+	RegisterInstanceWithRels(inst, func(context.Context, *collectionUser) []Link { return nil }, RelInfo{Rel: "self"})
+	c := inst.Clone()
+	inst.Reset()
+
+	ptr := reflect.TypeOf(&collectionUser{})
+	if got := inst.RelsFor(ptr); got != nil {
+		t.Fatalf("expected Reset to drop declarations, got %v", got)
+	}
+	if got := c.RelsFor(ptr); len(got) != 1 {
+		t.Fatalf("expected the clone to keep its declaration, got %v", got)
+	}
+}
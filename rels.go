@@ -0,0 +1,216 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"strings"
+)
+
+// ianaRels is the set of link relation types registered with IANA.
+// See https://www.iana.org/assignments/link-relations/link-relations.xhtml.
+var ianaRels = map[string]struct{}{
+	"about": {}, "acl": {}, "alternate": {}, "amphtml": {}, "api-catalog": {},
+	"appendix": {}, "apple-touch-icon": {}, "apple-touch-startup-image": {},
+	"archives": {}, "author": {}, "blocked-by": {}, "bookmark": {},
+	"c2pa-manifest": {}, "canonical": {}, "chapter": {}, "cite-as": {},
+	"collection": {}, "compression-dictionary": {}, "contents": {},
+	"convertedfrom": {}, "copyright": {}, "create-form": {}, "current": {},
+	"deprecation": {}, "describedby": {}, "describes": {}, "disclosure": {},
+	"dns-prefetch": {}, "duplicate": {}, "edit": {}, "edit-form": {},
+	"edit-media": {}, "enclosure": {}, "external": {}, "first": {},
+	"geofeed": {}, "glossary": {}, "help": {}, "hosts": {}, "hub": {},
+	"ice-server": {}, "icon": {}, "index": {}, "intervalafter": {},
+	"intervalbefore": {}, "intervalcontains": {}, "intervaldisjoint": {},
+	"intervalduring": {}, "intervalequals": {}, "intervalfinishedby": {},
+	"intervalfinishes": {}, "intervalin": {}, "intervalmeets": {},
+	"intervalmetby": {}, "intervaloverlappedby": {}, "intervaloverlaps": {},
+	"intervalstartedby": {}, "intervalstarts": {}, "item": {}, "last": {},
+	"latest-version": {}, "license": {}, "linkset": {}, "lrdd": {},
+	"manifest": {}, "mask-icon": {}, "me": {}, "media-feed": {}, "memento": {},
+	"micropub": {}, "modulepreload": {}, "monitor": {}, "monitor-group": {},
+	"next": {}, "next-archive": {}, "nofollow": {}, "noopener": {},
+	"noreferrer": {}, "opener": {}, "openid2.local_id": {},
+	"openid2.provider": {}, "original": {}, "p3pv1": {}, "payment": {},
+	"pingback": {}, "preconnect": {}, "predecessor-version": {}, "prefetch": {},
+	"preload": {}, "prerender": {}, "prev": {}, "prev-archive": {},
+	"preview": {}, "previous": {}, "privacy-policy": {}, "profile": {},
+	"publication": {}, "related": {}, "replies": {}, "restconf": {},
+	"ruleinput": {}, "search": {}, "section": {}, "self": {}, "service": {},
+	"service-desc": {}, "service-doc": {}, "service-meta": {}, "sip-trunking-capability": {},
+	"sponsored": {}, "start": {}, "status": {}, "stylesheet": {}, "subsection": {},
+	"successor-version": {}, "sunset": {}, "tag": {}, "terms-of-service": {},
+	"timegate": {}, "timemap": {}, "type": {}, "ugc": {}, "up": {},
+	"version-history": {}, "via": {}, "webmention": {}, "working-copy": {},
+	"working-copy-of": {},
+	// HAL reserves "curies" for CURIE definitions.
+	"curies": {},
+}
+
+// Rel is a link relation type. Link.Rel remains a plain string for
+// compatibility; the Rel* constants are untyped, so they can be assigned to
+// either Link.Rel or a Rel.
+type Rel string
+
+// Standard link relations commonly used in HAL documents.
+const (
+	RelSelf       = "self"
+	RelNext       = "next"
+	RelPrev       = "prev"
+	RelFirst      = "first"
+	RelLast       = "last"
+	RelItem       = "item"
+	RelCollection = "collection"
+	RelRelated    = "related"
+	RelAlternate  = "alternate"
+	RelEdit       = "edit"
+	RelCuries     = "curies"
+)
+
+// commonRels lists the Rel* constants, which lint checks for misspellings.
+var commonRels = []string{
+	RelSelf, RelNext, RelPrev, RelFirst, RelLast, RelItem,
+	RelCollection, RelRelated, RelAlternate, RelEdit, RelCuries,
+}
+
+// NewLink returns a link for rel and href. Templated is set when href
+// contains a URI template expression.
+func NewLink(rel Rel, href string) Link {
+	return Link{Rel: string(rel), Href: href, Templated: strings.Contains(href, "{")}
+}
+
+// SelfLink returns a "self" link to href.
+func SelfLink(href string) Link { return NewLink(RelSelf, href) }
+
+// NextLink returns a "next" link to href.
+func NextLink(href string) Link { return NewLink(RelNext, href) }
+
+// PrevLink returns a "prev" link to href.
+func PrevLink(href string) Link { return NewLink(RelPrev, href) }
+
+// FirstLink returns a "first" link to href.
+func FirstLink(href string) Link { return NewLink(RelFirst, href) }
+
+// LastLink returns a "last" link to href.
+func LastLink(href string) Link { return NewLink(RelLast, href) }
+
+// ItemLink returns an "item" link to href.
+func ItemLink(href string) Link { return NewLink(RelItem, href) }
+
+// CollectionLink returns a "collection" link to href.
+func CollectionLink(href string) Link { return NewLink(RelCollection, href) }
+
+// RelatedLink returns a "related" link to href.
+func RelatedLink(href string) Link { return NewLink(RelRelated, href) }
+
+// AlternateLink returns an "alternate" link to href with the given media type.
+func AlternateLink(href, mediaType string) Link {
+	l := NewLink(RelAlternate, href)
+	l.Type = mediaType
+	return l
+}
+
+// EditLink returns an "edit" link to href.
+func EditLink(href string) Link { return NewLink(RelEdit, href) }
+
+// IsStandardRel reports whether rel is an IANA-registered link relation
+// (or the HAL-reserved "curies" rel). Comparison is case-insensitive.
+func IsStandardRel(rel string) bool {
+	_, ok := ianaRels[strings.ToLower(rel)]
+	return ok
+}
+
+// RegisterWithCurie registers a generator whose non-standard rels are placed
+// under the given CURIE prefix automatically. Rels that are IANA-registered
+// (such as self or next) or already contain a colon (curied rels and URIs)
+// are left untouched. The prefix should be registered with RegisterCurie so
+// the curies definition is emitted; Instance.Validate reports it otherwise.
+//
+// # Example
+//
+//	inst.RegisterCurie("bil", "https://docs.example.com/billing/{rel}")
+//	hal.RegisterWithCurie(inst, "bil", func(ctx context.Context, inv *Invoice) []hal.Link {
+//	    return []hal.Link{
+//	        {Rel: "self", Href: "/invoices/1"},              // stays "self"
+//	        {Rel: "payments", Href: "/invoices/1/payments"}, // becomes "bil:payments"
+//	    }
+//	})
+func RegisterWithCurie[T any](i *Instance, prefix string, gen func(context.Context, *T) []Link) {
+	i.mu.Lock()
+	if i.curieRefs == nil {
+		i.curieRefs = make(map[string]struct{})
+	}
+	i.curieRefs[prefix] = struct{}{}
+	i.mu.Unlock()
+
+	RegisterInstance(i, func(ctx context.Context, v *T) []Link {
+		return curieLinks(prefix, gen(ctx, v))
+	})
+}
+
+// curieLinks prefixes non-standard rels in links. The input slice is copied
+// before modification, as generators may return shared slices.
+func curieLinks(prefix string, links []Link) []Link {
+	var out []Link
+	for idx, l := range links {
+		if l.Rel == "" || strings.Contains(l.Rel, ":") || IsStandardRel(l.Rel) {
+			continue
+		}
+		if out == nil {
+			out = append([]Link(nil), links...)
+		}
+		out[idx].Rel = prefix + ":" + l.Rel
+	}
+	if out == nil {
+		return links
+	}
+	return out
+}
+
+// nearMissRel returns the common rel that rel is one edit away from, if any.
+// An edit is an insertion, deletion, substitution or adjacent transposition,
+// so "slef" and "nxt" are both caught. Plurals such as "items" are deliberate
+// custom rels and are not reported.
+func nearMissRel(rel string) (string, bool) {
+	if rel == "" || strings.Contains(rel, ":") || IsStandardRel(rel) {
+		return "", false
+	}
+	for _, std := range commonRels {
+		if rel == std+"s" {
+			continue
+		}
+		if editDistance(rel, std) == 1 {
+			return std, true
+		}
+	}
+	return "", false
+}
+
+// editDistance computes the optimal string alignment distance between a and b.
+func editDistance(a, b string) int {
+	if d := len(a) - len(b); d > 1 || d < -1 {
+		return 2 // More than one edit apart; precise distance not needed.
+	}
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(b)]
+}
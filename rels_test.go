@@ -0,0 +1,141 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+type billingInvoice struct {
+	ID int `json:"id"`
+}
+
+func TestIsStandardRel(t *testing.T) {
+	for _, rel := range []string{"self", "next", "Prev", "curies", "edit-form"} {
+		if !IsStandardRel(rel) {
+			t.Errorf("expected %q to be standard", rel)
+		}
+	}
+	for _, rel := range []string{"payments", "acme:orders", ""} {
+		if IsStandardRel(rel) {
+			t.Errorf("expected %q to be non-standard", rel)
+		}
+	}
+}
+
+func TestRegisterWithCurie_PrefixesCustomRels(t *testing.T) {
+	inst := New()
+	inst.RegisterCurie("bil", "https://docs.example.com/billing/{rel}")
+
+	shared := []Link{
+		{Rel: "self", Href: "/invoices/1"},
+		{Rel: "payments", Href: "/invoices/1/payments"},
+		{Rel: "refunds", Href: "/invoices/1/refunds"},
+		{Rel: "other:thing", Href: "/thing"},
+	}
+	RegisterWithCurie(inst, "bil", func(_ context.Context, _ *billingInvoice) []Link {
+		return shared
+	})
+
+	b, err := json.Marshal(inst.Wrap(context.Background(), &billingInvoice{ID: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Links map[string]json.RawMessage `json:"_links"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rel := range []string{"self", "bil:payments", "bil:refunds", "other:thing", "curies"} {
+		if _, ok := doc.Links[rel]; !ok {
+			t.Errorf("expected rel %q in %s", rel, b)
+		}
+	}
+	for _, rel := range []string{"payments", "refunds", "bil:self"} {
+		if _, ok := doc.Links[rel]; ok {
+			t.Errorf("unexpected rel %q in %s", rel, b)
+		}
+	}
+	if want := `[{"href":"https://docs.example.com/billing/{rel}","templated":true,"name":"bil"}]`; string(doc.Links["curies"]) != want {
+		t.Errorf("expected bil curie definition, got %s", doc.Links["curies"])
+	}
+
+	if shared[1].Rel != "payments" {
+		t.Fatal("generator's slice must not be modified")
+	}
+}
+
+func TestLinkConstructors(t *testing.T) {
+	tests := []struct {
+		got  Link
+		want Link
+	}{
+		{SelfLink("/users/1"), Link{Rel: "self", Href: "/users/1"}},
+		{NextLink("/users?page=2"), Link{Rel: "next", Href: "/users?page=2"}},
+		{PrevLink("/users?page=1"), Link{Rel: "prev", Href: "/users?page=1"}},
+		{FirstLink("/users"), Link{Rel: "first", Href: "/users"}},
+		{LastLink("/users?page=9"), Link{Rel: "last", Href: "/users?page=9"}},
+		{ItemLink("/users/1"), Link{Rel: "item", Href: "/users/1"}},
+		{CollectionLink("/users"), Link{Rel: "collection", Href: "/users"}},
+		{RelatedLink("/teams/1"), Link{Rel: "related", Href: "/teams/1"}},
+		{AlternateLink("/users/1.pdf", "application/pdf"), Link{Rel: "alternate", Href: "/users/1.pdf", Type: "application/pdf"}},
+		{EditLink("/users/1"), Link{Rel: "edit", Href: "/users/1"}},
+		{NewLink("search", "/users{?q}"), Link{Rel: "search", Href: "/users{?q}", Templated: true}},
+		{NewLink(RelSelf, "/x"), Link{Rel: RelSelf, Href: "/x"}},
+	}
+
+	for _, tt := range tests {
+		if !tt.got.Equal(tt.want) {
+			t.Errorf("expected %+v, got %+v", tt.want, tt.got)
+		}
+	}
+}
+
+func TestNearMissRel(t *testing.T) {
+	misses := map[string]string{
+		"slef":       "self",
+		"sef":        "self",
+		"nxt":        "next",
+		"nexxt":      "next",
+		"prve":       "prev",
+		"colection":  "collection",
+		"alternates": "", // plural of a standard rel is deliberate
+	}
+	for rel, want := range misses {
+		got, ok := nearMissRel(rel)
+		if want == "" {
+			if ok {
+				t.Errorf("nearMissRel(%q) flagged %q, expected no match", rel, got)
+			}
+			continue
+		}
+		if !ok || got != want {
+			t.Errorf("nearMissRel(%q) = %q, %v; want %q", rel, got, ok, want)
+		}
+	}
+
+	for _, rel := range []string{"self", "items", "payments", "acme:slef", "previous", ""} {
+		if got, ok := nearMissRel(rel); ok {
+			t.Errorf("nearMissRel(%q) flagged %q, expected no match", rel, got)
+		}
+	}
+}
+
+func TestLintHook_NearMissRel(t *testing.T) {
+	var warnings []string
+	inst := New(WithLintHook(func(msg string) { warnings = append(warnings, msg) }))
+
+	env := inst.WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "self", Href: "/users/1"})
+	env.AddLink(Link{Rel: "slef", Href: "/users/1"})
+
+	if len(warnings) != 1 || !containsString(warnings[0], `"slef"`) || !containsString(warnings[0], `"self"`) {
+		t.Fatalf("expected one near-miss warning, got %v", warnings)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"net/url"
+)
+
+// RequestInfo describes the HTTP request a resource is rendered for.
+// Framework integrations store it in the context passed to Wrap, so generators
+// can build absolute or request-relative links without depending on a
+// particular router.
+type RequestInfo struct {
+	Method string
+	// URL is the externally visible request URL, with scheme and host
+	// resolved from forwarding headers where the integration supports it.
+	URL *url.URL
+	// Prefix is the path prefix a reverse proxy strips before forwarding
+	// the request, as sent in X-Forwarded-Prefix. It is empty when the
+	// request was not proxied or the integration does not trust the proxy.
+	Prefix string
+}
+
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a copy of ctx carrying info.
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext returns the RequestInfo stored in ctx, if any.
+//
+// # Example
+//
+//	hal.Register(func(ctx context.Context, u *User) []hal.Link {
+//	    if req, ok := hal.RequestInfoFromContext(ctx); ok {
+//	        base := req.URL.Scheme + "://" + req.URL.Host
+//	        return []hal.Link{{Rel: "self", Href: base + "/users/" + u.ID}}
+//	    }
+//	    return []hal.Link{{Rel: "self", Href: "/users/" + u.ID}}
+//	})
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
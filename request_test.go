@@ -0,0 +1,24 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func TestRequestInfo_RoundTrip(t *testing.T) {
+	if _, ok := RequestInfoFromContext(context.Background()); ok {
+		t.Fatal("expected no request info in empty context")
+	}
+
+	u, _ := url.Parse("https://api.example.com/users?page=2")
+	ctx := WithRequestInfo(context.Background(), RequestInfo{Method: "GET", URL: u})
+
+	info, ok := RequestInfoFromContext(ctx)
+	if !ok || info.Method != "GET" || info.URL.String() != u.String() {
+		t.Fatalf("unexpected request info: %+v (found=%v)", info, ok)
+	}
+}
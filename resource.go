@@ -0,0 +1,132 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"reflect"
+	"slices"
+
+	json "github.com/goccy/go-json"
+)
+
+// ResourceGenerator produces the links of a resource together with related
+// resources to embed, keyed by rel. Embedded values are wrapped with the same
+// instance, so they get their own links; slices are embedded as arrays and
+// nil values are skipped.
+type ResourceGenerator[T any] func(ctx context.Context, v *T) ([]Link, map[string]any)
+
+// defaultMaxEmbedDepth bounds automatic embedding when no limit is set with
+// WithMaxEmbedDepth.
+const defaultMaxEmbedDepth = 8
+
+// RegisterResource registers gen for *T. Wrap adds the returned links like a
+// Generator's and embeds the returned resources. Automatic embedding stops at
+// the instance's embed depth (see WithMaxEmbedDepth), so types that embed
+// each other do not recurse forever. A later RegisterInstance for T replaces
+// gen; RegisterAppend adds its links to gen's.
+//
+// # Example
+//
+//	hal.RegisterResource(inst, func(ctx context.Context, o *Order) ([]hal.Link, map[string]any) {
+//	    return []hal.Link{{Rel: "self", Href: "/orders/1"}},
+//	        map[string]any{"customer": o.Customer, "lines": o.Lines}
+//	})
+func RegisterResource[T any](i *Instance, gen ResourceGenerator[T]) {
+	targetType := reflect.TypeOf((*T)(nil))
+	adapter := func(ctx context.Context, v any) ([]Link, map[string]any) {
+		return gen(ctx, v.(*T))
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.resources == nil {
+		i.resources = make(map[reflect.Type]func(context.Context, any) ([]Link, map[string]any))
+	}
+	i.resources[targetType] = adapter
+	// Introspection and validation see the link half as a regular generator.
+	i.generators[targetType] = func(ctx context.Context, v any) []Link {
+		links, _ := adapter(ctx, v)
+		return links
+	}
+	i.registryChanged()
+}
+
+// WithMaxEmbedDepth limits how deep resources are embedded automatically by
+// RegisterResource generators and hal:"embed" struct tags. Resources at the
+// limit are still wrapped with their links, but embed nothing further. The
+// default is 8.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithMaxEmbedDepth(2))
+func WithMaxEmbedDepth(n int) InstanceOption {
+	return func(i *Instance) {
+		i.maxEmbedDepth = n
+	}
+}
+
+type embedDepthKey struct{}
+
+// nestedEmbedContext returns the context for resources embedded
+// automatically at the next level, or false once the depth limit is reached.
+func (i *Instance) nestedEmbedContext(ctx context.Context, owner any) (context.Context, bool) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	limit := i.maxEmbedDepth
+	if limit == 0 {
+		limit = defaultMaxEmbedDepth
+	}
+	depth, _ := ctx.Value(embedDepthKey{}).(int)
+	if depth >= limit {
+		i.lintf("hal: embed depth limit %d reached at %T; nested resources not embedded", limit, owner)
+		return ctx, false
+	}
+	return context.WithValue(ctx, embedDepthKey{}, depth+1), true
+}
+
+// computeResource runs the resource generator res for v, the value wrapped
+// by e. Panics are handled as for generators.
+func (e *Envelope) computeResource(ctx context.Context, res func(context.Context, any) ([]Link, map[string]any), t reflect.Type, v any) {
+	var embeds map[string]any
+	gen := func(ctx context.Context, v any) []Link {
+		var links []Link
+		links, embeds = res(ctx, v)
+		return links
+	}
+	links, err := e.instance.callGenerator(ctx, gen, t, v)
+	if err != nil {
+		e.err = err
+		return
+	}
+	if err := e.instance.checkGeneratorOutput(t, links); err != nil {
+		e.strictErr = err
+		return
+	}
+	for _, l := range links {
+		e.AddLink(l)
+	}
+	if len(embeds) == 0 {
+		return
+	}
+
+	nested, ok := e.instance.nestedEmbedContext(ctx, e.Data)
+	if !ok {
+		return
+	}
+	rels := make([]string, 0, len(embeds))
+	for rel := range embeds {
+		rels = append(rels, rel)
+	}
+	slices.Sort(rels)
+	for _, rel := range rels {
+		if v := embeds[rel]; v != nil {
+			e.instance.embedField(nested, e, rel, reflect.ValueOf(v))
+		}
+	}
+}
+
+// rawMessageType is embedded as a single resource, although it is a slice.
+var rawMessageType = reflect.TypeOf(json.RawMessage(nil))
@@ -0,0 +1,157 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type resCustomer struct {
+	ID int `json:"id"`
+}
+
+type resLine struct {
+	SKU string `json:"sku"`
+}
+
+type resOrder struct {
+	ID       int          `json:"id"`
+	Customer *resCustomer `json:"-"`
+	Lines    []resLine    `json:"-"`
+}
+
+func resourceInstance(opts ...InstanceOption) *Instance {
+	inst := New(opts...)
+	RegisterResource(inst, func(_ context.Context, o *resOrder) ([]Link, map[string]any) {
+		return []Link{{Rel: "self", Href: "/orders/" + itoa(o.ID)}},
+			map[string]any{"customer": o.Customer, "lines": o.Lines}
+	})
+	RegisterInstance(inst, func(_ context.Context, c *resCustomer) []Link {
+		return []Link{{Rel: "self", Href: "/customers/" + itoa(c.ID)}}
+	})
+	RegisterInstance(inst, func(_ context.Context, l *resLine) []Link {
+		return []Link{{Rel: "self", Href: "/skus/" + l.SKU}}
+	})
+	return inst
+}
+
+func TestRegisterResource_EmbedsWrappedResources(t *testing.T) {
+	order := &resOrder{ID: 1, Customer: &resCustomer{ID: 9}, Lines: []resLine{{SKU: "a"}}}
+	got := marshalString(t, resourceInstance().Wrap(context.Background(), order))
+
+	want := `{"id":1,"_embedded":{` +
+		`"customer":{"id":9,"_links":{"self":{"href":"/customers/9"}}},` +
+		`"lines":[{"sku":"a","_links":{"self":{"href":"/skus/a"}}}]},` +
+		`"_links":{"self":{"href":"/orders/1"}}}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestRegisterResource_NilEmbedsSkipped(t *testing.T) {
+	got := marshalString(t, resourceInstance().Wrap(context.Background(), &resOrder{ID: 1}))
+
+	if want := `{"id":1,"_links":{"self":{"href":"/orders/1"}}}`; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestRegisterResource_VisibleAsGenerator(t *testing.T) {
+	inst := resourceInstance()
+	inst.RegisterSample(&resOrder{ID: 1})
+
+	if err := inst.Validate(); err != nil {
+		t.Fatalf("expected resource generator to validate, got %v", err)
+	}
+}
+
+type resPing struct {
+	N int `json:"n"`
+}
+
+type resPong struct {
+	N int `json:"n"`
+}
+
+func TestRegisterResource_MutualEmbeddingIsDepthLimited(t *testing.T) {
+	var lints []string
+	inst := New(WithMaxEmbedDepth(3), WithLintHook(func(msg string) { lints = append(lints, msg) }))
+	RegisterResource(inst, func(_ context.Context, p *resPing) ([]Link, map[string]any) {
+		return []Link{{Rel: "self", Href: "/ping"}}, map[string]any{"pong": &resPong{N: p.N + 1}}
+	})
+	RegisterResource(inst, func(_ context.Context, p *resPong) ([]Link, map[string]any) {
+		return []Link{{Rel: "self", Href: "/pong"}}, map[string]any{"ping": &resPing{N: p.N + 1}}
+	})
+
+	got := marshalString(t, inst.Wrap(context.Background(), &resPing{}))
+
+	// The root plus three levels of embedded resources.
+	if n := strings.Count(got, `"n":`); n != 4 {
+		t.Fatalf("expected 4 resources, got %d: %s", n, got)
+	}
+	if len(lints) != 1 || !strings.Contains(lints[0], "embed depth limit 3") {
+		t.Fatalf("expected depth limit lint, got %v", lints)
+	}
+}
+
+type tagCycle struct {
+	ID   int       `json:"id"`
+	Next *tagCycle `json:"-" hal:"embed,rel=next"`
+}
+
+func TestAutoEmbed_PointerCycleIsDepthLimited(t *testing.T) {
+	inst := New(WithMaxEmbedDepth(2))
+	RegisterInstance(inst, func(_ context.Context, c *tagCycle) []Link {
+		return []Link{{Rel: "self", Href: "/nodes/" + itoa(c.ID)}}
+	})
+	node := &tagCycle{ID: 1}
+	node.Next = node
+
+	got := marshalString(t, inst.Wrap(context.Background(), node))
+	if n := strings.Count(got, `"id":1`); n != 3 {
+		t.Fatalf("expected 3 nested copies, got %d: %s", n, got)
+	}
+}
+
+func TestRegisterResource_ReplacedByGenerator(t *testing.T) {
+	ctx := context.Background()
+	inst := resourceInstance()
+	RegisterInstance(inst, func(_ context.Context, o *resOrder) []Link {
+		return []Link{{Rel: "self", Href: "/v2/orders/" + itoa(o.ID)}}
+	})
+	if got, want := marshalString(t, inst.Wrap(ctx, &resOrder{ID: 1})), `{"id":1,"_links":{"self":{"href":"/v2/orders/1"}}}`; got != want {
+		t.Fatalf("expected the later generator to win, got %s", got)
+	}
+
+	RegisterResource(inst, func(_ context.Context, o *resOrder) ([]Link, map[string]any) {
+		return []Link{{Rel: "self", Href: "/v3/orders/" + itoa(o.ID)}}, nil
+	})
+	if got, want := marshalString(t, inst.Wrap(ctx, &resOrder{ID: 1})), `{"id":1,"_links":{"self":{"href":"/v3/orders/1"}}}`; got != want {
+		t.Fatalf("expected the later resource generator to win, got %s", got)
+	}
+}
+
+func TestRegisterResource_Append(t *testing.T) {
+	ctx := context.Background()
+	inst := resourceInstance()
+	RegisterAppend(inst, func(_ context.Context, o *resOrder) []Link {
+		return []Link{{Rel: "invoice", Href: "/invoices/" + itoa(o.ID)}}
+	})
+	got := marshalString(t, inst.Wrap(ctx, &resOrder{ID: 1, Customer: &resCustomer{ID: 9}}))
+	want := `{"id":1,"_embedded":{"customer":{"id":9,"_links":{"self":{"href":"/customers/9"}}}},` +
+		`"_links":{"invoice":{"href":"/invoices/1"},"self":{"href":"/orders/1"}}}`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	// The other order: a resource generator replaces appended generators.
+	RegisterResource(inst, func(_ context.Context, o *resOrder) ([]Link, map[string]any) {
+		return []Link{{Rel: "self", Href: "/orders/" + itoa(o.ID)}}, nil
+	})
+	if got, want := marshalString(t, inst.Wrap(ctx, &resOrder{ID: 1})), `{"id":1,"_links":{"self":{"href":"/orders/1"}}}`; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
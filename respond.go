@@ -0,0 +1,226 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// MediaType is the Content-Type written by Respond.
+const MediaType = "application/hal+json; charset=utf-8"
+
+// internalErrorBody is written by Respond when data cannot be rendered.
+// The cause is not exposed to clients.
+const internalErrorBody = `{"message":"Internal Server Error"}`
+
+// notAcceptableBody is written by Respond for OutputNotAcceptable.
+const notAcceptableBody = `{"message":"Not Acceptable"}`
+
+// Respond writes data as a HAL document with the given status, using the
+// instance stored in the request context by NewContext, or the
+// DefaultInstance if there is none. See Instance.Respond.
+//
+// # Example
+//
+//	func getUser(w http.ResponseWriter, r *http.Request) {
+//	    hal.Respond(w, r, http.StatusOK, user)
+//	}
+func Respond(w http.ResponseWriter, r *http.Request, status int, data any) {
+	instanceFor(r.Context()).Respond(w, r, status, data)
+}
+
+// WithContentNegotiation makes Respond choose the representation from the
+// request's Accept header with Negotiate: clients accepting only
+// application/json receive the bare data, and clients accepting neither
+// JSON type receive 406 Not Acceptable. Responses then carry
+// "Vary: Accept".
+//
+// # Example
+//
+//	inst := hal.New(hal.WithContentNegotiation())
+func WithContentNegotiation() InstanceOption {
+	return func(i *Instance) {
+		i.negotiate = true
+	}
+}
+
+// Respond writes data as a HAL document with the given status and
+// Content-Type MediaType. Data is handled by shape:
+//
+//   - *Envelope and *CollectionPage values are written as-is
+//   - slices become a collection whose self link is the request URI and
+//     whose total is the slice length
+//   - anything else is wrapped with WrapE and the request context
+//
+// Envelopes with templates (see AddTemplate) are written with Content-Type
+// FormsMediaType to clients whose Accept header names
+// application/prs.hal-forms+json; such responses carry "Vary: Accept".
+//
+// With WithContentNegotiation, clients preferring application/json receive
+// the data alone with Content-Type JSONMediaType: an envelope's Data, or a
+// JSON array of the items' data for slices and collection pages.
+//
+// The request is exposed to generators through RequestInfoFromContext,
+// unless the context already carries a RequestInfo. If data cannot be
+// wrapped or marshaled, Respond writes a 500 response with a minimal
+// {"message": ...} body instead; the error is reported to the lint hook.
+// Responses to HEAD requests carry the headers only.
+func (i *Instance) Respond(w http.ResponseWriter, r *http.Request, status int, data any) {
+	ctx := r.Context()
+	if _, ok := RequestInfoFromContext(ctx); !ok {
+		ctx = WithRequestInfo(ctx, requestInfo(r))
+	}
+	h := w.Header()
+	mode := OutputHAL
+	if i.negotiate {
+		h.Add("Vary", "Accept")
+		mode = Negotiate(r)
+	}
+
+	var body [][]byte
+	var err error
+	contentType := MediaType
+	switch mode {
+	case OutputHAL:
+		dataBuf, metaBuf := getBuffer(), getBuffer()
+		defer putBuffer(dataBuf)
+		defer putBuffer(metaBuf)
+		var forms bool
+		body, forms, err = i.renderHAL(ctx, r, data, *dataBuf, *metaBuf)
+		if forms {
+			if !i.negotiate {
+				h.Add("Vary", "Accept")
+			}
+			if acceptsForms(r) {
+				contentType = FormsMediaType
+			}
+		}
+	case OutputJSON:
+		contentType = JSONMediaType
+		var b []byte
+		b, err = i.renderPlain(ctx, data)
+		body = [][]byte{b}
+	default:
+		contentType = JSONMediaType
+		status, body = http.StatusNotAcceptable, [][]byte{[]byte(notAcceptableBody)}
+	}
+	if err != nil {
+		i.lintf("hal: responding to %s %s: %v", r.Method, r.URL.Path, err)
+		status, body = http.StatusInternalServerError, [][]byte{[]byte(internalErrorBody)}
+	}
+
+	h.Set("Content-Type", contentType)
+	h.Set("Content-Length", strconv.Itoa(partsLen(body)))
+	w.WriteHeader(status)
+	if r.Method != http.MethodHead {
+		_, _ = writeParts(w, body)
+	}
+}
+
+// renderHAL returns data as a HAL document for Respond, and whether it has
+// HAL-FORMS templates. Envelopes are returned in the pieces built by
+// Envelope.Encode, using the scratch buffers dataBuf and metaBuf, so the
+// document is not spliced in memory.
+func (i *Instance) renderHAL(ctx context.Context, r *http.Request, data any, dataBuf, metaBuf []byte) ([][]byte, bool, error) {
+	var env *Envelope
+	switch v := data.(type) {
+	case *Envelope:
+		env = v
+	case *CollectionPage:
+		b, err := v.MarshalJSON()
+		return [][]byte{b}, false, err
+	default:
+		var err error
+		if rv, ok := listData(data); ok {
+			page, err := i.CollectionE(ctx, data, rv.Len(), SelfLink(r.URL.RequestURI()))
+			if err != nil {
+				return nil, false, err
+			}
+			b, err := page.MarshalJSON()
+			return [][]byte{b}, false, err
+		}
+		if env, err = i.WrapE(ctx, data); err != nil {
+			return nil, false, err
+		}
+	}
+	parts, err := env.encodeParts(dataBuf, metaBuf)
+	return parts, len(env.templatesForMarshal()) > 0, err
+}
+
+// renderPlain marshals the bare data for Respond, without running
+// generators.
+func (i *Instance) renderPlain(ctx context.Context, data any) ([]byte, error) {
+	switch v := data.(type) {
+	case *Envelope:
+		c := v.Clone()
+		c.plain = true
+		return c.MarshalJSON()
+	case *CollectionPage:
+		if v.err != nil {
+			return nil, v.err
+		}
+		var items []*Envelope
+		for _, e := range v.Embedded {
+			if envs, ok := e.([]*Envelope); ok {
+				for _, env := range envs {
+					c := env.Clone()
+					c.plain = true
+					items = append(items, c)
+				}
+			}
+		}
+		return marshalItems(items)
+	}
+	if rv, ok := listData(data); ok {
+		items := make([]*Envelope, rv.Len())
+		for idx := range items {
+			items[idx] = i.WrapWith(ctx, rv.Index(idx).Interface(), PlainOutput())
+		}
+		return marshalItems(items)
+	}
+	return i.WrapWith(ctx, data, PlainOutput()).MarshalJSON()
+}
+
+// listData reports whether Respond sends data as a list of items: a slice
+// other than bytes, such as json.RawMessage, or a type marshaling itself.
+func listData(data any) (reflect.Value, bool) {
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 || marshalsItself(rv.Type()) {
+		return reflect.Value{}, false
+	}
+	return rv, true
+}
+
+// marshalItems marshals envelopes as a JSON array.
+func marshalItems(items []*Envelope) ([]byte, error) {
+	out := []byte{'['}
+	for idx, item := range items {
+		if idx > 0 {
+			out = append(out, ',')
+		}
+		b, err := item.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("hal: item %d: %w", idx, err)
+		}
+		out = append(out, b...)
+	}
+	return append(out, ']'), nil
+}
+
+// requestInfo describes r. Forwarding headers are not consulted, as net/http
+// has no notion of trusted proxies; middleware can store a RequestInfo built
+// from them instead.
+func requestInfo(r *http.Request) RequestInfo {
+	u := *r.URL
+	u.Host = r.Host
+	u.Scheme = "http"
+	if r.TLS != nil {
+		u.Scheme = "https"
+	}
+	return RequestInfo{Method: r.Method, URL: &u}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func respondInstance() *Instance {
+	inst := New(WithStrictMode())
+	RegisterInstance(inst, func(ctx context.Context, u *collectionUser) []Link {
+		req, _ := RequestInfoFromContext(ctx)
+		return []Link{{Rel: "self", Href: req.URL.Scheme + "://" + req.URL.Host + "/users/" + itoa(u.ID)}}
+	})
+	return inst
+}
+
+func TestRespond(t *testing.T) {
+	inst := respondInstance()
+	r := httptest.NewRequest(http.MethodGet, "http://api.example.com/users/1", nil)
+	w := httptest.NewRecorder()
+	inst.Respond(w, r, http.StatusCreated, &collectionUser{ID: 1})
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != MediaType {
+		t.Fatalf("expected %q, got %q", MediaType, ct)
+	}
+	if got, want := w.Body.String(), `{"id":1,"_links":{"self":{"href":"http://api.example.com/users/1"}}}`; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestRespond_Slice(t *testing.T) {
+	inst := respondInstance()
+	r := httptest.NewRequest(http.MethodGet, "http://api.example.com/users?page=2", nil)
+	w := httptest.NewRecorder()
+	inst.Respond(w, r, http.StatusOK, []*collectionUser{{ID: 1}, {ID: 2}})
+
+	body := w.Body.String()
+	for _, want := range []string{`"self":{"href":"/users?page=2"}`, `"count":2`, `"total":2`, `/users/2"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %s in %s", want, body)
+		}
+	}
+}
+
+func TestRespond_Head(t *testing.T) {
+	inst := respondInstance()
+	r := httptest.NewRequest(http.MethodHead, "http://api.example.com/users/1", nil)
+	w := httptest.NewRecorder()
+	inst.Respond(w, r, http.StatusOK, &collectionUser{ID: 1})
+
+	if w.Body.Len() != 0 {
+		t.Fatalf("expected no body for HEAD, got %s", w.Body)
+	}
+	if w.Header().Get("Content-Length") == "0" || w.Header().Get("Content-Type") != MediaType {
+		t.Fatalf("expected GET headers, got %v", w.Header())
+	}
+}
+
+func TestRespond_Error(t *testing.T) {
+	var lints []string
+	inst := New(WithStrictMode(), WithLintHook(func(msg string) { lints = append(lints, msg) }))
+	r := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+	w := httptest.NewRecorder()
+	inst.Respond(w, r, http.StatusOK, &mergeOrder{ID: 1})
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", w.Code)
+	}
+	if got := w.Body.String(); got != internalErrorBody {
+		t.Fatalf("expected %s, got %s", internalErrorBody, got)
+	}
+	if len(lints) != 1 || !strings.Contains(lints[0], "no generator") {
+		t.Fatalf("expected the error to be linted, got %v", lints)
+	}
+}
+
+func TestRespond_ContextInstance(t *testing.T) {
+	inst := respondInstance()
+	r := httptest.NewRequest(http.MethodGet, "https://api.example.com/users/3", nil)
+	r = r.WithContext(NewContext(r.Context(), inst))
+	w := httptest.NewRecorder()
+	Respond(w, r, http.StatusOK, &collectionUser{ID: 3})
+
+	if !strings.Contains(w.Body.String(), `"href":"https://api.example.com/users/3"`) {
+		t.Fatalf("expected the context instance to be used, got %s", w.Body)
+	}
+}
+
+func TestRespond_RawMessage(t *testing.T) {
+	inst := New(WithContentNegotiation())
+	for _, accept := range []string{MediaType, JSONMediaType} {
+		r := httptest.NewRequest(http.MethodGet, "/things/1", nil)
+		r.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		inst.Respond(w, r, http.StatusOK, json.RawMessage(`{"a":1}`))
+
+		if w.Code != http.StatusOK || w.Body.String() != `{"a":1}` {
+			t.Fatalf("%s: expected the object, got %d %s", accept, w.Code, w.Body)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"maps"
+	"strings"
+)
+
+// WithLinkRewriter adds fn to the functions applied to every link when an
+// envelope is marshaled, after the generators and AddLink calls but before
+// hrefs are made absolute. Rewriters run in the order they were added.
+// The self link of a CollectionPage is rewritten when the page is built.
+// Links registered with RegisterStatic are not rewritten.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithLinkRewriter(hal.PrefixRewriter("/api/v2")))
+func WithLinkRewriter(fn func(Link) Link) InstanceOption {
+	return func(i *Instance) {
+		i.linkRewriters = append(i.linkRewriters, fn)
+	}
+}
+
+// WithContextLinkRewriter is like WithLinkRewriter for rewriters that depend
+// on the request. fn is called once per Wrap with the Wrap context and may
+// return nil to leave links unchanged. Its rewriter runs after those added
+// with WithLinkRewriter. RequestPrefixRewriter is a ready-made fn for
+// gateways that send X-Forwarded-Prefix.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithContextLinkRewriter(hal.RequestPrefixRewriter))
+func WithContextLinkRewriter(fn func(ctx context.Context) func(Link) Link) InstanceOption {
+	return func(i *Instance) {
+		i.contextRewriter = fn
+	}
+}
+
+// PrefixRewriter returns a rewriter that prepends the path prefix to hrefs
+// starting with a single "/". Absolute URLs, network-path references such as
+// "//cdn.example.com/x", path-relative hrefs and CURIE definitions are left
+// unchanged. An empty prefix, or "/", returns links as they are.
+//
+// # Example
+//
+//	hal.PrefixRewriter("/api/v2") // "/users/1" becomes "/api/v2/users/1"
+func PrefixRewriter(prefix string) func(Link) Link {
+	prefix = strings.TrimRight(prefix, "/")
+	if prefix != "" && prefix[0] != '/' {
+		prefix = "/" + prefix
+	}
+	return func(l Link) Link {
+		if prefix == "" || l.Rel == RelCuries || !strings.HasPrefix(l.Href, "/") || strings.HasPrefix(l.Href, "//") {
+			return l
+		}
+		l.Href = prefix + l.Href
+		return l
+	}
+}
+
+// RequestPrefixRewriter returns a PrefixRewriter for the Prefix of the
+// RequestInfo stored in ctx, or nil if there is none. Use it with
+// WithContextLinkRewriter; framework integrations fill Prefix from the
+// X-Forwarded-Prefix header of trusted proxies.
+func RequestPrefixRewriter(ctx context.Context) func(Link) Link {
+	req, ok := RequestInfoFromContext(ctx)
+	if !ok || req.Prefix == "" {
+		return nil
+	}
+	return PrefixRewriter(req.Prefix)
+}
+
+// rewriterFor returns the context-scoped rewriter for ctx, or nil.
+func (i *Instance) rewriterFor(ctx context.Context) func(Link) Link {
+	if i.contextRewriter == nil || ctx == nil {
+		return nil
+	}
+	return i.contextRewriter(ctx)
+}
+
+// rewriteLink applies the instance's rewriters and then extra, if not nil.
+func (i *Instance) rewriteLink(l Link, extra func(Link) Link) Link {
+	for _, fn := range i.linkRewriters {
+		l = fn(l)
+	}
+	if extra != nil {
+		l = extra(l)
+	}
+	return l
+}
+
+// rewriteLinks returns links with every Link passed through the instance's
+// rewriters and extra. Changed entries are copies; the input map is not
+// modified.
+func (i *Instance) rewriteLinks(links map[string]any, extra func(Link) Link) map[string]any {
+	if len(links) == 0 || (len(i.linkRewriters) == 0 && extra == nil) {
+		return links
+	}
+	out := maps.Clone(links)
+	for rel, v := range links {
+		switch vv := v.(type) {
+		case Link:
+			out[rel] = i.rewriteLink(vv, extra)
+		case []any:
+			s := make([]any, len(vv))
+			for idx, item := range vv {
+				if l, ok := item.(Link); ok {
+					item = i.rewriteLink(l, extra)
+				}
+				s[idx] = item
+			}
+			out[rel] = s
+		case []Link:
+			s := make([]Link, len(vv))
+			for idx, l := range vv {
+				s[idx] = i.rewriteLink(l, extra)
+			}
+			out[rel] = s
+		}
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func TestPrefixRewriter(t *testing.T) {
+	rw := PrefixRewriter("/api/v2/")
+	tests := []struct {
+		link Link
+		want string
+	}{
+		{Link{Rel: "self", Href: "/users/1"}, "/api/v2/users/1"},
+		{Link{Rel: "search", Href: "/users{?q}", Templated: true}, "/api/v2/users{?q}"},
+		{Link{Rel: "docs", Href: "https://docs.example.com/users"}, "https://docs.example.com/users"},
+		{Link{Rel: "cdn", Href: "//cdn.example.com/a.png"}, "//cdn.example.com/a.png"},
+		{Link{Rel: "next", Href: "page/2"}, "page/2"},
+		{Link{Rel: RelCuries, Href: "/rels/{rel}", Name: "acme"}, "/rels/{rel}"},
+	}
+	for _, tt := range tests {
+		if got := rw(tt.link).Href; got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.link.Href, tt.want, got)
+		}
+	}
+	if got := PrefixRewriter("api")(Link{Href: "/x"}).Href; got != "/api/x" {
+		t.Errorf("expected a leading slash to be added to the prefix, got %q", got)
+	}
+	if got := PrefixRewriter("/")(Link{Href: "/x"}).Href; got != "/x" {
+		t.Errorf("expected an empty prefix to keep hrefs, got %q", got)
+	}
+}
+
+func TestWithLinkRewriter(t *testing.T) {
+	inst := New(WithLinkRewriter(PrefixRewriter("/api/v2")), WithCuries(map[string]string{"acme": "/rels/{rel}"}))
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{
+			{Rel: "self", Href: "/users/" + itoa(u.ID)},
+			{Rel: "acme:orders", Href: "/users/" + itoa(u.ID) + "/orders"},
+		}
+	})
+
+	env := inst.Wrap(context.Background(), &collectionUser{ID: 1})
+	env.AddEmbedded(context.Background(), "friend", &collectionUser{ID: 2})
+	want := `{"id":1,"_embedded":{"friend":{"id":2,"_links":{"acme:orders":{"href":"/api/v2/users/2/orders"},` +
+		`"curies":[{"href":"/rels/{rel}","templated":true,"name":"acme"}],"self":{"href":"/api/v2/users/2"}}}},` +
+		`"_links":{"acme:orders":{"href":"/api/v2/users/1/orders"},` +
+		`"curies":[{"href":"/rels/{rel}","templated":true,"name":"acme"}],"self":{"href":"/api/v2/users/1"}}}`
+	if got := marshalString(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if l := env.Links()["self"]; l[0].Href != "/users/1" {
+		t.Fatalf("expected the envelope's links to stay unchanged, got %q", l[0].Href)
+	}
+}
+
+type prefixKey struct{}
+
+func TestWithContextLinkRewriter(t *testing.T) {
+	inst := New(
+		WithContextLinkRewriter(func(ctx context.Context) func(Link) Link {
+			prefix, _ := ctx.Value(prefixKey{}).(string)
+			return PrefixRewriter(prefix)
+		}),
+		WithAbsoluteLinks(),
+	)
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	ctx := context.WithValue(context.Background(), prefixKey{}, "/api/v2")
+
+	page := inst.Collection(ctx, []*collectionUser{{ID: 1}}, 1, SelfLink("/users"))
+	want := `{"_links":{"self":{"href":"/api/v2/users"}},"_embedded":{"items":[{"id":1,"_links":{"self":{"href":"/api/v2/users/1"}}}]},"count":1,"total":1}`
+	if got := marshalString(t, page); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	ctx = WithBaseURL(ctx, &url.URL{Scheme: "https", Host: "api.example.com"})
+	if got := marshalString(t, inst.Wrap(ctx, &collectionUser{ID: 1})); got != `{"id":1,"_links":{"self":{"href":"https://api.example.com/api/v2/users/1"}}}` {
+		t.Fatalf("expected the prefix before the base URL, got %s", got)
+	}
+}
+
+func TestRequestPrefixRewriter(t *testing.T) {
+	if RequestPrefixRewriter(context.Background()) != nil {
+		t.Fatal("expected nil without RequestInfo")
+	}
+	ctx := WithRequestInfo(context.Background(), RequestInfo{Prefix: "/api"})
+	if got := RequestPrefixRewriter(ctx)(Link{Href: "/users"}).Href; got != "/api/users" {
+		t.Fatalf("expected /api/users, got %q", got)
+	}
+}
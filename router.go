@@ -0,0 +1,102 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Router dispatches wrapping to one of several instances, keyed for example
+// by tenant, so that tenants with different feature sets get different link
+// registries. It is safe for concurrent use.
+//
+// # Example
+//
+//	r := hal.NewRouter(hal.DefaultInstance)
+//	r.Add("acme", acmeInst)
+//	r.Add("globex", globexInst)
+//	r.Resolve(func(ctx context.Context) string { return tenantFrom(ctx) })
+//
+//	env, err := r.Wrap(ctx, user)
+type Router struct {
+	mu           sync.RWMutex
+	instances    map[string]*Instance
+	resolve      func(ctx context.Context) string
+	fallback     *Instance
+	requireKnown bool
+}
+
+// NewRouter creates a Router that uses fallback for unknown keys.
+// A nil fallback means DefaultInstance.
+func NewRouter(fallback *Instance) *Router {
+	if fallback == nil {
+		fallback = DefaultInstance
+	}
+	return &Router{
+		instances: make(map[string]*Instance),
+		fallback:  fallback,
+	}
+}
+
+// Add routes key to inst, replacing any previous instance for key.
+func (r *Router) Add(key string, inst *Instance) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.instances[key] = inst
+}
+
+// Resolve sets the function deriving the routing key from the context.
+// Without it every call uses the fallback instance.
+func (r *Router) Resolve(fn func(ctx context.Context) string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resolve = fn
+}
+
+// RequireKnownKey makes keys without an instance an error wrapping
+// ErrUnknownRouteKey instead of using the fallback instance.
+func (r *Router) RequireKnownKey() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requireKnown = true
+}
+
+// Instance returns the instance for the key resolved from ctx.
+func (r *Router) Instance(ctx context.Context) (*Instance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var key string
+	if r.resolve != nil {
+		key = r.resolve(ctx)
+	}
+	if inst, ok := r.instances[key]; ok {
+		return inst, nil
+	}
+	if r.requireKnown {
+		return nil, fmt.Errorf("%w %q", ErrUnknownRouteKey, key)
+	}
+	return r.fallback, nil
+}
+
+// Wrap wraps data with the instance for the key resolved from ctx.
+func (r *Router) Wrap(ctx context.Context, data any) (*Envelope, error) {
+	inst, err := r.Instance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return inst.Wrap(ctx, data), nil
+}
+
+// Collection builds a CollectionPage with the instance for the key resolved
+// from ctx. Like Instance.Collection, it panics if items is not a slice.
+func (r *Router) Collection(ctx context.Context, items any, total int, selfLink Link, opts ...CollectionOption) (*CollectionPage, error) {
+	inst, err := r.Instance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return inst.Collection(ctx, items, total, selfLink, opts...), nil
+}
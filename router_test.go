@@ -0,0 +1,97 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func tenantRouter() *Router {
+	basic := New()
+	RegisterInstance(basic, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	premium := New()
+	RegisterInstance(premium, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{
+			{Rel: "self", Href: "/users/" + itoa(u.ID)},
+			{Rel: "analytics", Href: "/users/" + itoa(u.ID) + "/analytics"},
+		}
+	})
+
+	r := NewRouter(New())
+	r.Add("acme", basic)
+	r.Add("globex", premium)
+	r.Resolve(func(ctx context.Context) string {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+		return tenant
+	})
+	return r
+}
+
+func tenantCtx(tenant string) context.Context {
+	return context.WithValue(context.Background(), tenantKey{}, tenant)
+}
+
+func TestRouter_DispatchesByTenant(t *testing.T) {
+	r := tenantRouter()
+
+	tests := map[string]string{
+		"acme":   `{"id":1,"_links":{"self":{"href":"/users/1"}}}`,
+		"globex": `{"id":1,"_links":{"analytics":{"href":"/users/1/analytics"},"self":{"href":"/users/1"}}}`,
+		"other":  wantUserID1,
+	}
+	for tenant, want := range tests {
+		env, err := r.Wrap(tenantCtx(tenant), &collectionUser{ID: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := json.Marshal(env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != want {
+			t.Errorf("tenant %s: expected %s, got %s", tenant, want, b)
+		}
+	}
+}
+
+func TestRouter_Collection(t *testing.T) {
+	page, err := tenantRouter().Collection(tenantCtx("globex"), []*collectionUser{{ID: 2}}, 1, Link{Rel: "self", Href: "/users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !contains(b, `"/users/2/analytics"`) {
+		t.Fatalf("expected items wrapped by the tenant instance, got %s", b)
+	}
+}
+
+func TestRouter_RequireKnownKey(t *testing.T) {
+	r := tenantRouter()
+	r.RequireKnownKey()
+
+	if _, err := r.Wrap(tenantCtx("acme"), &collectionUser{ID: 1}); err != nil {
+		t.Fatalf("expected known key to succeed, got %v", err)
+	}
+	if _, err := r.Wrap(tenantCtx("initech"), &collectionUser{ID: 1}); !errors.Is(err, ErrUnknownRouteKey) {
+		t.Fatalf("expected ErrUnknownRouteKey, got %v", err)
+	}
+	if _, err := r.Collection(tenantCtx("initech"), []*collectionUser{}, 0, Link{}); !errors.Is(err, ErrUnknownRouteKey) {
+		t.Fatalf("expected ErrUnknownRouteKey from Collection, got %v", err)
+	}
+}
+
+func TestRouter_DefaultsToDefaultInstance(t *testing.T) {
+	inst, err := NewRouter(nil).Instance(context.Background())
+	if err != nil || inst != DefaultInstance {
+		t.Fatalf("expected DefaultInstance, got %p, %v", inst, err)
+	}
+}
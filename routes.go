@@ -0,0 +1,113 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"fmt"
+	"maps"
+)
+
+// RegisterRoute registers a named route pattern on the DefaultInstance.
+// See Instance.RegisterRoute.
+func RegisterRoute(name, pattern string) {
+	DefaultInstance.RegisterRoute(name, pattern)
+}
+
+// LinkFor builds a link to a route registered on the DefaultInstance.
+// See Instance.LinkFor.
+func LinkFor(name string, params map[string]any, opts ...LinkOption) (Link, error) {
+	return DefaultInstance.LinkFor(name, params, opts...)
+}
+
+// RegisterRoute registers pattern under name, so generators can build hrefs
+// with LinkFor instead of concatenating strings. The pattern is a URI
+// template whose {param} placeholders are filled by LinkFor; any RFC 6570
+// expression, such as {?page}, may be used. Registering a name again
+// replaces its pattern.
+//
+// Malformed patterns panic in strict mode. Otherwise they are registered as
+// given and reported to the lint hook, and LinkFor fails for them.
+//
+// # Example
+//
+//	inst.RegisterRoute("user", "/users/{id}")
+//	inst.RegisterRoute("user-orders", "/users/{id}/orders{?page}")
+func (i *Instance) RegisterRoute(name, pattern string) {
+	if _, _, err := expandTemplate(pattern, nil, missingKeep); err != nil {
+		err = fmt.Errorf("hal: route %q: %w", name, err)
+		if i.strictMode {
+			panic(err)
+		}
+		i.lintf("%v", err)
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.routes == nil {
+		i.routes = make(map[string]string)
+	}
+	i.routes[name] = pattern
+}
+
+// Routes returns a copy of the registered route patterns, keyed by name.
+func (i *Instance) Routes() map[string]string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return maps.Clone(i.routes)
+}
+
+// LinkOption configures a link built by LinkFor.
+type LinkOption func(*Link)
+
+// AsRel sets the link's rel. LinkFor uses the route name by default.
+func AsRel(rel string) LinkOption {
+	return func(l *Link) { l.Rel = rel }
+}
+
+// LinkTitle sets the link's title.
+func LinkTitle(title string) LinkOption {
+	return func(l *Link) { l.Title = title }
+}
+
+// LinkName sets the link's name.
+func LinkName(name string) LinkOption {
+	return func(l *Link) { l.Name = name }
+}
+
+// LinkMethod sets the link's method hint.
+func LinkMethod(method string) LinkOption {
+	return func(l *Link) { l.Method = method }
+}
+
+// LinkFor builds a link to the route registered under name. Placeholders
+// are filled from params and percent-encoded as URI Template expansion
+// specifies, so "a/b" becomes "a%2Fb". Placeholders without a value in
+// params are left in the href and the link is marked Templated, for clients
+// to finish. Params not used by the pattern are ignored.
+//
+// The link's rel is the route name unless AsRel sets another. An unknown
+// name returns an error wrapping ErrUnknownRoute.
+//
+// # Example
+//
+//	inst.RegisterRoute("user-orders", "/users/{id}/orders{?page}")
+//
+//	l, err := inst.LinkFor("user-orders", map[string]any{"id": 42}, hal.AsRel("orders"))
+//	// l.Href == "/users/42/orders{?page}", l.Templated == true
+func (i *Instance) LinkFor(name string, params map[string]any, opts ...LinkOption) (Link, error) {
+	i.mu.RLock()
+	pattern, ok := i.routes[name]
+	i.mu.RUnlock()
+	if !ok {
+		return Link{}, fmt.Errorf("%w %q", ErrUnknownRoute, name)
+	}
+	href, templated, err := expandTemplate(pattern, params, missingKeep)
+	if err != nil {
+		return Link{}, fmt.Errorf("hal: route %q: %w", name, err)
+	}
+	l := Link{Rel: name, Href: href, Templated: templated}
+	for _, opt := range opts {
+		opt(&l)
+	}
+	return l, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLinkFor(t *testing.T) {
+	inst := New()
+	inst.RegisterRoute("user", "/users/{id}")
+	inst.RegisterRoute("file", "/files/{path}")
+
+	l, err := inst.LinkFor("user", map[string]any{"id": 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !l.Equal(Link{Rel: "user", Href: "/users/42"}) {
+		t.Fatalf("unexpected link %+v", l)
+	}
+
+	l, err = inst.LinkFor("file", map[string]any{"path": "a/b c"}, AsRel("self"), LinkTitle("File"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !l.Equal(Link{Rel: "self", Href: "/files/a%2Fb%20c", Title: "File"}) {
+		t.Fatalf("expected escaped param and options applied, got %+v", l)
+	}
+}
+
+func TestLinkFor_PartialFill(t *testing.T) {
+	inst := New()
+	inst.RegisterRoute("user-orders", "/users/{id}/orders/{orderID}{?page}")
+
+	l, err := inst.LinkFor("user-orders", map[string]any{"id": 7}, AsRel("orders"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Href != "/users/7/orders/{orderID}{?page}" || !l.Templated {
+		t.Fatalf("expected a templated link with unfilled params kept, got %+v", l)
+	}
+
+	l, err = inst.LinkFor("user-orders", map[string]any{"id": 7, "orderID": 3, "page": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Href != "/users/7/orders/3?page=2" || l.Templated {
+		t.Fatalf("expected a fully expanded link, got %+v", l)
+	}
+}
+
+func TestLinkFor_UnknownRoute(t *testing.T) {
+	if _, err := New().LinkFor("missing", nil); !errors.Is(err, ErrUnknownRoute) {
+		t.Fatalf("expected ErrUnknownRoute, got %v", err)
+	}
+}
+
+func TestRegisterRoute_Invalid(t *testing.T) {
+	var lints []string
+	inst := New(WithLintHook(func(msg string) { lints = append(lints, msg) }))
+	inst.RegisterRoute("broken", "/users/{id")
+	if len(lints) != 1 || !strings.Contains(lints[0], `route "broken"`) {
+		t.Fatalf("expected a lint warning, got %v", lints)
+	}
+	if _, err := inst.LinkFor("broken", nil); !errors.Is(err, ErrInvalidTemplate) {
+		t.Fatalf("expected ErrInvalidTemplate, got %v", err)
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrInvalidTemplate) {
+			t.Fatalf("expected strict mode to panic with ErrInvalidTemplate, got %v", err)
+		}
+	}()
+	New(WithStrictMode()).RegisterRoute("broken", "/users/{id")
+}
+
+func TestLinkFor_InGenerator(t *testing.T) {
+	inst := New()
+	inst.RegisterRoute("user", "/users/{id}")
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		l, _ := inst.LinkFor("user", map[string]any{"id": u.ID}, AsRel(RelSelf))
+		return []Link{l}
+	})
+	if got := marshalString(t, inst.Wrap(context.Background(), &collectionUser{ID: 5})); got != `{"id":5,"_links":{"self":{"href":"/users/5"}}}` {
+		t.Fatalf("unexpected output %s", got)
+	}
+}
+
+func TestRoutes_CloneMerge(t *testing.T) {
+	base := New()
+	base.RegisterRoute("user", "/users/{id}")
+	c := base.Clone()
+	c.RegisterRoute("order", "/orders/{id}")
+	if _, ok := base.Routes()["order"]; ok {
+		t.Fatal("expected clone routes not to leak into base")
+	}
+
+	other := New()
+	other.RegisterRoute("user", "/members/{id}")
+	if err := base.Merge(other); !errors.Is(err, ErrRegistrationConflict) || !strings.Contains(err.Error(), `route "user"`) {
+		t.Fatalf("expected a route conflict, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+//go:build go1.23
+
+package hal
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	json "github.com/goccy/go-json"
+)
+
+// streamFlushEvery is the number of items StreamCollection writes between
+// flushes of an http.Flusher.
+const streamFlushEvery = 256
+
+// StreamCollection writes a collection document to w while consuming items,
+// for exports too large to hold as a CollectionPage. Each item is wrapped
+// and written as it is yielded, so memory use does not grow with the number
+// of items. The document has the same members as a CollectionPage: _links,
+// _embedded, then count, which is written last, total and any WithMeta
+// members. Nil items are skipped.
+//
+// Output is buffered and, if w implements http.Flusher, flushed every few
+// hundred items. Items emit their own curies, since the page's curies are
+// written before the items are known.
+//
+// If ctx is cancelled or an item fails, by a generator error (see
+// WithCollectionErrorPolicy) or a strict mode failure, the items array and
+// the document are closed, with count reporting the items written, and the
+// error is returned; a cancellation error wraps ctx.Err().
+// Errors from w are returned as-is, leaving a partial document.
+//
+// This method is only built with Go 1.23 or later.
+//
+// # Example
+//
+//	w.Header().Set("Content-Type", hal.MediaType)
+//	err := inst.StreamCollection(ctx, w, repo.AllUsers(ctx), total, hal.SelfLink("/users/export"))
+func (i *Instance) StreamCollection(ctx context.Context, w io.Writer, items iter.Seq[any], total int, selfLink Link, opts ...CollectionOption) error {
+	cfg, err := i.collectionConfig(append(opts[:len(opts):len(opts)], returnStrictErrors))
+	if err != nil {
+		return err
+	}
+	page := i.newCollectionPage(ctx, cfg, nil, total, selfLink)
+	links, err := json.Marshal(page.linksForMarshal())
+	if err != nil {
+		return fmt.Errorf("hal: marshaling _links: %w", err)
+	}
+	rel, _ := json.Marshal(cfg.itemsRel)
+
+	bw := bufio.NewWriter(w)
+	flusher, _ := w.(http.Flusher)
+	bw.WriteString(`{"_links":`)
+	bw.Write(links)
+	bw.WriteString(`,"_embedded":{`)
+	bw.Write(rel)
+	bw.WriteString(`:[`)
+
+	wrap := i.collectionItemWrapper(reflect.TypeFor[any](), cfg.wrapOpts)
+	dataBuf, metaBuf := getBuffer(), getBuffer()
+	defer putBuffer(dataBuf)
+	defer putBuffer(metaBuf)
+	var streamErr error
+	idx := 0
+	for item := range items {
+		if err := contextErr(ctx); err != nil {
+			streamErr = fmt.Errorf("hal: collection stream cancelled after %d items: %w", page.Count64, err)
+			break
+		}
+		if isNilData(item) {
+			idx++
+			continue
+		}
+		env, err := cfg.wrapItem(ctx, idx, item, wrap)
+		idx++
+		if err != nil {
+			streamErr = err
+			break
+		}
+		if env == nil {
+			continue
+		}
+		// The page's curies are already written.
+		env.hoistedCuries = false
+		parts, err := env.encodeParts((*dataBuf)[:0], (*metaBuf)[:0])
+		if err != nil {
+			streamErr = fmt.Errorf("hal: collection item %d: %w", idx-1, err)
+			break
+		}
+		if page.Count64 > 0 {
+			bw.WriteByte(',')
+		}
+		if _, err := writeParts(bw, parts); err != nil {
+			return err
+		}
+		page.Count64++
+		if flusher != nil && page.Count64%streamFlushEvery == 0 {
+			if err := bw.Flush(); err != nil {
+				return err
+			}
+			flusher.Flush()
+		}
+	}
+
+	tail, err := page.appendMeta(page.appendCounts(nil))
+	if err != nil {
+		tail = page.appendCounts(nil)
+		if streamErr == nil {
+			streamErr = err
+		}
+	}
+	bw.WriteString(`]},`)
+	bw.Write(tail[1:])
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if flusher != nil {
+		flusher.Flush()
+	}
+	return streamErr
+}
+
+// appendCounts appends a JSON object holding the page's count and total
+// members, as written by MarshalJSON.
+func (p *CollectionPage) appendCounts(dst []byte) []byte {
+	dst = append(dst, `{"count":`...)
+	count, total := p.counts()
+	dst = strconv.AppendInt(dst, count, 10)
+	if total != 0 || p.TotalKnown {
+		dst = append(dst, `,"total":`...)
+		dst = strconv.AppendInt(dst, total, 10)
+	}
+	return append(dst, '}')
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+//go:build go1.23
+
+package hal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"math"
+	"net/http/httptest"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestStreamCollection_MatchesCollection(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	ctx := context.Background()
+	items := []any{&collectionUser{ID: 1}, nil, &collectionUser{ID: 2}, map[string]int{"n": 3}}
+	opts := []CollectionOption{WithItemsRel("users"), WithMeta(map[string]any{"page": 1}), WithPageLinks(NextLink("/users?page=2"))}
+
+	var buf bytes.Buffer
+	if err := inst.StreamCollection(ctx, &buf, slices.Values(items), 10, SelfLink("/users"), opts...); err != nil {
+		t.Fatal(err)
+	}
+	if want := marshalString(t, inst.Collection(ctx, items, 10, SelfLink("/users"), opts...)); buf.String() != want {
+		t.Fatalf("expected %s, got %s", want, buf.String())
+	}
+
+	buf.Reset()
+	if err := inst.StreamCollection(ctx, &buf, slices.Values([]any{}), 0, SelfLink("/users")); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != `{"_links":{"self":{"href":"/users"}},"_embedded":{"items":[]},"count":0}` {
+		t.Fatalf("expected an empty collection, got %s", got)
+	}
+}
+
+func TestStreamCollection_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	seq := func(yield func(any) bool) {
+		for n := 0; ; n++ {
+			if n == 5 {
+				cancel()
+			}
+			if !yield(&collectionUser{ID: n}) {
+				return
+			}
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	err := New().StreamCollection(ctx, rec, seq, 0, SelfLink("/users"))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	var doc struct {
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil || doc.Count != 5 {
+		t.Fatalf("expected a closed document with 5 items, got %v: %s", err, rec.Body)
+	}
+	if !rec.Flushed {
+		t.Fatal("expected the http.Flusher to be flushed")
+	}
+}
+
+// heapSampler is a writer discarding its input that samples the live heap
+// after every MB written.
+type heapSampler struct {
+	written, next int
+	peak          uint64
+}
+
+func (h *heapSampler) Write(p []byte) (int, error) {
+	h.written += len(p)
+	if h.written >= h.next {
+		h.next += 1 << 20
+		runtime.GC()
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		h.peak = max(h.peak, m.HeapAlloc)
+	}
+	return len(p), nil
+}
+
+func TestStreamCollection_BoundedMemory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("streams 100k items")
+	}
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	users := func(n int) iter.Seq[any] {
+		return func(yield func(any) bool) {
+			for id := 0; id < n; id++ {
+				if !yield(&collectionUser{ID: id}) {
+					return
+				}
+			}
+		}
+	}
+
+	// Warm up the encoders' one-time type caches before measuring.
+	if err := inst.StreamCollection(context.Background(), &heapSampler{}, users(10), 10, SelfLink("/users")); err != nil {
+		t.Fatal(err)
+	}
+	runtime.GC()
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	w := &heapSampler{}
+	if err := inst.StreamCollection(context.Background(), w, users(100_000), 100_000, SelfLink("/users")); err != nil {
+		t.Fatal(err)
+	}
+	// A buffered page of 100k envelopes would hold tens of MB.
+	if growth := int64(w.peak) - int64(before.HeapAlloc); growth > 1<<20 {
+		t.Fatalf("expected bounded memory, heap grew by %d bytes while writing %d", growth, w.written)
+	}
+
+	perItem := func(n int) float64 {
+		return testing.AllocsPerRun(3, func() {
+			_ = inst.StreamCollection(context.Background(), &heapSampler{next: math.MaxInt}, users(n), n, SelfLink("/users"))
+		}) / float64(n)
+	}
+	if small, large := perItem(1_000), perItem(20_000); large > small*1.1 {
+		t.Fatalf("expected allocations per item not to grow with the item count, got %.2f vs %.2f", small, large)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// StrictCheck selects a validation applied to generator output at wrap time.
+// Checks are bit flags and can be combined:
+//
+//	inst := hal.New(hal.WithStrictChecks(hal.CheckHref | hal.CheckMethod))
+type StrictCheck uint
+
+const (
+	// CheckRel rejects links with an empty Rel.
+	CheckRel StrictCheck = 1 << iota
+	// CheckHref rejects links with an empty Href.
+	CheckHref
+	// CheckMethod rejects Method values that are not standard HTTP verbs.
+	CheckMethod
+	// CheckTemplated rejects Templated links whose Href has no template expression.
+	CheckTemplated
+	// CheckDuplicateSelf rejects generators returning more than one "self" link.
+	CheckDuplicateSelf
+
+	// CheckAll enables every check. It is the default for WithStrictMode.
+	CheckAll = CheckRel | CheckHref | CheckMethod | CheckTemplated | CheckDuplicateSelf
+)
+
+// WithStrictChecks selects which generator output checks run at wrap time.
+// It may be used on its own to adopt link validation incrementally, or together
+// with WithStrictMode to narrow the default CheckAll set.
+// A violating link causes Wrap to panic with the type and link in the message.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithStrictMode(), hal.WithStrictChecks(hal.CheckHref))
+func WithStrictChecks(checks StrictCheck) InstanceOption {
+	return func(i *Instance) {
+		i.strictChecks = checks
+		i.strictChecksSet = true
+	}
+}
+
+// httpMethods is the set of accepted Link.Method values under CheckMethod.
+var httpMethods = map[string]struct{}{
+	http.MethodGet:     {},
+	http.MethodHead:    {},
+	http.MethodPost:    {},
+	http.MethodPut:     {},
+	http.MethodPatch:   {},
+	http.MethodDelete:  {},
+	http.MethodConnect: {},
+	http.MethodOptions: {},
+	http.MethodTrace:   {},
+}
+
+// validateLinks returns a description of the first link violating checks,
+// or the empty string if all links pass.
+func validateLinks(links []Link, checks StrictCheck) string {
+	selfCount := 0
+	for _, l := range links {
+		switch {
+		case checks&CheckRel != 0 && l.Rel == "":
+			return fmt.Sprintf("empty rel in link %s", linkString(l))
+		case checks&CheckHref != 0 && l.Href == "":
+			return fmt.Sprintf("empty href in link %s", linkString(l))
+		case checks&CheckMethod != 0 && l.Method != "" && !isHTTPMethod(l.Method):
+			return fmt.Sprintf("invalid method %q in link %s", l.Method, linkString(l))
+		case checks&CheckTemplated != 0 && l.Templated && !strings.Contains(l.Href, "{"):
+			return fmt.Sprintf("templated link without template expression %s", linkString(l))
+		}
+		if l.Rel == "self" {
+			selfCount++
+			if checks&CheckDuplicateSelf != 0 && selfCount > 1 {
+				return fmt.Sprintf("duplicate self link %s", linkString(l))
+			}
+		}
+	}
+	return ""
+}
+
+// linkString formats l for messages as it is serialized: its rel, then
+// the link object.
+func linkString(l Link) string {
+	b, err := l.MarshalJSON()
+	if err != nil {
+		return fmt.Sprintf("%q: %v", l.Rel, err)
+	}
+	return fmt.Sprintf("%q: %s", l.Rel, b)
+}
+
+func isHTTPMethod(m string) bool {
+	_, ok := httpMethods[m]
+	return ok
+}
+
+// checkGeneratorOutput returns an error wrapping ErrInvalidLink if links
+// violate the instance's strict checks.
+func (i *Instance) checkGeneratorOutput(t reflect.Type, links []Link) error {
+	if i.strictChecks == 0 {
+		return nil
+	}
+	if msg := validateLinks(links, i.strictChecks); msg != "" {
+		return fmt.Errorf("%w: type %v: %s", ErrInvalidLink, t, msg)
+	}
+	return nil
+}
+
+// WithStrictKinds sets the data kinds that must have a registered generator
+// in strict mode. Pointers and interfaces are unwrapped to the underlying
+// value before its kind is compared. The default is reflect.Struct, so
+// structs and pointers to structs are checked.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithStrictMode(), hal.WithStrictKinds(reflect.Struct, reflect.Map))
+func WithStrictKinds(kinds ...reflect.Kind) InstanceOption {
+	return func(i *Instance) {
+		i.strictKinds = make(map[reflect.Kind]struct{}, len(kinds))
+		for _, k := range kinds {
+			i.strictKinds[k] = struct{}{}
+		}
+	}
+}
+
+// WithStrictExemptions exempts the types of the given sample values from the
+// strict mode generator requirement, for intentionally link-less DTOs.
+// A sample exempts both its value and pointer forms.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithStrictMode(), hal.WithStrictExemptions(HealthStatus{}))
+func WithStrictExemptions(samples ...any) InstanceOption {
+	return func(i *Instance) {
+		if i.strictExempt == nil {
+			i.strictExempt = make(map[reflect.Type]struct{}, len(samples))
+		}
+		for _, s := range samples {
+			i.strictExempt[baseType(reflect.TypeOf(s))] = struct{}{}
+		}
+	}
+}
+
+// requiresGenerator reports whether strict mode demands a generator for data.
+func (i *Instance) requiresGenerator(data any) bool {
+	if _, ok := i.strictExempt[baseType(reflect.TypeOf(data))]; ok {
+		return false
+	}
+	kind := underlyingKind(reflect.ValueOf(data))
+	if i.strictKinds == nil {
+		return kind == reflect.Struct
+	}
+	_, ok := i.strictKinds[kind]
+	return ok
+}
+
+// underlyingKind unwraps pointers and interfaces to the dynamic value's kind.
+// Nil pointers report the kind of their static element type.
+func underlyingKind(v reflect.Value) reflect.Kind {
+	for {
+		switch v.Kind() {
+		case reflect.Ptr:
+			if v.IsNil() {
+				return baseType(v.Type()).Kind()
+			}
+			v = v.Elem()
+		case reflect.Interface:
+			if v.IsNil() {
+				return reflect.Interface
+			}
+			v = v.Elem()
+		default:
+			return v.Kind()
+		}
+	}
+}
+
+// baseType strips all pointer indirections from t.
+func baseType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
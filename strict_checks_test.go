@@ -0,0 +1,93 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type checkedUser struct {
+	ID int `json:"id"`
+}
+
+func wrapWithLinks(inst *Instance, links ...Link) (panicMsg string) {
+	RegisterInstance(inst, func(_ context.Context, _ *checkedUser) []Link {
+		return links
+	})
+	defer func() {
+		if r := recover(); r != nil {
+			panicMsg = fmt.Sprint(r)
+		}
+	}()
+	inst.Wrap(context.Background(), &checkedUser{ID: 1})
+	return ""
+}
+
+func TestStrictChecks_PerCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		check StrictCheck
+		link  Link
+		want  string
+	}{
+		{"empty rel", CheckRel, Link{Href: "/a"}, `empty rel in link "": {"href":"/a"}`},
+		{"empty href", CheckHref, Link{Rel: "self"}, `empty href in link "self": {"href":""}`},
+		{"invalid method", CheckMethod, Link{Rel: "edit", Href: "/a", Method: "FETCH"}, `invalid method "FETCH" in link "edit": {"href":"/a","method":"FETCH"}`},
+		{"lowercase method", CheckMethod, Link{Rel: "edit", Href: "/a", Method: "get"}, `invalid method "get"`},
+		{"templated without expression", CheckTemplated, Link{Rel: "search", Href: "/a", Templated: true}, `templated link without template expression "search": {"href":"/a","templated":true}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := wrapWithLinks(New(WithStrictChecks(tt.check)), tt.link)
+			if !strings.Contains(msg, tt.want) {
+				t.Fatalf("expected panic containing %q, got %q", tt.want, msg)
+			}
+			if !strings.Contains(msg, "checkedUser") {
+				t.Fatalf("expected type in panic message, got %q", msg)
+			}
+
+			// The same link passes when only unrelated checks are enabled.
+			if msg := wrapWithLinks(New(WithStrictChecks(CheckAll&^tt.check)), tt.link); msg != "" {
+				t.Fatalf("expected no panic with check disabled, got %q", msg)
+			}
+		})
+	}
+}
+
+func TestStrictChecks_DuplicateSelf(t *testing.T) {
+	msg := wrapWithLinks(New(WithStrictChecks(CheckDuplicateSelf)),
+		Link{Rel: "self", Href: "/a"},
+		Link{Rel: "self", Href: "/b"},
+	)
+	if !strings.Contains(msg, `duplicate self link "self": {"href":"/b"}`) {
+		t.Fatalf("expected duplicate self panic naming the link, got %q", msg)
+	}
+}
+
+func TestStrictChecks_ValidLinksPass(t *testing.T) {
+	msg := wrapWithLinks(New(WithStrictMode()),
+		Link{Rel: "self", Href: "/users/1", Method: "GET"},
+		Link{Rel: "search", Href: "/users{?q}", Templated: true},
+		Link{Rel: "edit", Href: "/users/1", Method: "PATCH"},
+	)
+	if msg != "" {
+		t.Fatalf("expected valid links to pass, got %q", msg)
+	}
+}
+
+func TestStrictChecks_StrictModeDefaultsToAll(t *testing.T) {
+	if got := New(WithStrictMode()).strictChecks; got != CheckAll {
+		t.Fatalf("expected CheckAll by default, got %b", got)
+	}
+	if got := New(WithStrictChecks(CheckHref), WithStrictMode()).strictChecks; got != CheckHref {
+		t.Fatalf("expected narrowed checks regardless of option order, got %b", got)
+	}
+	if got := New().strictChecks; got != 0 {
+		t.Fatalf("expected no checks without strict mode, got %b", got)
+	}
+}
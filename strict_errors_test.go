@@ -0,0 +1,111 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func strictErrorsInstance() *Instance {
+	inst := New(WithStrictErrors())
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	return inst
+}
+
+func TestWithStrictErrors_MarshalFails(t *testing.T) {
+	type unknown struct{}
+	tests := []struct {
+		name string
+		data any
+		want error
+		typ  string
+	}{
+		{"pointer mismatch", collectionUser{ID: 1}, ErrPointerMismatch, "hal.collectionUser"},
+		{"missing registration", &unknown{}, ErrNoGenerator, "*hal.unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inst := strictErrorsInstance()
+			env := inst.Wrap(context.Background(), tt.data) // Must not panic.
+
+			_, err := json.Marshal(env)
+			if !errors.Is(err, tt.want) || !strings.Contains(err.Error(), tt.typ) {
+				t.Fatalf("expected %v naming %s, got %v", tt.want, tt.typ, err)
+			}
+			if !errors.Is(env.Err(), tt.want) {
+				t.Errorf("expected Err to report %v, got %v", tt.want, env.Err())
+			}
+			if _, err := inst.WrapE(context.Background(), tt.data); !errors.Is(err, tt.want) {
+				t.Errorf("expected WrapE to return %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestWithStrictErrors_Valid(t *testing.T) {
+	env := strictErrorsInstance().Wrap(context.Background(), &collectionUser{ID: 1})
+	if got := marshalString(t, env); got != `{"id":1,"_links":{"self":{"href":"/users/1"}}}` {
+		t.Fatalf("unexpected output %s", got)
+	}
+}
+
+func TestWithStrictErrors_CollectionAndEmbedded(t *testing.T) {
+	type unknown struct{ ID int }
+	inst := strictErrorsInstance()
+	ctx := context.Background()
+
+	page := inst.Collection(ctx, []*unknown{{ID: 1}}, 1, SelfLink("/unknowns"))
+	if _, err := json.Marshal(page); !errors.Is(err, ErrNoGenerator) {
+		t.Fatalf("expected the item's violation from the page, got %v", err)
+	}
+
+	env := inst.Wrap(ctx, &collectionUser{ID: 1})
+	env.AddEmbedded(ctx, "thing", &unknown{ID: 2})
+	if _, err := json.Marshal(env); !errors.Is(err, ErrNoGenerator) {
+		t.Fatalf("expected the embedded violation, got %v", err)
+	}
+}
+
+func TestWithStrictErrors_RecoversGeneratorPanics(t *testing.T) {
+	inst := New(WithStrictErrors())
+	RegisterInstance(inst, func(_ context.Context, _ *collectionUser) []Link { panic("boom") })
+	env := inst.Wrap(context.Background(), &collectionUser{ID: 1})
+	if !errors.Is(env.Err(), ErrGeneratorPanic) {
+		t.Fatalf("expected the panic to be recorded, got %v", env.Err())
+	}
+}
+
+func TestWithStrictErrors_Respond(t *testing.T) {
+	type unknown struct{}
+	var lints []string
+	inst := New(WithStrictErrors(), WithLintHook(func(msg string) { lints = append(lints, msg) }))
+	w := httptest.NewRecorder()
+	inst.Respond(w, httptest.NewRequest(http.MethodGet, "/", nil), http.StatusOK, &unknown{})
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(lints) != 1 || !strings.Contains(lints[0], "no generator") {
+		t.Fatalf("expected the violation to be linted, got %q", lints)
+	}
+}
+
+func TestWithStrictMode_StillPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected WithStrictMode to keep panicking")
+		}
+	}()
+	inst := New(WithStrictMode())
+	RegisterInstance(inst, func(_ context.Context, _ *collectionUser) []Link { return nil })
+	inst.Clone().Wrap(context.Background(), collectionUser{})
+}
@@ -5,6 +5,11 @@ package hal
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -39,3 +44,149 @@ func TestStrictMode_NoGeneratorPanics(t *testing.T) {
 
 	inst.Wrap(context.Background(), &Unknown{})
 }
+
+func TestStrictMode_MapDataAllowedByDefault(t *testing.T) {
+	inst := New(WithStrictMode())
+
+	// Maps are not among the default strict kinds.
+	inst.Wrap(context.Background(), map[string]any{"id": 1})
+}
+
+func TestStrictKinds_MapDataPanics(t *testing.T) {
+	inst := New(WithStrictMode(), WithStrictKinds(reflect.Struct, reflect.Map))
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for map data without generator")
+		}
+	}()
+
+	inst.Wrap(context.Background(), map[string]any{"id": 1})
+}
+
+func TestStrictKinds_InterfaceUnwrapsToDynamicType(t *testing.T) {
+	type Hidden struct{}
+
+	inst := New(WithStrictMode())
+	var v any = Hidden{}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for interface holding an unregistered struct")
+		}
+	}()
+
+	inst.Wrap(context.Background(), &v)
+}
+
+func TestStrictExemptions_SkipsExemptedType(t *testing.T) {
+	type HealthStatus struct{}
+	type Other struct{}
+
+	inst := New(WithStrictMode(), WithStrictExemptions(HealthStatus{}))
+
+	// Both value and pointer forms of the exempted type pass.
+	inst.Wrap(context.Background(), HealthStatus{})
+	inst.Wrap(context.Background(), &HealthStatus{})
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for non-exempted type")
+		}
+	}()
+
+	inst.Wrap(context.Background(), &Other{})
+}
+
+func TestWrapE_StrictValueVsPointer(t *testing.T) {
+	type User struct{}
+
+	inst := New(WithStrictMode())
+	RegisterInstance(inst, func(_ context.Context, _ *User) []Link {
+		return nil
+	})
+
+	env, err := inst.WrapE(context.Background(), User{})
+	if env != nil || !errors.Is(err, ErrPointerMismatch) {
+		t.Fatalf("expected ErrPointerMismatch, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "hal.User") || !strings.Contains(err.Error(), "*hal.User") {
+		t.Fatalf("expected both types in error, got %v", err)
+	}
+}
+
+func TestWrapE_StrictNoGenerator(t *testing.T) {
+	type Unknown struct{}
+
+	inst := New(WithStrictMode())
+	_, err := inst.WrapE(context.Background(), &Unknown{})
+	if !errors.Is(err, ErrNoGenerator) || !strings.Contains(err.Error(), "*hal.Unknown") {
+		t.Fatalf("expected ErrNoGenerator naming the type, got %v", err)
+	}
+}
+
+func TestWrapE_StrictInvalidLink(t *testing.T) {
+	inst := New(WithStrictMode())
+	RegisterInstance(inst, func(_ context.Context, _ *checkedUser) []Link {
+		return []Link{{Rel: "self"}}
+	})
+
+	if _, err := inst.WrapE(context.Background(), &checkedUser{ID: 1}); !errors.Is(err, ErrInvalidLink) {
+		t.Fatalf("expected ErrInvalidLink, got %v", err)
+	}
+}
+
+func TestWrapE_StrictNestedEmbedFailure(t *testing.T) {
+	type Unknown struct{}
+
+	inst := New(WithStrictMode(), WithAfterWrap(func(ctx context.Context, e *Envelope) {
+		e.AddEmbedded(ctx, "unknown", &Unknown{})
+	}))
+	RegisterInstance(inst, func(_ context.Context, _ *checkedUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/1"}}
+	})
+
+	if _, err := inst.WrapE(context.Background(), &checkedUser{ID: 1}); !errors.Is(err, ErrNoGenerator) {
+		t.Fatalf("expected nested ErrNoGenerator, got %v", err)
+	}
+}
+
+func TestCollectionE_StrictItemFailure(t *testing.T) {
+	type Unknown struct{}
+
+	inst := New(WithStrictMode())
+	RegisterInstance(inst, func(_ context.Context, _ *checkedUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/1"}}
+	})
+	for _, opts := range [][]CollectionOption{nil, {WrapItems(ExtraLinks(Link{Rel: "up", Href: "/"}))}} {
+		_, err := inst.CollectionE(context.Background(), []any{&checkedUser{ID: 1}, &Unknown{}}, 2, SelfLink("/things"), opts...)
+		if !errors.Is(err, ErrNoGenerator) || !strings.Contains(err.Error(), "collection item 1") {
+			t.Fatalf("expected ErrNoGenerator for item 1, got %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	inst.Respond(w, httptest.NewRequest(http.MethodGet, "/things", nil), http.StatusOK, []*Unknown{{}})
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected Respond to fail with 500, got %d", w.Code)
+	}
+}
+
+func TestWrap_StrictPanicsWithSentinel(t *testing.T) {
+	type Unknown struct{}
+
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, ErrNoGenerator) {
+			t.Fatalf("expected panic with ErrNoGenerator, got %v", err)
+		}
+	}()
+	New(WithStrictMode()).Wrap(context.Background(), &Unknown{})
+}
+
+func TestWrapE_PackageLevel(t *testing.T) {
+	env, err := WrapE(context.Background(), &TestData{ID: 1})
+	if err != nil || env == nil {
+		t.Fatalf("expected envelope, got %v", err)
+	}
+}
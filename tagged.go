@@ -0,0 +1,230 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// RegisterTagged registers a generator for *T built from link declarations in
+// T's hal struct tags, so simple resources need no hand-written generator.
+// A declaration names the rel, then lists options:
+//
+//	type User struct {
+//	    _      struct{} `hal:"self,href=/users/{ID}"`
+//	    ID     int
+//	    Org    Org
+//	    Orders []Order `hal:"orders,href=/orgs/{Org.Slug}/users/{ID}/orders,method=GET"`
+//	}
+//
+// Declarations may sit on any field, including blank _ fields; several can
+// share one tag separated by semicolons. Placeholders name exported fields,
+// with dots walking into nested structs, and are replaced by the field value,
+// path-escaped. If a nil pointer is met on the way, the link is omitted.
+//
+// Supported options are href (required), method, title, name, type and
+// templated=true. Templated links may keep URI template expressions such as
+// {?page} verbatim. Tags of the form hal:"embed,..." are not link
+// declarations (see Wrap), nor are the constraints read by TemplateFor.
+//
+// Tags are parsed once, here; invalid declarations are reported as an error
+// wrapping ErrInvalidTag and nothing is registered.
+//
+// # Example
+//
+//	if err := hal.RegisterTagged[User](inst); err != nil {
+//	    log.Fatal(err)
+//	}
+func RegisterTagged[T any](i *Instance) error {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %v is not a struct", ErrInvalidTag, t)
+	}
+	var decls []taggedLink
+	for _, f := range reflect.VisibleFields(t) {
+		tag, ok := f.Tag.Lookup("hal")
+		if first, _, _ := strings.Cut(tag, ","); !ok || first == "embed" || isFormTag(tag) {
+			continue
+		}
+		for _, decl := range strings.Split(tag, ";") {
+			l, err := parseTaggedLink(t, decl)
+			if err != nil {
+				return fmt.Errorf("%w: %v.%s: %w", ErrInvalidTag, t, f.Name, err)
+			}
+			decls = append(decls, l)
+		}
+	}
+	if len(decls) == 0 {
+		return fmt.Errorf("%w: %v declares no links", ErrInvalidTag, t)
+	}
+
+	RegisterInstance(i, func(_ context.Context, v *T) []Link {
+		rv := reflect.ValueOf(v).Elem()
+		links := make([]Link, 0, len(decls))
+		for _, d := range decls {
+			if l, ok := d.build(rv); ok {
+				links = append(links, l)
+			}
+		}
+		return links
+	})
+	return nil
+}
+
+// taggedLink is a parsed link declaration.
+type taggedLink struct {
+	link  Link // Static fields; Href is built from parts
+	parts []hrefPart
+}
+
+// hrefPart is a literal piece of an href, or a field path to substitute.
+type hrefPart struct {
+	literal string
+	path    [][]int // Field indexes per dotted segment
+}
+
+func parseTaggedLink(t reflect.Type, decl string) (taggedLink, error) {
+	opts := strings.Split(decl, ",")
+	d := taggedLink{link: Link{Rel: strings.TrimSpace(opts[0])}}
+	if d.link.Rel == "" {
+		return d, fmt.Errorf("missing rel in %q", decl)
+	}
+	var href string
+	for _, opt := range opts[1:] {
+		key, val, ok := strings.Cut(opt, "=")
+		if !ok {
+			return d, fmt.Errorf("option %q is not key=value", opt)
+		}
+		switch key {
+		case "href":
+			href = val
+		case "method":
+			d.link.Method = val
+		case "title":
+			d.link.Title = val
+		case "name":
+			d.link.Name = val
+		case "type":
+			d.link.Type = val
+		case "templated":
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return d, fmt.Errorf("templated=%q: %w", val, err)
+			}
+			d.link.Templated = b
+		default:
+			return d, fmt.Errorf("unknown option %q", key)
+		}
+	}
+	if href == "" {
+		return d, fmt.Errorf("rel %q has no href", d.link.Rel)
+	}
+	parts, err := parseTaggedHref(t, href, d.link.Templated)
+	if err != nil {
+		return d, err
+	}
+	d.parts = parts
+	return d, nil
+}
+
+// parseTaggedHref splits href into literals and field placeholders.
+// Expressions that do not name a field are kept verbatim in templated links.
+func parseTaggedHref(t reflect.Type, href string, templated bool) ([]hrefPart, error) {
+	var parts []hrefPart
+	for href != "" {
+		start := strings.IndexByte(href, '{')
+		if start < 0 {
+			parts = append(parts, hrefPart{literal: href})
+			break
+		}
+		end := strings.IndexByte(href[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed placeholder in %q", href)
+		}
+		end += start
+		if start > 0 {
+			parts = append(parts, hrefPart{literal: href[:start]})
+		}
+		expr := href[start+1 : end]
+		path, err := fieldPath(t, expr)
+		switch {
+		case err == nil:
+			parts = append(parts, hrefPart{path: path})
+		case templated:
+			parts = append(parts, hrefPart{literal: href[start : end+1]})
+		default:
+			return nil, err
+		}
+		href = href[end+1:]
+	}
+	return parts, nil
+}
+
+// fieldPath resolves a dotted path of exported field names in t.
+func fieldPath(t reflect.Type, expr string) ([][]int, error) {
+	var path [][]int
+	cur := t
+	for _, name := range strings.Split(expr, ".") {
+		for cur.Kind() == reflect.Pointer {
+			cur = cur.Elem()
+		}
+		if cur.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("placeholder {%s}: %v is not a struct", expr, cur)
+		}
+		f, ok := cur.FieldByName(name)
+		if !ok || !f.IsExported() {
+			return nil, fmt.Errorf("placeholder {%s}: no exported field %s in %v", expr, name, cur)
+		}
+		path = append(path, f.Index)
+		cur = f.Type
+	}
+	return path, nil
+}
+
+// build produces the link for the resource rv, reporting false when a nil
+// pointer is met on a placeholder's field path.
+func (d taggedLink) build(rv reflect.Value) (Link, bool) {
+	var b strings.Builder
+	for _, p := range d.parts {
+		if p.path == nil {
+			b.WriteString(p.literal)
+			continue
+		}
+		v, ok := resolvePath(rv, p.path)
+		if !ok {
+			return Link{}, false
+		}
+		b.WriteString(url.PathEscape(fmt.Sprint(v.Interface())))
+	}
+	l := d.link
+	l.Href = b.String()
+	return l, true
+}
+
+func resolvePath(v reflect.Value, path [][]int) (reflect.Value, bool) {
+	for _, index := range path {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		var err error
+		if v, err = v.FieldByIndexErr(index); err != nil {
+			return reflect.Value{}, false
+		}
+	}
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
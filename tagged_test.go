@@ -0,0 +1,102 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type taggedOrg struct {
+	Slug string
+}
+
+type taggedUser struct {
+	_      struct{} `hal:"self,href=/orgs/{Org.Slug}/users/{ID}"`
+	ID     int      `json:"id"`
+	Name   string   `json:"name"`
+	Org    taggedOrg
+	Parent *taggedOrg `json:"-"`
+	Orders []int      `json:"-" hal:"orders,href=/users/{ID}/orders{?page},templated=true;delete,href=/users/{ID},method=DELETE,title=Remove"`
+}
+
+type taggedParent struct {
+	_      struct{} `hal:"up,href=/orgs/{Parent.Slug}"`
+	Parent *taggedOrg
+}
+
+func TestRegisterTagged_Links(t *testing.T) {
+	inst := New()
+	if err := RegisterTagged[taggedUser](inst); err != nil {
+		t.Fatal(err)
+	}
+
+	env := inst.Wrap(context.Background(), &taggedUser{ID: 7, Name: "n", Org: taggedOrg{Slug: "acme corp"}})
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"id":7,"name":"n","Org":{"Slug":"acme corp"},"_links":{` +
+		`"delete":{"href":"/users/7","title":"Remove","method":"DELETE"},` +
+		`"orders":{"href":"/users/7/orders{?page}","templated":true},` +
+		`"self":{"href":"/orgs/acme%20corp/users/7"}}}`
+	if string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+}
+
+func TestRegisterTagged_NilPathOmitsLink(t *testing.T) {
+	inst := New()
+	if err := RegisterTagged[taggedParent](inst); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if got := marshalString(t, inst.Wrap(ctx, &taggedParent{})); got != `{"Parent":null}` {
+		t.Fatalf("expected no links for nil parent, got %s", got)
+	}
+	got := marshalString(t, inst.Wrap(ctx, &taggedParent{Parent: &taggedOrg{Slug: "root"}}))
+	if !strings.Contains(got, `"up":{"href":"/orgs/root"}`) {
+		t.Fatalf("expected up link, got %s", got)
+	}
+}
+
+func TestRegisterTagged_InvalidTags(t *testing.T) {
+	type unknownField struct {
+		_ struct{} `hal:"self,href=/users/{Missing}"`
+	}
+	type noHref struct {
+		_ struct{} `hal:"self,method=GET"`
+	}
+	type badOption struct {
+		_ struct{} `hal:"self,href=/x,color=red"`
+	}
+	type unclosed struct {
+		_  struct{} `hal:"self,href=/users/{ID"`
+		ID int
+	}
+	type noLinks struct {
+		ID int
+	}
+
+	inst := New()
+	for name, err := range map[string]error{
+		"unknown field": RegisterTagged[unknownField](inst),
+		"no href":       RegisterTagged[noHref](inst),
+		"bad option":    RegisterTagged[badOption](inst),
+		"unclosed":      RegisterTagged[unclosed](inst),
+		"no links":      RegisterTagged[noLinks](inst),
+	} {
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("%s: expected ErrInvalidTag, got %v", name, err)
+		}
+	}
+	if types := inst.RegisteredTypes(); len(types) != 0 {
+		t.Fatalf("expected nothing registered, got %v", types)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"fmt"
+)
+
+// Transformer mutates an envelope after its links have been computed. It is
+// the last chance to adjust every envelope of an instance centrally, without
+// touching individual generators.
+type Transformer func(ctx context.Context, e *Envelope) error
+
+// WithTransformer registers a Transformer. Transformers run in registration
+// order at the end of Wrap (and so for each Collection item), with the context
+// passed to Wrap. If one fails, the remaining transformers are skipped and
+// marshaling the envelope returns the error; it is also reported by Err.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithTransformer(func(_ context.Context, e *hal.Envelope) error {
+//	    e.RenameRel("old-rel", "new-rel")
+//	    return nil
+//	}))
+func WithTransformer(fn Transformer) InstanceOption {
+	return func(i *Instance) {
+		i.transformers = append(i.transformers, fn)
+	}
+}
+
+// WithAfterWrap registers a callback invoked at the end of Wrap (and so for
+// each Collection item), after the generator has run and before any
+// Transformer. Callbacks run in registration order and typically attach
+// embedded resources based on the links the generator produced. Resources
+// embedded this way are wrapped by the same instance, so the callbacks run
+// for them as well.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithAfterWrap(func(ctx context.Context, e *hal.Envelope) {
+//	    if author, ok := e.LinkByName("author", ""); ok && wantsEmbed(ctx, "author") {
+//	        e.AddEmbedded(ctx, "author", loadAuthor(ctx, author.Href))
+//	    }
+//	}))
+func WithAfterWrap(fn func(ctx context.Context, e *Envelope)) InstanceOption {
+	return func(i *Instance) {
+		i.afterWrap = append(i.afterWrap, fn)
+	}
+}
+
+// finishWrap runs the after-wrap callbacks and then the transformers on e.
+// The envelope is charged to the context's wrap budget first, then fields
+// tagged with `hal:"embed"` are embedded.
+func (i *Instance) finishWrap(ctx context.Context, e *Envelope) {
+	if b := budgetFrom(ctx); b != nil {
+		b.take(e)
+	}
+	i.autoEmbed(ctx, e)
+	for _, fn := range i.afterWrap {
+		fn(ctx, e)
+	}
+	i.applyTransformers(ctx, e)
+}
+
+// applyTransformers runs the instance transformers on e.
+func (i *Instance) applyTransformers(ctx context.Context, e *Envelope) {
+	for _, fn := range i.transformers {
+		if err := fn(ctx, e); err != nil {
+			e.transformErr = fmt.Errorf("hal: transformer: %w", err)
+			return
+		}
+	}
+}
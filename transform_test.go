@@ -0,0 +1,162 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func renameOldRel(_ context.Context, e *Envelope) error {
+	e.RenameRel("old-rel", "new-rel")
+	return nil
+}
+
+func TestWithTransformer_RenamesRel(t *testing.T) {
+	inst := New(WithTransformer(renameOldRel))
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{
+			{Rel: "self", Href: "/users/1"},
+			{Rel: "old-rel", Href: "/legacy/1"},
+		}
+	})
+
+	b, err := json.Marshal(inst.Wrap(context.Background(), &collectionUser{ID: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":1,"_links":{"new-rel":{"href":"/legacy/1"},"self":{"href":"/users/1"}}}`
+	if string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+}
+
+func TestWithTransformer_RunsInOrder(t *testing.T) {
+	var order []string
+	record := func(name string) Transformer {
+		return func(context.Context, *Envelope) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	inst := New(WithTransformer(record("first")), WithTransformer(record("second")))
+
+	inst.Wrap(context.Background(), &collectionUser{ID: 1})
+
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Fatalf("expected transformers in registration order, got %v", order)
+	}
+}
+
+func TestWithTransformer_ErrorAbortsMarshal(t *testing.T) {
+	var ranAfter bool
+	inst := New(
+		WithTransformer(func(context.Context, *Envelope) error { return errBoom }),
+		WithTransformer(func(context.Context, *Envelope) error {
+			ranAfter = true
+			return nil
+		}),
+	)
+
+	env := inst.Wrap(context.Background(), &collectionUser{ID: 1})
+
+	if ranAfter {
+		t.Fatal("expected later transformers to be skipped")
+	}
+	if !errors.Is(env.Err(), errBoom) {
+		t.Fatalf("expected Err to report the transformer error, got %v", env.Err())
+	}
+	if _, err := json.Marshal(env); !errors.Is(err, errBoom) {
+		t.Fatalf("expected marshal to fail with errBoom, got %v", err)
+	}
+}
+
+func TestWithTransformer_AppliesToCollectionItems(t *testing.T) {
+	inst := New(WithTransformer(func(_ context.Context, e *Envelope) error {
+		e.AddLink(Link{Rel: "tenant", Href: "/tenants/acme"})
+		return nil
+	}))
+
+	page := inst.Collection(context.Background(), []*collectionUser{{ID: 1}}, 1, Link{Rel: "self", Href: "/users"})
+	items := page.Embedded["items"].([]*Envelope)
+	if _, ok := items[0].links["tenant"]; !ok {
+		t.Fatalf("expected transformer to run on collection items, got %v", items[0].links)
+	}
+}
+
+func TestEnvelope_RenameRel(t *testing.T) {
+	env := New().WrapRaw(&collectionUser{ID: 1})
+	env.AddLink(Link{Rel: "old-rel", Href: "/a"})
+	env.AddLink(Link{Rel: "old-rel", Href: "/b"})
+	env.AddLink(Link{Rel: "new-rel", Href: "/c"})
+
+	env.RenameRel("old-rel", "new-rel")
+	env.RenameRel("missing", "other")
+
+	if _, ok := env.links["old-rel"]; ok {
+		t.Fatal("expected old rel to be removed")
+	}
+	got, ok := env.links["new-rel"].([]any)
+	if !ok || len(got) != 3 {
+		t.Fatalf("expected three merged links, got %v", env.links["new-rel"])
+	}
+	if l := got[1].(Link); l.Rel != "new-rel" || l.Href != "/a" {
+		t.Fatalf("expected renamed link after existing ones, got %+v", l)
+	}
+}
+
+type authoredPost struct {
+	ID       int `json:"id"`
+	AuthorID int `json:"-"`
+}
+
+func TestWithAfterWrap_EmbedsFromGeneratorLinks(t *testing.T) {
+	inst := New(WithAfterWrap(func(ctx context.Context, e *Envelope) {
+		post, ok := e.Data.(*authoredPost)
+		if !ok {
+			return
+		}
+		if _, ok := e.LinkByName("author", ""); ok {
+			e.AddEmbedded(ctx, "author", &collectionUser{ID: post.AuthorID})
+		}
+	}))
+	RegisterInstance(inst, func(_ context.Context, p *authoredPost) []Link {
+		return []Link{{Rel: "author", Href: "/users/" + itoa(p.AuthorID)}}
+	})
+
+	b, err := json.Marshal(inst.Wrap(context.Background(), &authoredPost{ID: 1, AuthorID: 7}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":1,"_embedded":{"author":{"id":7}},"_links":{"author":{"href":"/users/7"}}}`
+	if string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+}
+
+func TestWithAfterWrap_OrderAndCollectionItems(t *testing.T) {
+	var order []string
+	inst := New(
+		WithAfterWrap(func(context.Context, *Envelope) { order = append(order, "first") }),
+		WithTransformer(func(context.Context, *Envelope) error {
+			order = append(order, "transformer")
+			return nil
+		}),
+		WithAfterWrap(func(context.Context, *Envelope) { order = append(order, "second") }),
+	)
+
+	inst.Collection(context.Background(), []*collectionUser{{ID: 1}, {ID: 2}}, 2, Link{Rel: "self", Href: "/users"})
+
+	want := []string{"first", "second", "transformer", "first", "second", "transformer"}
+	if len(order) != len(want) {
+		t.Fatalf("expected %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, order)
+		}
+	}
+}
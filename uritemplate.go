@@ -0,0 +1,429 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Expand expands the RFC 6570 URI template tmpl (up to level 4) with vars.
+//
+// Values may be strings, numbers, bools or any other value printable with
+// fmt.Sprint (used as strings), slices (lists) and maps (associative arrays,
+// expanded in key order). Variables that are missing, nil or empty lists/maps
+// are undefined and expand to nothing, as the RFC specifies.
+//
+// # Example
+//
+//	href, err := hal.Expand("/users/{id}/orders{?page,limit}", map[string]any{
+//	    "id": 42, "page": 2,
+//	})
+//	// href == "/users/42/orders?page=2"
+func Expand(tmpl string, vars map[string]any) (string, error) {
+	s, _, err := expandTemplate(tmpl, vars, missingEmpty)
+	return s, err
+}
+
+// missingVars selects how expandTemplate treats variables absent from vars.
+type missingVars int
+
+const (
+	missingEmpty missingVars = iota // Undefined, as RFC 6570 specifies
+	missingKeep                     // Keep the whole expression verbatim
+	missingError                    // Fail with ErrMissingTemplateVar
+)
+
+// expandTemplate implements Expand. kept reports whether any expression was
+// left unexpanded under missingKeep.
+func expandTemplate(tmpl string, vars map[string]any, missing missingVars) (s string, kept bool, err error) {
+	var b strings.Builder
+	b.Grow(len(tmpl))
+	for {
+		open := strings.IndexByte(tmpl, '{')
+		if open < 0 {
+			if strings.IndexByte(tmpl, '}') >= 0 {
+				return "", false, fmt.Errorf("%w: unmatched '}'", ErrInvalidTemplate)
+			}
+			b.WriteString(tmpl)
+			return b.String(), kept, nil
+		}
+		if strings.IndexByte(tmpl[:open], '}') >= 0 {
+			return "", false, fmt.Errorf("%w: unmatched '}'", ErrInvalidTemplate)
+		}
+		end := strings.IndexByte(tmpl[open:], '}')
+		if end < 0 {
+			return "", false, fmt.Errorf("%w: unclosed expression %q", ErrInvalidTemplate, tmpl[open:])
+		}
+		b.WriteString(tmpl[:open])
+		expr := tmpl[open+1 : open+end]
+		if missing != missingEmpty {
+			name, err := firstMissingVar(expr, vars)
+			switch {
+			case err != nil:
+				return "", false, err
+			case name != "" && missing == missingError:
+				return "", false, fmt.Errorf("%w %q", ErrMissingTemplateVar, name)
+			case name != "":
+				b.WriteString(tmpl[open : open+end+1])
+				kept = true
+				tmpl = tmpl[open+end+1:]
+				continue
+			}
+		}
+		if err := expandExpression(&b, expr, vars); err != nil {
+			return "", false, err
+		}
+		tmpl = tmpl[open+end+1:]
+	}
+}
+
+// firstMissingVar returns the first variable of expr absent from vars, or
+// the empty string if all are present.
+func firstMissingVar(expr string, vars map[string]any) (string, error) {
+	_, specs, err := ParseTemplateExpression(expr)
+	if err != nil {
+		return "", err
+	}
+	for _, spec := range specs {
+		if _, ok := vars[spec.Name]; !ok {
+			return spec.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// ExpandOption configures Link.Expand.
+type ExpandOption func(*missingVars)
+
+// KeepMissing leaves expressions referencing variables absent from vars in
+// the href, unexpanded, so the link stays templated for clients to finish.
+func KeepMissing() ExpandOption {
+	return func(m *missingVars) { *m = missingKeep }
+}
+
+// RequireAll makes Link.Expand fail with ErrMissingTemplateVar when a
+// variable referenced by the href is absent from vars.
+func RequireAll() ExpandOption {
+	return func(m *missingVars) { *m = missingError }
+}
+
+// Expand returns a copy of the link with its templated Href expanded with
+// vars, following Expand. Templated is cleared unless KeepMissing left
+// expressions in the href. Links that are not templated are returned as-is.
+//
+// By default, variables absent from vars expand to nothing, as RFC 6570
+// specifies; KeepMissing and RequireAll change that.
+//
+// # Example
+//
+//	search := hal.Link{Rel: "search", Href: "/orders{?q,page}", Templated: true}
+//	l, err := search.Expand(map[string]any{"q": "shoes"}, hal.KeepMissing())
+//	// l.Href == "/orders{?q,page}", l.Templated == true
+//	l, err = search.Expand(map[string]any{"q": "shoes"})
+//	// l.Href == "/orders?q=shoes", l.Templated == false
+func (l Link) Expand(vars map[string]any, opts ...ExpandOption) (Link, error) {
+	if !l.Templated {
+		return l, nil
+	}
+	missing := missingEmpty
+	for _, opt := range opts {
+		opt(&missing)
+	}
+	href, kept, err := expandTemplate(l.Href, vars, missing)
+	if err != nil {
+		return Link{}, fmt.Errorf("hal: expanding %q link: %w", l.Rel, err)
+	}
+	l.Href = href
+	l.Templated = kept
+	return l, nil
+}
+
+// MustExpand is like Expand but panics if the template is malformed.
+// It is intended for templates known to be valid, such as constants.
+func MustExpand(tmpl string, vars map[string]any) string {
+	s, err := Expand(tmpl, vars)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// templateOp describes an RFC 6570 expression operator (Appendix A).
+type templateOp struct {
+	first, sep    string
+	named         bool
+	ifEmpty       string
+	allowReserved bool
+}
+
+var templateOps = map[byte]templateOp{
+	'+': {sep: ",", allowReserved: true},
+	'#': {first: "#", sep: ",", allowReserved: true},
+	'.': {first: ".", sep: "."},
+	'/': {first: "/", sep: "/"},
+	';': {first: ";", sep: ";", named: true},
+	'?': {first: "?", sep: "&", named: true, ifEmpty: "="},
+	'&': {first: "&", sep: "&", named: true, ifEmpty: "="},
+}
+
+// TemplateVarSpec is a variable reference parsed from a URI template
+// expression, such as "id", "path*" or "name:3".
+type TemplateVarSpec struct {
+	Name    string
+	Explode bool
+	Prefix  int // 0 when no prefix modifier is present
+}
+
+// ParseTemplateExpression parses the inside of a URI template expression
+// (without braces) into its operator, or 0 for simple expansion, and
+// variable references.
+func ParseTemplateExpression(expr string) (op byte, specs []TemplateVarSpec, err error) {
+	if expr == "" {
+		return 0, nil, fmt.Errorf("%w: empty expression", ErrInvalidTemplate)
+	}
+	if _, ok := templateOps[expr[0]]; ok {
+		op, expr = expr[0], expr[1:]
+	} else if strings.ContainsRune("=,!@|", rune(expr[0])) {
+		return 0, nil, fmt.Errorf("%w: reserved operator %q", ErrInvalidTemplate, expr[0])
+	}
+	for _, raw := range strings.Split(expr, ",") {
+		spec := TemplateVarSpec{Name: raw}
+		if name, ok := strings.CutSuffix(raw, "*"); ok {
+			spec.Name, spec.Explode = name, true
+		} else if name, prefix, ok := strings.Cut(raw, ":"); ok {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n <= 0 || n >= 10000 {
+				return 0, nil, fmt.Errorf("%w: bad prefix modifier in %q", ErrInvalidTemplate, raw)
+			}
+			spec.Name, spec.Prefix = name, n
+		}
+		if !validVarName(spec.Name) {
+			return 0, nil, fmt.Errorf("%w: bad variable name %q", ErrInvalidTemplate, spec.Name)
+		}
+		specs = append(specs, spec)
+	}
+	return op, specs, nil
+}
+
+// validVarName reports whether name is an RFC 6570 varname.
+func validVarName(name string) bool {
+	if name == "" || name[0] == '.' || name[len(name)-1] == '.' {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '.':
+		case c == '%' && i+2 < len(name) && isHex(name[i+1]) && isHex(name[i+2]):
+			i += 2
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func expandExpression(b *strings.Builder, expr string, vars map[string]any) error {
+	opChar, specs, err := ParseTemplateExpression(expr)
+	if err != nil {
+		return err
+	}
+	op := templateOps[opChar]
+	if opChar == 0 {
+		op = templateOp{sep: ","}
+	}
+
+	first := true
+	for _, spec := range specs {
+		v, ok := templateValue(vars[spec.Name])
+		if !ok {
+			continue
+		}
+		if first {
+			b.WriteString(op.first)
+			first = false
+		} else {
+			b.WriteString(op.sep)
+		}
+		if err := expandValue(b, op, spec, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateValue normalizes v to a string, []string or [][2]string (an
+// associative array in key order). ok is false for undefined values.
+func templateValue(v any) (val any, ok bool) {
+	if v == nil {
+		return nil, false
+	}
+	switch vv := v.(type) {
+	case string:
+		return vv, true
+	case []string:
+		return vv, len(vv) > 0
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil, false
+		}
+		return templateValue(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 {
+			return nil, false
+		}
+		list := make([]string, rv.Len())
+		for i := range list {
+			list[i] = fmt.Sprint(rv.Index(i).Interface())
+		}
+		return list, true
+	case reflect.Map:
+		if rv.Len() == 0 {
+			return nil, false
+		}
+		pairs := make([][2]string, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			pairs = append(pairs, [2]string{fmt.Sprint(iter.Key().Interface()), fmt.Sprint(iter.Value().Interface())})
+		}
+		slices.SortFunc(pairs, func(a, b [2]string) int { return strings.Compare(a[0], b[0]) })
+		return pairs, true
+	}
+	return fmt.Sprint(v), true
+}
+
+func expandValue(b *strings.Builder, op templateOp, spec TemplateVarSpec, v any) error {
+	switch vv := v.(type) {
+	case string:
+		if op.named {
+			b.WriteString(spec.Name)
+			if vv == "" {
+				b.WriteString(op.ifEmpty)
+				return nil
+			}
+			b.WriteByte('=')
+		}
+		if spec.Prefix > 0 {
+			vv = truncateRunes(vv, spec.Prefix)
+		}
+		writeTemplateEscaped(b, vv, op.allowReserved)
+		return nil
+	case []string:
+		if spec.Prefix > 0 {
+			return fmt.Errorf("%w: prefix modifier on list variable %q", ErrInvalidTemplate, spec.Name)
+		}
+		if !spec.Explode {
+			if op.named {
+				b.WriteString(spec.Name)
+				b.WriteByte('=')
+			}
+			for i, item := range vv {
+				if i > 0 {
+					b.WriteByte(',')
+				}
+				writeTemplateEscaped(b, item, op.allowReserved)
+			}
+			return nil
+		}
+		for i, item := range vv {
+			if i > 0 {
+				b.WriteString(op.sep)
+			}
+			if op.named {
+				b.WriteString(spec.Name)
+				if item == "" {
+					b.WriteString(op.ifEmpty)
+					continue
+				}
+				b.WriteByte('=')
+			}
+			writeTemplateEscaped(b, item, op.allowReserved)
+		}
+		return nil
+	case [][2]string:
+		if spec.Prefix > 0 {
+			return fmt.Errorf("%w: prefix modifier on map variable %q", ErrInvalidTemplate, spec.Name)
+		}
+		if !spec.Explode {
+			if op.named {
+				b.WriteString(spec.Name)
+				b.WriteByte('=')
+			}
+			for i, kv := range vv {
+				if i > 0 {
+					b.WriteByte(',')
+				}
+				writeTemplateEscaped(b, kv[0], op.allowReserved)
+				b.WriteByte(',')
+				writeTemplateEscaped(b, kv[1], op.allowReserved)
+			}
+			return nil
+		}
+		for i, kv := range vv {
+			if i > 0 {
+				b.WriteString(op.sep)
+			}
+			writeTemplateEscaped(b, kv[0], op.allowReserved)
+			if op.named && kv[1] == "" {
+				b.WriteString(op.ifEmpty)
+				continue
+			}
+			b.WriteByte('=')
+			writeTemplateEscaped(b, kv[1], op.allowReserved)
+		}
+	}
+	return nil
+}
+
+func truncateRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+const upperHex = "0123456789ABCDEF"
+
+// writeTemplateEscaped percent-encodes s as UTF-8, keeping unreserved
+// characters and, if allowReserved is set, reserved characters and existing
+// percent-encoded triplets.
+func writeTemplateEscaped(b *strings.Builder, s string, allowReserved bool) {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isUnreserved(c):
+			b.WriteByte(c)
+			continue
+		case allowReserved && strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0:
+			b.WriteByte(c)
+			continue
+		case allowReserved && c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			b.WriteString(s[i : i+3])
+			i += 2
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(upperHex[c>>4])
+		b.WriteByte(upperHex[c&0xF])
+	}
+}
+
+func isUnreserved(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
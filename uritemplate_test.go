@@ -0,0 +1,201 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// rfc6570Vars are the example variables of RFC 6570, section 3.2.
+var rfc6570Vars = map[string]any{
+	"count":      []string{"one", "two", "three"},
+	"dom":        []string{"example", "com"},
+	"dub":        "me/too",
+	"hello":      "Hello World!",
+	"half":       "50%",
+	"var":        "value",
+	"who":        "fred",
+	"base":       "http://example.com/home/",
+	"path":       "/foo/bar",
+	"list":       []string{"red", "green", "blue"},
+	"keys":       map[string]string{"semi": ";", "dot": ".", "comma": ","},
+	"v":          "6",
+	"x":          "1024",
+	"y":          "768",
+	"empty":      "",
+	"empty_keys": map[string]string{},
+	"undef":      nil,
+}
+
+func TestExpand_RFC6570Examples(t *testing.T) {
+	tests := map[string]string{
+		// Level 1 and 2.
+		"{var}":            "value",
+		"{hello}":          "Hello%20World%21",
+		"{half}":           "50%25",
+		"O{empty}X":        "OX",
+		"O{undef}X":        "OX",
+		"{x,y}":            "1024,768",
+		"{x,hello,y}":      "1024,Hello%20World%21,768",
+		"?{x,empty}":       "?1024,",
+		"?{x,undef}":       "?1024",
+		"{+var}":           "value",
+		"{+hello}":         "Hello%20World!",
+		"{+half}":          "50%25",
+		"{base}index":      "http%3A%2F%2Fexample.com%2Fhome%2Findex",
+		"{+base}index":     "http://example.com/home/index",
+		"{+path}/here":     "/foo/bar/here",
+		"here?ref={+path}": "here?ref=/foo/bar",
+		"{#var}":           "#value",
+		"{#hello}":         "#Hello%20World!",
+		"X{#var}":          "X#value",
+		// Level 3.
+		"{+x,hello,y}":   "1024,Hello%20World!,768",
+		"{#path,x}/here": "#/foo/bar,1024/here",
+		"X{.var}":        "X.value",
+		"X{.x,y}":        "X.1024.768",
+		"{/var}":         "/value",
+		"{/var,x}/here":  "/value/1024/here",
+		"{;x,y}":         ";x=1024;y=768",
+		"{;x,y,empty}":   ";x=1024;y=768;empty",
+		"{?x,y}":         "?x=1024&y=768",
+		"{?x,y,empty}":   "?x=1024&y=768&empty=",
+		"?fixed=yes{&x}": "?fixed=yes&x=1024",
+		"{&x,y,empty}":   "&x=1024&y=768&empty=",
+		// Level 4.
+		"{var:3}":         "val",
+		"{var:30}":        "value",
+		"{list}":          "red,green,blue",
+		"{list*}":         "red,green,blue",
+		"{keys}":          "comma,%2C,dot,.,semi,%3B",
+		"{keys*}":         "comma=%2C,dot=.,semi=%3B",
+		"{+path:6}/here":  "/foo/b/here",
+		"{+list*}":        "red,green,blue",
+		"{+keys*}":        "comma=,,dot=.,semi=;",
+		"{#keys}":         "#comma,,,dot,.,semi,;",
+		"X{.list*}":       "X.red.green.blue",
+		"X{.keys*}":       "X.comma=%2C.dot=..semi=%3B",
+		"{/var:1,var}":    "/v/value",
+		"{/list*,path:4}": "/red/green/blue/%2Ffoo",
+		"{;list}":         ";list=red,green,blue",
+		"{;list*}":        ";list=red;list=green;list=blue",
+		"{;keys*}":        ";comma=%2C;dot=.;semi=%3B",
+		"{?var:3}":        "?var=val",
+		"{?list}":         "?list=red,green,blue",
+		"{?list*}":        "?list=red&list=green&list=blue",
+		"{?keys*}":        "?comma=%2C&dot=.&semi=%3B",
+		"{&list*}":        "&list=red&list=green&list=blue",
+		"{?empty_keys*}":  "",
+		"{/who,who}":      "/fred/fred",
+		"{/dub}":          "/me%2Ftoo",
+		"{.dom*}":         ".example.com",
+	}
+
+	for tmpl, want := range tests {
+		got, err := Expand(tmpl, rfc6570Vars)
+		if err != nil {
+			t.Errorf("Expand(%q): %v", tmpl, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Expand(%q) = %q, want %q", tmpl, got, want)
+		}
+	}
+}
+
+func TestExpand_GoValues(t *testing.T) {
+	id := 42
+	got, err := Expand("/users/{id}/orders{?page,limit,active,tags}", map[string]any{
+		"id":     &id,
+		"page":   2,
+		"active": true,
+		"tags":   []int{1, 2},
+		"name":   "ömer",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/users/42/orders?page=2&active=true&tags=1,2"; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	if got := MustExpand("/users/{name}", map[string]any{"name": "ömer"}); got != "/users/%C3%B6mer" {
+		t.Fatalf("expected UTF-8 percent-encoding, got %s", got)
+	}
+}
+
+func TestExpand_Invalid(t *testing.T) {
+	for _, tmpl := range []string{"/users/{id", "/users/id}", "{}", "{=x}", "{x:0}", "{x:abc}", "{a b}", "{.}", "{list:2}"} {
+		if _, err := Expand(tmpl, map[string]any{"list": []string{"a"}}); !errors.Is(err, ErrInvalidTemplate) {
+			t.Errorf("Expand(%q): expected ErrInvalidTemplate, got %v", tmpl, err)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected MustExpand to panic")
+		}
+	}()
+	MustExpand("{", nil)
+}
+
+func TestLinkExpand(t *testing.T) {
+	search := Link{Rel: "search", Href: "/users/{id}/orders{?page,limit}{/seg}", Templated: true, Title: "Orders"}
+
+	tests := []struct {
+		name          string
+		vars          map[string]any
+		opts          []ExpandOption
+		wantHref      string
+		wantTemplated bool
+	}{
+		{"all variables", map[string]any{"id": 7, "page": 2, "limit": 10, "seg": "a b"}, nil, "/users/7/orders?page=2&limit=10/a%20b", false},
+		{"missing expand to nothing", map[string]any{"id": 7}, nil, "/users/7/orders", false},
+		{"keep missing", map[string]any{"id": 7, "page": 2}, []ExpandOption{KeepMissing()}, "/users/7/orders{?page,limit}{/seg}", true},
+		{"keep with all present", map[string]any{"id": 7, "page": 2, "limit": 1, "seg": "x"}, []ExpandOption{KeepMissing()}, "/users/7/orders?page=2&limit=1/x", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := search.Expand(tt.vars, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Href != tt.wantHref || got.Templated != tt.wantTemplated {
+				t.Fatalf("expected %q (templated %v), got %q (templated %v)", tt.wantHref, tt.wantTemplated, got.Href, got.Templated)
+			}
+			if got.Rel != "search" || got.Title != "Orders" {
+				t.Fatalf("expected other fields preserved, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestLinkExpand_RequireAll(t *testing.T) {
+	l := Link{Rel: "item", Href: "/items/{id}{?fields}", Templated: true}
+
+	_, err := l.Expand(map[string]any{"id": 1}, RequireAll())
+	if !errors.Is(err, ErrMissingTemplateVar) || !strings.Contains(err.Error(), `"fields"`) {
+		t.Fatalf("expected ErrMissingTemplateVar naming the variable, got %v", err)
+	}
+	if _, err := l.Expand(map[string]any{"id": 1, "fields": "a"}, RequireAll()); err != nil {
+		t.Fatalf("expected success with all variables, got %v", err)
+	}
+}
+
+func TestLinkExpand_NotTemplated(t *testing.T) {
+	l := Link{Rel: "self", Href: "/literal/{braces}"}
+	got, err := l.Expand(map[string]any{"braces": "x"})
+	if err != nil || !got.Equal(l) {
+		t.Fatalf("expected link unchanged, got %+v, %v", got, err)
+	}
+}
+
+func TestLinkExpand_InvalidTemplate(t *testing.T) {
+	l := Link{Rel: "bad", Href: "/x{", Templated: true}
+	if _, err := l.Expand(nil); !errors.Is(err, ErrInvalidTemplate) {
+		t.Fatalf("expected ErrInvalidTemplate, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	json "github.com/goccy/go-json"
+)
+
+// WithRequireSelf makes Validate report registered samples whose links lack
+// a self link.
+//
+// # Example
+//
+//	inst := hal.New(hal.WithRequireSelf())
+func WithRequireSelf() InstanceOption {
+	return func(i *Instance) {
+		i.requireSelf = true
+	}
+}
+
+// RegisterSample records representative values that Validate wraps to check
+// the registered generators. Samples should be the values passed to Wrap,
+// such as &User{ID: 1}.
+func (i *Instance) RegisterSample(samples ...any) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.samples = append(i.samples, samples...)
+}
+
+// Samples returns a copy of the values recorded with RegisterSample.
+// Documentation exporters such as halalps use them to run the generators.
+func (i *Instance) Samples() []any {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return slices.Clone(i.samples)
+}
+
+// Validate checks the registry for inconsistencies, so a misconfigured
+// service fails at startup instead of serving broken links. It reports:
+//
+//   - samples with no generator or static links registered for their type
+//   - samples whose generator panics or returns no links
+//   - samples without a self link, if WithRequireSelf is set
+//   - sample links failing the instance's strict checks (see WithStrictChecks)
+//   - CURIE prefixes used by samples or RegisterWithCurie but not registered
+//
+// All problems are returned joined, one line each. Validate is meant to be
+// called once from main, before serving.
+func (i *Instance) Validate() error {
+	i.mu.RLock()
+	samples := slices.Clone(i.samples)
+	curieRefs := make([]string, 0, len(i.curieRefs))
+	for prefix := range i.curieRefs {
+		curieRefs = append(curieRefs, prefix)
+	}
+	i.mu.RUnlock()
+	curies := i.curieSnapshot()
+
+	var problems []error
+	report := func(format string, args ...any) {
+		problems = append(problems, fmt.Errorf("hal: "+format, args...))
+	}
+
+	for _, sample := range samples {
+		t := reflect.TypeOf(sample)
+		rels, err := i.sampleRels(sample)
+		if err != nil {
+			report("sample %v: %v", t, err)
+			continue
+		}
+		if len(rels) == 0 {
+			report("sample %v: no links", t)
+			continue
+		}
+		if i.requireSelf && !slices.Contains(rels, "self") {
+			report("sample %v: no self link", t)
+		}
+		for _, rel := range rels {
+			if prefix, ok := curiePrefix(rel); ok {
+				if _, defined := curies[prefix]; !defined {
+					report("sample %v: rel %q uses unregistered curie prefix %q", t, rel, prefix)
+				}
+			}
+		}
+	}
+
+	slices.Sort(curieRefs)
+	for _, prefix := range curieRefs {
+		if _, ok := curies[prefix]; !ok {
+			report("curie prefix %q used by RegisterWithCurie is not registered", prefix)
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// sampleRels returns the rels the registry produces for sample.
+func (i *Instance) sampleRels(sample any) (rels []string, err error) {
+	t := reflect.TypeOf(sample)
+	i.mu.RLock()
+	pre, hasPre := i.precomputed[t]
+	i.mu.RUnlock()
+	if hasPre && pre != nil {
+		var doc struct {
+			Links map[string]json.RawMessage `json:"_links"`
+		}
+		if err := json.Unmarshal(pre.JSON, &doc); err != nil {
+			return nil, err
+		}
+		for rel := range doc.Links {
+			rels = append(rels, rel)
+		}
+		return rels, nil
+	}
+
+	gen, ok := i.lookupGenerator(t)
+	if !ok {
+		return nil, errors.New("no generator registered")
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if f, ok := r.(generatorFailure); ok {
+				err = f.err
+				return
+			}
+			err = fmt.Errorf("%w: %v", ErrGeneratorPanic, r)
+		}
+	}()
+	links := gen(context.Background(), sample)
+	if i.strictChecks != 0 {
+		if msg := validateLinks(links, i.strictChecks); msg != "" {
+			return nil, errors.New(msg)
+		}
+	}
+	for _, l := range links {
+		if !slices.Contains(rels, l.Rel) {
+			rels = append(rels, l.Rel)
+		}
+	}
+	return rels, nil
+}
+
+// curiePrefix returns the prefix of a CURIE rel such as "acme:widget".
+// Rels that are URIs ("https://...") have no CURIE prefix.
+func curiePrefix(rel string) (string, bool) {
+	prefix, _, ok := strings.Cut(rel, ":")
+	if !ok || prefix == "" || strings.Contains(rel, "://") {
+		return "", false
+	}
+	return prefix, true
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type (
+	validUser    struct{ ID int }
+	noSelfUser   struct{ ID int }
+	noLinksUser  struct{ ID int }
+	unregistered struct{ ID int }
+	staticUser   struct{ ID int }
+)
+
+func validInstance(opts ...InstanceOption) *Instance {
+	inst := New(opts...)
+	inst.RegisterCurie("acme", "https://docs.example.com/{rel}")
+	RegisterWithCurie(inst, "acme", func(_ context.Context, _ *validUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/1"}, {Rel: "widgets", Href: "/users/1/widgets"}}
+	})
+	RegisterStatic(inst, &staticUser{}, []Link{{Rel: "self", Href: "/static"}})
+	inst.RegisterSample(&validUser{ID: 1}, &staticUser{ID: 1})
+	return inst
+}
+
+func TestValidate_CleanRegistry(t *testing.T) {
+	if err := validInstance(WithRequireSelf(), WithStrictMode()).Validate(); err != nil {
+		t.Fatalf("expected clean registry, got %v", err)
+	}
+	if err := New().Validate(); err != nil {
+		t.Fatalf("expected empty registry to validate, got %v", err)
+	}
+}
+
+func TestValidate_Rules(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(inst *Instance)
+		want  string
+	}{
+		{"missing generator", func(inst *Instance) {
+			inst.RegisterSample(&unregistered{})
+		}, "sample *hal.unregistered: no generator registered"},
+		{"no links", func(inst *Instance) {
+			RegisterInstance(inst, func(context.Context, *noLinksUser) []Link { return nil })
+			inst.RegisterSample(&noLinksUser{})
+		}, "sample *hal.noLinksUser: no links"},
+		{"missing self", func(inst *Instance) {
+			RegisterInstance(inst, func(context.Context, *noSelfUser) []Link {
+				return []Link{{Rel: "collection", Href: "/users"}}
+			})
+			inst.RegisterSample(&noSelfUser{})
+		}, "sample *hal.noSelfUser: no self link"},
+		{"generator panic", func(inst *Instance) {
+			RegisterInstance(inst, nilMapGenerator)
+			inst.RegisterSample(&panicUser{})
+		}, "sample *hal.panicUser: hal: generator panicked"},
+		{"strict check", func(inst *Instance) {
+			RegisterInstance(inst, func(context.Context, *noSelfUser) []Link {
+				return []Link{{Rel: "self"}}
+			})
+			inst.RegisterSample(&noSelfUser{})
+		}, "sample *hal.noSelfUser: empty href"},
+		{"unregistered curie in sample", func(inst *Instance) {
+			RegisterInstance(inst, func(context.Context, *noSelfUser) []Link {
+				return []Link{{Rel: "self", Href: "/a"}, {Rel: "bil:invoices", Href: "/b"}}
+			})
+			inst.RegisterSample(&noSelfUser{})
+		}, `rel "bil:invoices" uses unregistered curie prefix "bil"`},
+		{"unregistered curie in RegisterWithCurie", func(inst *Instance) {
+			RegisterWithCurie(inst, "ord", func(context.Context, *noSelfUser) []Link { return nil })
+		}, `curie prefix "ord" used by RegisterWithCurie is not registered`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inst := validInstance(WithRequireSelf(), WithStrictChecks(CheckAll))
+			tt.setup(inst)
+
+			err := inst.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected %q, got %v", tt.want, err)
+			}
+			if n := strings.Count(err.Error(), "\n") + 1; n != 1 {
+				t.Fatalf("expected exactly one problem, got %d: %v", n, err)
+			}
+		})
+	}
+}
+
+func TestValidate_SelfOnlyWithRequireSelf(t *testing.T) {
+	inst := validInstance()
+	RegisterInstance(inst, func(context.Context, *noSelfUser) []Link {
+		return []Link{{Rel: "collection", Href: "/users"}}
+	})
+	inst.RegisterSample(&noSelfUser{})
+
+	if err := inst.Validate(); err != nil {
+		t.Fatalf("expected no self requirement by default, got %v", err)
+	}
+}
+
+func TestValidate_AggregatesProblems(t *testing.T) {
+	inst := validInstance()
+	inst.RegisterSample(&unregistered{}, &noLinksUser{})
+	RegisterWithCurie(inst, "ord", func(context.Context, *noSelfUser) []Link { return nil })
+
+	err := inst.Validate()
+	if got := strings.Count(err.Error(), "\n") + 1; got != 3 {
+		t.Fatalf("expected three problems, got %d: %v", got, err)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// ErrorMediaType is the Content-Type written by RespondError.
+const ErrorMediaType = "application/vnd.error+json; charset=utf-8"
+
+// ErrorEnvelope is an error rendered as a vnd.error document: a message,
+// an optional logref and path, links such as help, describes and about,
+// and nested errors under _embedded.errors. It implements error, so
+// handlers can return it, wrapped or not, and leave the response to
+// RespondError.
+type ErrorEnvelope struct {
+	status int
+	data   *vndError
+	cause  error
+	nested []*ErrorEnvelope
+	env    *Envelope
+}
+
+// vndError holds the members of a vnd.error document besides _links and
+// _embedded.
+type vndError struct {
+	Message string `json:"message"`
+	Logref  string `json:"logref,omitempty"`
+	Path    string `json:"path,omitempty"`
+}
+
+// ErrorOption configures NewError.
+type ErrorOption func(*ErrorEnvelope)
+
+// ErrorLogref sets the logref member, which identifies the error in server
+// logs.
+func ErrorLogref(logref string) ErrorOption {
+	return func(e *ErrorEnvelope) {
+		e.data.Logref = logref
+	}
+}
+
+// ErrorPath sets the path member, a JSON Pointer to the request field the
+// error is about.
+func ErrorPath(path string) ErrorOption {
+	return func(e *ErrorEnvelope) {
+		e.data.Path = path
+	}
+}
+
+// ErrorLinks adds links, such as help or describes, as if by AddLink.
+func ErrorLinks(links ...Link) ErrorOption {
+	return func(e *ErrorEnvelope) {
+		for _, l := range links {
+			e.env.AddLink(l)
+		}
+	}
+}
+
+// NestedErrors embeds errs under _embedded.errors, which is always an
+// array. Nil errors are skipped.
+func NestedErrors(errs ...*ErrorEnvelope) ErrorOption {
+	return func(e *ErrorEnvelope) {
+		e.nested = append(e.nested, errs...)
+	}
+}
+
+// ErrorCause records the error that caused this one, returned by Unwrap.
+// The cause is not part of the document.
+func ErrorCause(err error) ErrorOption {
+	return func(e *ErrorEnvelope) {
+		e.cause = err
+	}
+}
+
+// NewError builds an error document for the given HTTP status. Links are
+// added through the instance stored in ctx by NewContext, or the
+// DefaultInstance, so its CURIEs and link rewriting apply. Registered
+// generators do not run.
+//
+// # Example
+//
+//	return hal.NewError(ctx, http.StatusNotFound, "user not found",
+//	    hal.ErrorLogref(reqID),
+//	    hal.ErrorLinks(hal.Link{Rel: "help", Href: "/docs/errors/not-found"}),
+//	)
+func NewError(ctx context.Context, status int, message string, opts ...ErrorOption) *ErrorEnvelope {
+	return instanceFor(ctx).NewError(ctx, status, message, opts...)
+}
+
+// NewError builds an error document like the package-level NewError, with
+// links added through i.
+func (i *Instance) NewError(ctx context.Context, status int, message string, opts ...ErrorOption) *ErrorEnvelope {
+	e := &ErrorEnvelope{status: status, data: &vndError{Message: message}}
+	e.env = i.WrapWith(ctx, e.data, SkipGenerators(), EmbedAsArray("errors"))
+	for _, opt := range opts {
+		opt(e)
+	}
+	for _, n := range e.nested {
+		if n != nil {
+			e.env.AddEmbedded(ctx, "errors", n.env)
+		}
+	}
+	return e
+}
+
+// Error returns the message, followed by the cause if there is one.
+func (e *ErrorEnvelope) Error() string {
+	if e.cause != nil {
+		return e.data.Message + ": " + e.cause.Error()
+	}
+	return e.data.Message
+}
+
+// Unwrap returns the cause set with ErrorCause.
+func (e *ErrorEnvelope) Unwrap() error {
+	return e.cause
+}
+
+// StatusCode returns the HTTP status passed to NewError.
+func (e *ErrorEnvelope) StatusCode() int {
+	return e.status
+}
+
+// MarshalJSON implements json.Marshaler, writing the vnd.error document.
+func (e *ErrorEnvelope) MarshalJSON() ([]byte, error) {
+	return e.env.MarshalJSON()
+}
+
+// RespondError writes err as a vnd.error document, using the instance
+// stored in the request context by NewContext, or the DefaultInstance if
+// there is none. See Instance.RespondError.
+//
+// # Example
+//
+//	if err := svc.Update(ctx, user); err != nil {
+//	    hal.RespondError(w, r, err)
+//	    return
+//	}
+func RespondError(w http.ResponseWriter, r *http.Request, err error) {
+	instanceFor(r.Context()).RespondError(w, r, err)
+}
+
+// RespondError writes err as a vnd.error document with Content-Type
+// ErrorMediaType. If err is or wraps an *ErrorEnvelope, found with
+// errors.As, that document is written with its status, or 500 if the
+// status is not an error status. Any other error is reported to the lint
+// hook and answered with a 500 document whose message does not expose it.
+// A nil err writes nothing. Responses to HEAD requests carry the headers
+// only.
+func (i *Instance) RespondError(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		return
+	}
+	var e *ErrorEnvelope
+	if !errors.As(err, &e) {
+		i.lintf("hal: responding to %s %s: %v", r.Method, r.URL.Path, err)
+		e = i.NewError(r.Context(), http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	}
+	status := e.status
+	if status < 400 || status > 599 {
+		status = http.StatusInternalServerError
+	}
+	body, err := e.MarshalJSON()
+	if err != nil {
+		i.lintf("hal: responding to %s %s: %v", r.Method, r.URL.Path, err)
+		status, body = http.StatusInternalServerError, []byte(internalErrorBody)
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", ErrorMediaType)
+	h.Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(body)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewError(t *testing.T) {
+	inst := New(WithCuries(map[string]string{"acme": "https://docs.acme.com/errors/{rel}"}))
+	ctx := NewContext(context.Background(), inst)
+
+	field := NewError(ctx, http.StatusBadRequest, "email is invalid", ErrorPath("/email"))
+	e := NewError(ctx, http.StatusBadRequest, "validation failed",
+		ErrorLogref("42"),
+		ErrorLinks(Link{Rel: "help", Href: "/docs/validation"}, Link{Rel: "acme:validation", Href: "/errors/validation"}),
+		NestedErrors(field, nil),
+	)
+
+	want := `{"message":"validation failed","logref":"42",` +
+		`"_embedded":{"errors":[{"message":"email is invalid","path":"/email"}]},` +
+		`"_links":{"acme:validation":{"href":"/errors/validation"},` +
+		`"curies":[{"href":"https://docs.acme.com/errors/{rel}","templated":true,"name":"acme"}],` +
+		`"help":{"href":"/docs/validation"}}}`
+	if got := marshalString(t, e); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if e.StatusCode() != http.StatusBadRequest || e.Error() != "validation failed" {
+		t.Fatalf("unexpected status %d or message %q", e.StatusCode(), e.Error())
+	}
+}
+
+func TestNewError_Cause(t *testing.T) {
+	cause := errors.New("no rows")
+	e := NewError(context.Background(), http.StatusNotFound, "user not found", ErrorCause(cause))
+	if !errors.Is(e, cause) || e.Error() != "user not found: no rows" {
+		t.Fatalf("expected the cause to be wrapped, got %v", e)
+	}
+	if got := marshalString(t, e); got != `{"message":"user not found"}` {
+		t.Fatalf("expected the cause to stay out of the document, got %s", got)
+	}
+}
+
+func TestRespondError(t *testing.T) {
+	var lints []string
+	inst := New(WithLintHook(func(msg string) { lints = append(lints, msg) }))
+	notFound := inst.NewError(context.Background(), http.StatusNotFound, "user not found", ErrorLogref("abc"))
+
+	tests := []struct {
+		name   string
+		method string
+		err    error
+		status int
+		body   string
+	}{
+		{"envelope", http.MethodGet, notFound, http.StatusNotFound, `{"message":"user not found","logref":"abc"}`},
+		{"wrapped", http.MethodGet, fmt.Errorf("loading user: %w", notFound), http.StatusNotFound, `{"message":"user not found","logref":"abc"}`},
+		{"plain error", http.MethodGet, errors.New("db password is hunter2"), http.StatusInternalServerError, `{"message":"Internal Server Error"}`},
+		{"non-error status", http.MethodGet, inst.NewError(context.Background(), http.StatusOK, "odd"), http.StatusInternalServerError, `{"message":"odd"}`},
+		{"head", http.MethodHead, notFound, http.StatusNotFound, ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			inst.RespondError(w, httptest.NewRequest(tt.method, "/users/1", nil), tt.err)
+
+			if w.Code != tt.status {
+				t.Errorf("expected %d, got %d", tt.status, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != ErrorMediaType {
+				t.Errorf("expected %q, got %q", ErrorMediaType, ct)
+			}
+			if got := w.Body.String(); got != tt.body {
+				t.Errorf("expected %s, got %s", tt.body, got)
+			}
+		})
+	}
+	if len(lints) != 1 || !strings.Contains(lints[0], "hunter2") {
+		t.Fatalf("expected only the plain error to be reported, got %v", lints)
+	}
+
+	w := httptest.NewRecorder()
+	inst.RespondError(w, httptest.NewRequest(http.MethodGet, "/", nil), nil)
+	if w.Body.Len() != 0 || len(lints) != 1 {
+		t.Fatalf("expected a nil error to write nothing, got %q", w.Body.String())
+	}
+}
+
+func TestRespondError_UsesContextInstance(t *testing.T) {
+	inst := New(WithCuries(map[string]string{"acme": "https://docs.acme.com/errors/{rel}"}))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := NewContext(r.Context(), inst)
+		err := NewError(ctx, http.StatusConflict, "already exists", ErrorLinks(Link{Rel: "acme:conflict", Href: "/errors/conflict"}))
+		RespondError(w, r.WithContext(ctx), fmt.Errorf("creating user: %w", err))
+	}))
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusConflict || !strings.Contains(string(body), `"name":"acme"`) {
+		t.Fatalf("expected a 409 document with curies, got %d %s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func countingInstance(calls *int) *Instance {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		*calls++
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	return inst
+}
+
+func TestWrapOnce_ReturnsSameInstanceEnvelope(t *testing.T) {
+	calls := 0
+	inst := countingInstance(&calls)
+	ctx := context.Background()
+
+	env := inst.Wrap(ctx, &collectionUser{ID: 1})
+	if got := inst.WrapOnce(ctx, env); got != env {
+		t.Fatal("expected the same envelope to be returned")
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 generator call, got %d", calls)
+	}
+}
+
+func TestWrapOnce_WrapsPlainData(t *testing.T) {
+	calls := 0
+	inst := countingInstance(&calls)
+
+	env := inst.WrapOnce(context.Background(), &collectionUser{ID: 1})
+	if env.Data == nil || calls != 1 {
+		t.Fatalf("expected data to be wrapped once, got %d calls", calls)
+	}
+}
+
+func TestWrapOnce_ForeignEnvelopeIsWrapped(t *testing.T) {
+	calls := 0
+	inst := countingInstance(&calls)
+
+	foreign := New().Wrap(context.Background(), &collectionUser{ID: 1})
+	if got := inst.WrapOnce(context.Background(), foreign); got == foreign {
+		t.Fatal("expected envelope from another instance to be wrapped")
+	}
+}
+
+func TestCollection_PreWrappedItemsNotRewrapped(t *testing.T) {
+	calls := 0
+	inst := countingInstance(&calls)
+	ctx := context.Background()
+
+	items := []*Envelope{
+		inst.Wrap(ctx, &collectionUser{ID: 1}),
+		inst.Wrap(ctx, &collectionUser{ID: 2}),
+	}
+	page := inst.Collection(ctx, items, 2, Link{Rel: "self", Href: "/users"})
+
+	b, err := json.Marshal(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected exactly one generator call per resource, got %d", calls)
+	}
+	if !contains(b, `"/users/1"`) || !contains(b, `"/users/2"`) {
+		t.Fatalf("expected item links in output: %s", b)
+	}
+}
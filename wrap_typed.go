@@ -0,0 +1,96 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"reflect"
+)
+
+// resolution is what Wrap looks up for a link type: static links, a resource
+// generator or a link generator, at most one of which is used.
+type resolution struct {
+	pre    *PrecomputedLinks
+	res    func(context.Context, any) ([]Link, map[string]any)
+	gen    Generator
+	genErr error // Ambiguous interface generators (see resolveGenerator)
+}
+
+// found reports whether any registration applies.
+func (r resolution) found() bool {
+	return r.pre != nil || r.res != nil || r.gen != nil || r.genErr != nil
+}
+
+// resolve looks up the registrations for t.
+func (i *Instance) resolve(t reflect.Type) resolution {
+	var r resolution
+	if f := i.frozen.Load(); f != nil {
+		r.pre = f.precomputed[t]
+		r.res = f.resources[t]
+	} else {
+		i.mu.RLock()
+		r.pre = i.precomputed[t]
+		r.res = i.resources[t]
+		i.mu.RUnlock()
+	}
+	if r.pre != nil || r.res != nil {
+		return r
+	}
+	r.gen, _, r.genErr = i.resolveGenerator(t)
+	return r
+}
+
+// registryChanged invalidates the resolutions cached by WrapT and
+// republishes the snapshot of a frozen instance. The caller must hold i.mu
+// for writing.
+func (i *Instance) registryChanged() {
+	i.registryVersion.Add(1)
+	i.refreeze()
+}
+
+// typeKey[T] keys the typed resolution cache; each T is a distinct key.
+type typeKey[T any] struct{}
+
+// typedResolution is a resolution cached for *T.
+type typedResolution struct {
+	version uint64
+	t       reflect.Type
+	r       resolution
+}
+
+// WrapT wraps v like Wrap, using the instance stored in ctx by NewContext
+// or the DefaultInstance. See WrapInstanceT.
+//
+// # Example
+//
+//	env := hal.WrapT(ctx, user) // user is a *User
+func WrapT[T any](ctx context.Context, v *T) *Envelope {
+	return WrapInstanceT(ctx, instanceFor(ctx), v)
+}
+
+// WrapInstanceT wraps v with i like i.Wrap(ctx, v) and returns an identical
+// envelope, but the registrations for *T are looked up once and cached per
+// type parameter, so later calls skip the type-keyed registry lookups.
+// The cache is invalidated whenever a generator, static links or a resource
+// generator is registered or removed. Instances with WithAutoDeref, which
+// may resolve *T to T per value, take the regular Wrap path.
+func WrapInstanceT[T any](ctx context.Context, i *Instance, v *T) *Envelope {
+	if i.autoDeref {
+		return i.Wrap(ctx, v)
+	}
+	var tr *typedResolution
+	if cached, ok := i.typed.Load(typeKey[T]{}); ok {
+		tr = cached.(*typedResolution)
+	}
+	if version := i.registryVersion.Load(); tr == nil || tr.version != version {
+		t := reflect.TypeOf(v)
+		// The version is read before resolving, so an entry resolved
+		// concurrently with a registration is already stale.
+		tr = &typedResolution{version: version, t: t, r: i.resolve(t)}
+		i.typed.Store(typeKey[T]{}, tr)
+	}
+	e := i.wrapResolved(ctx, v, tr.t, tr.r)
+	i.failStrict(e)
+	return e
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestWrapT_MatchesWrap(t *testing.T) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{{Rel: "self", Href: "/users/" + itoa(u.ID)}}
+	})
+	RegisterStatic(inst, &TestData{}, []Link{{Rel: "self", Href: "/static"}})
+	RegisterInstance(inst, func(_ context.Context, v *ifaceDoc) []Link {
+		return []Link{{Rel: "self", Href: "/iface"}}
+	})
+	ctx := context.Background()
+
+	check := func(typed, plain *Envelope) {
+		t.Helper()
+		if got, want := marshalString(t, typed), marshalString(t, plain); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	}
+	for n := 0; n < 2; n++ { // Second pass uses the cache.
+		check(WrapInstanceT(ctx, inst, &collectionUser{ID: 1}), inst.Wrap(ctx, &collectionUser{ID: 1}))
+		check(WrapInstanceT(ctx, inst, &TestData{ID: 2}), inst.Wrap(ctx, &TestData{ID: 2}))
+		check(WrapInstanceT(ctx, inst, &ifaceNote{}), inst.Wrap(ctx, &ifaceNote{}))
+		check(WrapInstanceT(ctx, inst, &mergeOrder{ID: 3}), inst.Wrap(ctx, &mergeOrder{ID: 3}))
+	}
+
+	if got := marshalString(t, WrapT(NewContext(ctx, inst), &collectionUser{ID: 4})); got != `{"id":4,"_links":{"self":{"href":"/users/4"}}}` {
+		t.Fatalf("expected the context instance, got %s", got)
+	}
+}
+
+func TestWrapT_Invalidation(t *testing.T) {
+	inst := New()
+	ctx := context.Background()
+	if got := marshalString(t, WrapInstanceT(ctx, inst, &mergeOrder{ID: 1})); got != `{"id":1}` {
+		t.Fatalf("expected no links, got %s", got)
+	}
+
+	RegisterInstance(inst, func(_ context.Context, o *mergeOrder) []Link {
+		return []Link{{Rel: "self", Href: "/orders/" + itoa(o.ID)}}
+	})
+	if got := marshalString(t, WrapInstanceT(ctx, inst, &mergeOrder{ID: 1})); got != `{"id":1,"_links":{"self":{"href":"/orders/1"}}}` {
+		t.Fatalf("expected the new generator to be used, got %s", got)
+	}
+
+	RegisterStatic(inst, &mergeOrder{}, []Link{{Rel: "self", Href: "/static"}})
+	if got := marshalString(t, WrapInstanceT(ctx, inst, &mergeOrder{ID: 1})); got != `{"id":1,"_links":{"self":{"href":"/static"}}}` {
+		t.Fatalf("expected static links to be used, got %s", got)
+	}
+
+	inst.Unregister(reflect.TypeOf(&mergeOrder{}))
+	if got := marshalString(t, WrapInstanceT(ctx, inst, &mergeOrder{ID: 1})); got != `{"id":1}` {
+		t.Fatalf("expected Unregister to be seen, got %s", got)
+	}
+}
+
+func TestWrapT_Strict(t *testing.T) {
+	inst := New(WithStrictMode())
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrNoGenerator) {
+			t.Fatalf("expected a panic wrapping ErrNoGenerator, got %v", err)
+		}
+	}()
+	WrapInstanceT(context.Background(), inst, &mergeOrder{ID: 1})
+}
+
+func TestWrapT_Concurrent(t *testing.T) {
+	inst := New()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 200; n++ {
+			RegisterInstance(inst, func(_ context.Context, o *mergeOrder) []Link {
+				return []Link{{Rel: "self", Href: "/orders"}}
+			})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for n := 0; n < 200; n++ {
+			WrapInstanceT(context.Background(), inst, &mergeOrder{ID: n})
+		}
+	}()
+	wg.Wait()
+	if got := marshalString(t, WrapInstanceT(context.Background(), inst, &mergeOrder{ID: 1})); got != `{"id":1,"_links":{"self":{"href":"/orders"}}}` {
+		t.Fatalf("expected the registered generator after concurrent use, got %s", got)
+	}
+}
+
+func BenchmarkWrap_Reflect(b *testing.B) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, d *TestData) []Link {
+		return []Link{{Rel: "self", Href: "/users"}}
+	})
+	data := &TestData{ID: 42}
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	// Parallel, as the registry lock is shared by concurrent requests.
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			inst.Wrap(ctx, data)
+		}
+	})
+}
+
+func BenchmarkWrap_Typed(b *testing.B) {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, d *TestData) []Link {
+		return []Link{{Rel: "self", Href: "/users"}}
+	})
+	data := &TestData{ID: 42}
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	// Parallel, as the registry lock is shared by concurrent requests.
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			WrapInstanceT(ctx, inst, data)
+		}
+	})
+}
+
+func BenchmarkResolve_Reflect(b *testing.B) {
+	inst := New()
+	RegisterInstance(inst, func(context.Context, *TestData) []Link { return nil })
+	var data any = &TestData{}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		inst.resolve(inst.linkType(data))
+	}
+}
+
+func BenchmarkResolve_Typed(b *testing.B) {
+	inst := New()
+	RegisterInstance(inst, func(context.Context, *TestData) []Link { return nil })
+	WrapInstanceT(context.Background(), inst, &TestData{})
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		cached, _ := inst.typed.Load(typeKey[TestData]{})
+		if cached.(*typedResolution).version != inst.registryVersion.Load() {
+			b.Fatal("stale cache entry")
+		}
+	}
+}
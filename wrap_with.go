@@ -0,0 +1,171 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"reflect"
+
+	json "github.com/goccy/go-json"
+)
+
+// WrapOption adjusts a single WrapWith call without changing the instance.
+type WrapOption func(*wrapConfig)
+
+type wrapConfig struct {
+	skipGenerators bool
+	plain          bool
+	extraLinks     []Link
+	self           *Link
+	arrayRels      []string
+}
+
+// SkipGenerators wraps without running the registered generator or using
+// static links, for example for internal serialization. Instance hooks
+// (WithAfterWrap, WithTransformer) still run.
+func SkipGenerators() WrapOption {
+	return func(c *wrapConfig) {
+		c.skipGenerators = true
+	}
+}
+
+// PlainOutput serializes the envelope as its data alone, without _links or
+// _embedded, even if links are added later. No generator runs.
+func PlainOutput() WrapOption {
+	return func(c *wrapConfig) {
+		c.skipGenerators = true
+		c.plain = true
+	}
+}
+
+// ExtraLinks adds links after the generator output, as if AddLink had been
+// called for each.
+func ExtraLinks(links ...Link) WrapOption {
+	return func(c *wrapConfig) {
+		c.extraLinks = append(c.extraLinks, links...)
+	}
+}
+
+// OverrideSelf replaces any self link produced by the generator with self.
+func OverrideSelf(self Link) WrapOption {
+	return func(c *wrapConfig) {
+		self.Rel = "self"
+		c.self = &self
+	}
+}
+
+// EmbedAsArray makes the listed embedded rels serialize as arrays for this
+// envelope, like WithArrayEmbeddedRels does for the whole instance.
+func EmbedAsArray(rels ...string) WrapOption {
+	return func(c *wrapConfig) {
+		c.arrayRels = append(c.arrayRels, rels...)
+	}
+}
+
+// WrapItems applies opts to every item envelope built by Collection.
+//
+// # Example
+//
+//	page := inst.Collection(ctx, users, total, self, hal.WrapItems(hal.SkipGenerators()))
+func WrapItems(opts ...WrapOption) CollectionOption {
+	return func(c *collectionConfig) {
+		c.wrapOpts = append(c.wrapOpts, opts...)
+	}
+}
+
+// WrapWith wraps data like Wrap, adjusted by opts for this envelope only.
+//
+// # Example
+//
+//	env := inst.WrapWith(ctx, user,
+//	    hal.ExtraLinks(hal.Link{Rel: "audit", Href: "/audit/42"}),
+//	    hal.OverrideSelf(hal.Link{Href: "/me"}),
+//	)
+func (i *Instance) WrapWith(ctx context.Context, data any, opts ...WrapOption) *Envelope {
+	e := i.wrapWith(ctx, data, opts)
+	i.failStrict(e)
+	return e
+}
+
+// wrapWith implements WrapWith, recording strict mode failures like wrap.
+func (i *Instance) wrapWith(ctx context.Context, data any, opts []WrapOption) *Envelope {
+	if len(opts) == 0 {
+		return i.wrap(ctx, data)
+	}
+	var cfg wrapConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var e *Envelope
+	if cfg.skipGenerators {
+		e = &Envelope{
+			Data:     data,
+			instance: i,
+			links:    make(map[string]any, defaultLinksCapacity),
+			baseURL:  i.baseURLFor(ctx),
+			rewriter: i.rewriterFor(ctx),
+			plain:    cfg.plain,
+		}
+		i.finishWrap(ctx, e)
+	} else {
+		e = i.wrap(ctx, data)
+	}
+
+	if cfg.self != nil || len(cfg.extraLinks) > 0 {
+		e.materializePrecomputed()
+	}
+	if cfg.self != nil {
+		delete(e.links, "self")
+		e.AddLink(*cfg.self)
+	}
+	for _, l := range cfg.extraLinks {
+		e.AddLink(l)
+	}
+	if len(cfg.arrayRels) > 0 {
+		e.arrayRels = make(map[string]struct{}, len(cfg.arrayRels))
+		for _, rel := range cfg.arrayRels {
+			e.arrayRels[rel] = struct{}{}
+		}
+	}
+	return e
+}
+
+// materializePrecomputed turns precomputed links into regular links so they
+// can be modified. Precomputed links are kept as raw JSON values.
+func (e *Envelope) materializePrecomputed() {
+	if e.precomputedJSON == nil {
+		return
+	}
+	var doc struct {
+		Links map[string]json.RawMessage `json:"_links"`
+	}
+	// precomputedJSON is produced by this package and always valid.
+	_ = json.Unmarshal(e.precomputedJSON, &doc)
+	e.links = make(map[string]any, len(doc.Links)+defaultLinksCapacity)
+	for rel, raw := range doc.Links {
+		e.links[rel] = raw
+	}
+	e.precomputedJSON = nil
+}
+
+// collectionItemWrapper returns the function wrapping collection items of
+// the slice element type elem. For a concrete elem the registrations are
+// looked up once for the whole collection; interface elements, which may
+// hold values of mixed types, are resolved per item as by Wrap. Strict mode
+// failures are recorded on the envelope, for wrapItem to handle.
+func (i *Instance) collectionItemWrapper(elem reflect.Type, opts []WrapOption) func(context.Context, any) *Envelope {
+	if len(opts) == 0 {
+		if elem.Kind() == reflect.Interface || i.autoDeref {
+			return i.wrap
+		}
+		r := i.resolve(elem)
+		return func(ctx context.Context, item any) *Envelope {
+			return i.wrapResolved(ctx, item, elem, r)
+		}
+	}
+	return func(ctx context.Context, item any) *Envelope {
+		return i.wrapWith(ctx, item, opts)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025-2026 Emin Salih Açıkgöz
+// SPDX-License-Identifier: MIT
+
+package hal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func wrapWithInstance() *Instance {
+	inst := New()
+	RegisterInstance(inst, func(_ context.Context, u *collectionUser) []Link {
+		return []Link{
+			{Rel: "self", Href: "/users/" + itoa(u.ID)},
+			{Rel: "orders", Href: "/users/" + itoa(u.ID) + "/orders"},
+		}
+	})
+	return inst
+}
+
+func marshalEnvelope(t *testing.T, env *Envelope) string {
+	t.Helper()
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestWrapWith_SkipGeneratorsLeavesDataPristine(t *testing.T) {
+	env := wrapWithInstance().WrapWith(context.Background(), &collectionUser{ID: 1}, SkipGenerators())
+
+	if got := marshalEnvelope(t, env); got != wantUserID1 {
+		t.Fatalf("expected %s, got %s", wantUserID1, got)
+	}
+
+	// Links added explicitly are still emitted.
+	env.AddLink(Link{Rel: "self", Href: "/internal/1"})
+	if got := marshalEnvelope(t, env); got != `{"id":1,"_links":{"self":{"href":"/internal/1"}}}` {
+		t.Fatalf("expected explicit link, got %s", got)
+	}
+}
+
+func TestWrapWith_ExtraLinksMergeWithGeneratorOutput(t *testing.T) {
+	env := wrapWithInstance().WrapWith(context.Background(), &collectionUser{ID: 1},
+		ExtraLinks(Link{Rel: "orders", Href: "/archive/1/orders"}, Link{Rel: "audit", Href: "/audit/1"}),
+	)
+
+	want := `{"id":1,"_links":{"audit":{"href":"/audit/1"},` +
+		`"orders":[{"href":"/users/1/orders"},{"href":"/archive/1/orders"}],"self":{"href":"/users/1"}}}`
+	if got := marshalEnvelope(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestWrapWith_OverrideSelf(t *testing.T) {
+	env := wrapWithInstance().WrapWith(context.Background(), &collectionUser{ID: 1},
+		OverrideSelf(Link{Href: "/me"}),
+	)
+
+	want := `{"id":1,"_links":{"orders":{"href":"/users/1/orders"},"self":{"href":"/me"}}}`
+	if got := marshalEnvelope(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestWrapWith_OverrideSelfOnStaticLinks(t *testing.T) {
+	inst := New()
+	RegisterStatic(inst, &collectionUser{}, []Link{{Rel: "self", Href: "/users"}, {Rel: "up", Href: "/"}})
+
+	env := inst.WrapWith(context.Background(), &collectionUser{ID: 1}, OverrideSelf(Link{Href: "/me"}))
+
+	want := `{"id":1,"_links":{"self":{"href":"/me"},"up":{"href":"/"}}}`
+	if got := marshalEnvelope(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestWrapWith_PlainOutput(t *testing.T) {
+	ctx := context.Background()
+	env := wrapWithInstance().WrapWith(ctx, &collectionUser{ID: 1}, PlainOutput())
+	env.AddLink(Link{Rel: "self", Href: "/users/1"})
+	env.AddEmbedded(ctx, "friend", &collectionUser{ID: 2})
+
+	if got := marshalEnvelope(t, env); got != wantUserID1 {
+		t.Fatalf("expected plain data, got %s", got)
+	}
+}
+
+func TestWrapWith_EmbedAsArray(t *testing.T) {
+	ctx := context.Background()
+	inst := New()
+
+	env := inst.WrapWith(ctx, &collectionUser{ID: 1}, EmbedAsArray("friends"))
+	env.AddEmbedded(ctx, "friends", &collectionUser{ID: 2})
+	want := `{"id":1,"_embedded":{"friends":[{"id":2}]}}`
+	if got := marshalEnvelope(t, env); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	// Other envelopes of the instance keep the default shape.
+	other := inst.Wrap(ctx, &collectionUser{ID: 1})
+	other.AddEmbedded(ctx, "friends", &collectionUser{ID: 2})
+	if got := marshalEnvelope(t, other); got != `{"id":1,"_embedded":{"friends":{"id":2}}}` {
+		t.Fatalf("expected single object, got %s", got)
+	}
+}
+
+func TestWrapItems_AppliesToCollectionItems(t *testing.T) {
+	page := wrapWithInstance().Collection(context.Background(), []*collectionUser{{ID: 1}, {ID: 2}}, 2,
+		Link{Rel: "self", Href: "/users"},
+		WrapItems(SkipGenerators(), ExtraLinks(Link{Rel: "up", Href: "/users"})),
+	)
+
+	b, err := json.Marshal(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"_links":{"self":{"href":"/users"}},"_embedded":{"items":[` +
+		`{"id":1,"_links":{"up":{"href":"/users"}}},{"id":2,"_links":{"up":{"href":"/users"}}}]},"count":2,"total":2}`
+	if string(b) != want {
+		t.Fatalf("expected %s, got %s", want, b)
+	}
+}